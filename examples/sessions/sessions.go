@@ -0,0 +1,167 @@
+// Package sessions is an example of an expiring HTTP session store built on skip lists. The sessions are kept
+// in a skiplist.DualIndex mapping session IDs to their expiry, which provides the lookup by ID as well as the
+// order by expiry: expired sessions are found at the front of the expiry order and removed in O(log(n)) each
+// without scanning all sessions. The store follows the interface of gorilla/sessions (Get, New, Save) and can be
+// used from multiple goroutines.
+package sessions
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"log"
+	"maps"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// Session is the state of a client stored between requests.
+type Session struct {
+	ID      string
+	Name    string         // name of the cookie holding the ID
+	Values  map[string]any // values of the session, saved by Store.Save
+	Expires time.Time      // expiry after the last save
+	IsNew   bool           // the session was created by this request
+}
+
+// Store is an in-memory session store whose sessions expire `maxAge` after they were saved last.
+type Store struct {
+	mu     sync.Mutex
+	maxAge time.Duration
+	clock  func() time.Time
+	expiry *skiplist.DualIndex[string, int64] // session ID -> expiry in Unix nanoseconds, ordered by expiry
+	values map[string]map[string]any          // values by session ID
+}
+
+// NewStore creates a Store whose sessions expire after `maxAge`. A nil clock uses time.Now.
+func NewStore(maxAge time.Duration, clock func() time.Time) *Store {
+	if maxAge <= 0 {
+		log.Panic("Parameter maxAge out of range (must be > 0)")
+	}
+	if clock == nil {
+		clock = time.Now
+	}
+	return &Store{
+		maxAge: maxAge,
+		clock:  clock,
+		expiry: skiplist.NewDualIndex[string, int64](),
+		values: make(map[string]map[string]any),
+	}
+}
+
+// Get returns the session referenced by the cookie `name` of the request or a new session if there is no
+// valid one. The values of the session are a copy; modifications are stored by Save.
+func (st *Store) Get(r *http.Request, name string) (*Session, error) {
+	if c, err := r.Cookie(name); err == nil {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		st.expire()
+		if expires, ok := st.expiry.Get(c.Value); ok {
+			return &Session{
+				ID:      c.Value,
+				Name:    name,
+				Values:  maps.Clone(st.values[c.Value]),
+				Expires: time.Unix(0, expires),
+			}, nil
+		}
+	}
+	return st.New(r, name)
+}
+
+// New returns a new session with a random ID, which is stored by the first Save.
+func (st *Store) New(_ *http.Request, name string) (*Session, error) {
+	var id [24]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	return &Session{
+		ID:     base64.RawURLEncoding.EncodeToString(id[:]),
+		Name:   name,
+		Values: make(map[string]any),
+		IsNew:  true,
+	}, nil
+}
+
+// Save stores the values of the session, extends its expiry, and sets its cookie on the response.
+func (st *Store) Save(_ *http.Request, w http.ResponseWriter, s *Session) error {
+	st.mu.Lock()
+	s.Expires = st.clock().Add(st.maxAge)
+	st.expiry.Set(s.ID, s.Expires.UnixNano())
+	st.values[s.ID] = maps.Clone(s.Values)
+	st.mu.Unlock()
+	http.SetCookie(w, &http.Cookie{Name: s.Name, Value: s.ID, Path: "/", Expires: s.Expires, HttpOnly: true,
+		SameSite: http.SameSiteLaxMode})
+	return nil
+}
+
+// Delete removes the session and clears its cookie.
+func (st *Store) Delete(w http.ResponseWriter, s *Session) {
+	st.mu.Lock()
+	st.expiry.Remove(s.ID)
+	delete(st.values, s.ID)
+	st.mu.Unlock()
+	http.SetCookie(w, &http.Cookie{Name: s.Name, Value: "", Path: "/", MaxAge: -1})
+}
+
+// Len returns the number of stored sessions including expired ones not yet removed.
+func (st *Store) Len() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.expiry.Size()
+}
+
+// Expire removes all expired sessions and returns their number. Expired sessions are also removed by Get, so
+// calling Expire periodically only releases the memory of abandoned sessions earlier.
+func (st *Store) Expire() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.expire()
+}
+
+func (st *Store) expire() int {
+	now := st.clock().UnixNano()
+	var ids []string
+	st.expiry.RangeByValue(math.MinInt64, now, func(id string, _ int64) bool {
+		ids = append(ids, id)
+		return true
+	})
+	for _, id := range ids {
+		st.expiry.Remove(id)
+		delete(st.values, id)
+	}
+	return len(ids)
+}
+
+type contextKey struct{}
+
+// Middleware loads the session of the cookie `name` for every request, refreshes its expiry and cookie, and
+// passes it to the handler in the request context (see FromContext). Values modified by the handler are saved
+// after it returns.
+func (st *Store) Middleware(name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, err := st.Get(r, name)
+		if err == nil {
+			err = st.Save(r, w, s)
+		}
+		if err != nil {
+			http.Error(w, "session unavailable", http.StatusInternalServerError)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, s)))
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		if _, ok := st.expiry.Get(s.ID); ok {
+			st.values[s.ID] = maps.Clone(s.Values)
+		}
+	})
+}
+
+// FromContext returns the session passed by Middleware or nil.
+func FromContext(ctx context.Context) *Session {
+	s, _ := ctx.Value(contextKey{}).(*Session)
+	return s
+}
@@ -0,0 +1,97 @@
+package sessions
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	now := time.Unix(1000, 0)
+	st := NewStore(time.Minute, func() time.Time { return now })
+
+	s, err := st.Get(httptest.NewRequest("GET", "/", nil), "sid")
+	require.NoError(t, err)
+	assert.True(t, s.IsNew)
+	s.Values["user"] = "alice"
+	w := httptest.NewRecorder()
+	require.NoError(t, st.Save(nil, w, s))
+	cookie := w.Result().Cookies()[0]
+	assert.Equal(t, s.ID, cookie.Value)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(cookie)
+	loaded, err := st.Get(r, "sid")
+	require.NoError(t, err)
+	assert.False(t, loaded.IsNew)
+	assert.Equal(t, "alice", loaded.Values["user"])
+	assert.Equal(t, now.Add(time.Minute), loaded.Expires)
+
+	// the values are copies
+	loaded.Values["user"] = "bob"
+	again, _ := st.Get(r, "sid")
+	assert.Equal(t, "alice", again.Values["user"])
+
+	// the session expires a minute after the last save
+	now = now.Add(time.Minute)
+	expired, err := st.Get(r, "sid")
+	require.NoError(t, err)
+	assert.True(t, expired.IsNew)
+	assert.Equal(t, 0, st.Len())
+}
+
+func TestExpire(t *testing.T) {
+	now := time.Unix(1000, 0)
+	st := NewStore(time.Minute, func() time.Time { return now })
+	for i := 0; i < 10; i++ {
+		s, _ := st.New(nil, "sid")
+		require.NoError(t, st.Save(nil, httptest.NewRecorder(), s))
+		now = now.Add(10 * time.Second)
+	}
+	assert.Equal(t, 10, st.Len())
+	// saved at 1000, 1010, ..., 1090; now is 1100
+	assert.Equal(t, 5, st.Expire())
+	assert.Equal(t, 5, st.Len())
+
+	s, _ := st.New(nil, "sid")
+	w := httptest.NewRecorder()
+	require.NoError(t, st.Save(nil, w, s))
+	st.Delete(w, s)
+	assert.Equal(t, 5, st.Len())
+	assert.Panics(t, func() { NewStore(0, nil) })
+}
+
+func TestMiddleware(t *testing.T) {
+	st := NewStore(time.Hour, nil)
+	handler := st.Middleware("sid", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := FromContext(r.Context())
+		n, _ := s.Values["visits"].(int)
+		s.Values["visits"] = n + 1
+		fmt.Fprint(w, n+1)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, "1", w.Body.String())
+	cookie := w.Result().Cookies()[0]
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := httptest.NewRequest("GET", "/", nil)
+			r.AddCookie(cookie)
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, st.Len())
+	assert.Nil(t, FromContext(httptest.NewRequest("GET", "/", nil).Context()))
+}
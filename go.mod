@@ -1,8 +1,11 @@
 module github.com/andremueller/goskiplist
 
-go 1.21.5
+go 1.23
 
-require github.com/stretchr/testify v1.9.0
+require (
+	github.com/google/flatbuffers v25.12.19+incompatible
+	github.com/stretchr/testify v1.9.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,282 @@
+// Package hashring implements a consistent hashing ring with virtual nodes on top of a skip list. Every node
+// places a number of points on the ring of 64 bit hashes; a key is owned by the node of the first point at or
+// after the hash of the key, wrapping around to the first point. Adding or removing a node moves only the keys
+// of the arcs before its points, which are reported as a MoveSet.
+package hashring
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// ErrNodeExists is returned (wrapped) when a node is added which is already part of the ring.
+var ErrNodeExists = errors.New("hashring: node exists")
+
+// HashFunc maps a key or the label of a virtual node to a point of the ring.
+type HashFunc func(s string) uint64
+
+// Move is the change of the owner of an arc of the ring: the keys with a hash h in (Start, End] move from the
+// node From to the node To. The arc wraps around past the largest hash if End <= Start.
+type Move struct {
+	Start, End uint64
+	From, To   string
+}
+
+// Share returns the fraction of the hash space covered by the arc.
+func (m Move) Share() float64 {
+	if m.Start == m.End {
+		return 1
+	}
+	return float64(m.End-m.Start) / (1 << 64)
+}
+
+// MoveSet holds the arcs changing their owner by a membership change in the order of the ring.
+type MoveSet []Move
+
+// Share returns the fraction of the hash space moved.
+func (ms MoveSet) Share() float64 {
+	share := 0.0
+	for _, m := range ms {
+		share += m.Share()
+	}
+	return share
+}
+
+// Contains reports whether the key with the hash h moves and returns its move.
+func (ms MoveSet) Contains(h uint64) (Move, bool) {
+	for _, m := range ms {
+		if h-m.Start-1 < m.End-m.Start || m.Start == m.End {
+			return m, true
+		}
+	}
+	return Move{}, false
+}
+
+// Stats holds the rebalancing statistics of a ring.
+type Stats struct {
+	Nodes      int     // number of nodes
+	Points     int     // number of points on the ring
+	Collisions int     // points not placed because another node occupies the same hash
+	Rebalances int     // number of membership changes
+	Moves      int     // number of moved arcs of all membership changes
+	MovedShare float64 // moved fraction of the hash space summed over all membership changes
+}
+
+// Ring is a consistent hashing ring. Its methods can be used from multiple goroutines.
+type Ring struct {
+	mu     sync.RWMutex
+	hash   HashFunc
+	points *skiplist.SkipList[uint64, string] // node names by their points
+	nodes  map[string][]uint64                // points of the nodes
+	stats  Stats
+}
+
+// New creates an empty Ring hashing by `hash` or by a mixed 64 bit FNV-1a if hash is nil. All members of a
+// cluster must use the same hash function.
+func New(hash HashFunc) *Ring {
+	if hash == nil {
+		hash = defaultHash
+	}
+	return &Ring{
+		hash:   hash,
+		points: skiplist.NewSkipList[uint64, string](),
+		nodes:  make(map[string][]uint64),
+	}
+}
+
+// defaultHash is FNV-1a followed by the finalizer of SplitMix64, which spreads similar labels of virtual nodes.
+func defaultHash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	x := h.Sum64()
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// AddNode adds the node `name` with `vnodes` points on the ring, which are the hashes of "name#0" ... Points
+// occupied by other nodes are skipped. Returns the arcs taken over by the new node.
+func (r *Ring) AddNode(name string, vnodes int) (MoveSet, error) {
+	if vnodes <= 0 {
+		log.Panic("Parameter vnodes out of range (must be > 0)")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.nodes[name]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrNodeExists, name)
+	}
+	points := make([]uint64, 0, vnodes)
+	for i := 0; i < vnodes; i++ {
+		h := r.hash(name + "#" + strconv.Itoa(i))
+		if x, _ := r.points.Get(h); x != nil {
+			r.stats.Collisions++
+			continue
+		}
+		r.points.Set(h, name)
+		points = append(points, h)
+	}
+	r.nodes[name] = points
+	moves := r.moves(name)
+	for i := range moves {
+		moves[i].From, moves[i].To = moves[i].To, name
+	}
+	r.rebalanced(moves)
+	return moves, nil
+}
+
+// RemoveNode removes the node `name` and its points. Returns the arcs handed over to the remaining nodes and
+// false if the node was not found.
+func (r *Ring) RemoveNode(name string) (MoveSet, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	points, ok := r.nodes[name]
+	if !ok {
+		return nil, false
+	}
+	moves := r.moves(name)
+	for i := range moves {
+		moves[i].From = name
+	}
+	for _, h := range points {
+		r.points.Remove(h)
+	}
+	delete(r.nodes, name)
+	r.rebalanced(moves)
+	return moves, true
+}
+
+// moves returns the arcs ending at the points of `name` merged into maximal runs. The To field holds the owner
+// of the arc if name was missing, which is empty if there is no other node.
+func (r *Ring) moves(name string) MoveSet {
+	n := r.points.Size()
+	if n == len(r.nodes[name]) {
+		return nil
+	}
+	var moves MoveSet
+	for x := r.points.First(); x != nil; x = x.Next() {
+		if x.Value != name {
+			continue
+		}
+		_, pos := r.points.Get(x.Key())
+		start := r.points.GetByPos((pos + n - 1) % n)
+		if len(moves) > 0 && moves[len(moves)-1].End == start.Key() && start.Value == name {
+			moves[len(moves)-1].End = x.Key()
+			continue
+		}
+		moves = append(moves, Move{Start: start.Key(), End: x.Key(), To: r.successor(pos, name)})
+	}
+	// merge a run wrapping around from the last to the first point
+	if len(moves) > 1 && moves[len(moves)-1].End == moves[0].Start {
+		moves[0].Start = moves[len(moves)-1].Start
+		moves = moves[:len(moves)-1]
+	}
+	return moves
+}
+
+// successor returns the owner of the first point after the position pos which does not belong to `name`.
+func (r *Ring) successor(pos int, name string) string {
+	n := r.points.Size()
+	x := r.points.GetByPos(pos)
+	for i := 0; i < n; i++ {
+		if x = x.Next(); x == nil {
+			x = r.points.First()
+		}
+		if x.Value != name {
+			return x.Value
+		}
+	}
+	return ""
+}
+
+// rebalanced records a membership change in the statistics.
+func (r *Ring) rebalanced(moves MoveSet) {
+	r.stats.Rebalances++
+	r.stats.Moves += len(moves)
+	r.stats.MovedShare += moves.Share()
+}
+
+// Owner returns the node owning `key` and false if the ring is empty.
+func (r *Ring) Owner(key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	x := r.points.LookupOwner(r.hash(key))
+	if x == nil {
+		return "", false
+	}
+	return x.Value, true
+}
+
+// Owners returns up to n distinct nodes following the hash of `key` on the ring, e.g. the replicas of a key.
+// The first node is the owner.
+func (r *Ring) Owners(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n = min(n, len(r.nodes))
+	var owners []string
+	x := r.points.LookupOwner(r.hash(key))
+	for i := 0; i < r.points.Size() && len(owners) < n; i++ {
+		if !slices.Contains(owners, x.Value) {
+			owners = append(owners, x.Value)
+		}
+		if x = x.Next(); x == nil {
+			x = r.points.First()
+		}
+	}
+	return owners
+}
+
+// Nodes returns the number of nodes.
+func (r *Ring) Nodes() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.nodes)
+}
+
+// Shares returns the fraction of the hash space owned by every node, which shows the balance of the ring.
+func (r *Ring) Shares() map[string]float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	shares := make(map[string]float64, len(r.nodes))
+	if r.points.Size() == 1 {
+		shares[r.points.First().Value] = 1
+		return shares
+	}
+	prev := r.points.GetByPos(r.points.Size() - 1)
+	for x := r.points.First(); x != nil; x = x.Next() {
+		shares[x.Value] += float64(x.Key()-prev.Key()) / (1 << 64)
+		prev = x
+	}
+	return shares
+}
+
+// Imbalance returns the ratio of the largest share of a node to the mean share, 1 for a perfectly balanced
+// ring, or 0 if the ring is empty.
+func (r *Ring) Imbalance() float64 {
+	shares := r.Shares()
+	if len(shares) == 0 {
+		return 0
+	}
+	largest := 0.0
+	for _, share := range shares {
+		largest = math.Max(largest, share)
+	}
+	return largest * float64(len(shares))
+}
+
+// Stats returns the rebalancing statistics.
+func (r *Ring) Stats() Stats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stats := r.stats
+	stats.Nodes = len(r.nodes)
+	stats.Points = r.points.Size()
+	return stats
+}
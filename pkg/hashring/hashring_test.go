@@ -0,0 +1,116 @@
+package hashring
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func owners(r *Ring, keys int) map[string]string {
+	m := make(map[string]string, keys)
+	for k := 0; k < keys; k++ {
+		key := "key" + strconv.Itoa(k)
+		m[key], _ = r.Owner(key)
+	}
+	return m
+}
+
+// checkMoves checks that exactly the keys covered by the moves changed their owner as stated by the moves.
+func checkMoves(t *testing.T, r *Ring, before, after map[string]string, moves MoveSet) {
+	t.Helper()
+	for key, owner := range before {
+		m, moved := moves.Contains(r.hash(key))
+		if moved {
+			assert.Equal(t, owner, m.From, key)
+			assert.Equal(t, after[key], m.To, key)
+		} else {
+			assert.Equal(t, owner, after[key], key)
+		}
+	}
+}
+
+func TestRing(t *testing.T) {
+	r := New(nil)
+	_, ok := r.Owner("a")
+	assert.False(t, ok)
+
+	moves, err := r.AddNode("n1", 50)
+	require.NoError(t, err)
+	assert.Empty(t, moves)
+	owner, ok := r.Owner("a")
+	assert.True(t, ok)
+	assert.Equal(t, "n1", owner)
+	_, err = r.AddNode("n1", 10)
+	assert.ErrorIs(t, err, ErrNodeExists)
+
+	for _, name := range []string{"n2", "n3", "n4"} {
+		before := owners(r, 2000)
+		moves, err := r.AddNode(name, 50)
+		require.NoError(t, err)
+		assert.NotEmpty(t, moves)
+		checkMoves(t, r, before, owners(r, 2000), moves)
+	}
+	assert.Equal(t, 4, r.Nodes())
+	assert.Less(t, r.Imbalance(), 1.5)
+	total := 0.0
+	for _, share := range r.Shares() {
+		total += share
+	}
+	assert.InDelta(t, 1, total, 1e-9)
+
+	before := owners(r, 2000)
+	moves, ok = r.RemoveNode("n2")
+	assert.True(t, ok)
+	after := owners(r, 2000)
+	checkMoves(t, r, before, after, moves)
+	assert.InDelta(t, 0.25, moves.Share(), 0.1)
+	for _, owner := range after {
+		assert.NotEqual(t, "n2", owner)
+	}
+	_, ok = r.RemoveNode("n2")
+	assert.False(t, ok)
+
+	stats := r.Stats()
+	assert.Equal(t, 3, stats.Nodes)
+	assert.Equal(t, 150-stats.Collisions, stats.Points)
+	assert.Equal(t, 5, stats.Rebalances)
+	assert.Greater(t, stats.MovedShare, 0.5)
+}
+
+func TestOwners(t *testing.T) {
+	r := New(nil)
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := r.AddNode(name, 20)
+		require.NoError(t, err)
+	}
+	replicas := r.Owners("key", 2)
+	assert.Len(t, replicas, 2)
+	assert.NotEqual(t, replicas[0], replicas[1])
+	owner, _ := r.Owner("key")
+	assert.Equal(t, owner, replicas[0])
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, r.Owners("key", 5))
+}
+
+func TestMoveWrapAround(t *testing.T) {
+	m := Move{Start: 1<<64 - 10, End: 10}
+	_, ok := MoveSet{m}.Contains(5)
+	assert.True(t, ok)
+	_, ok = MoveSet{m}.Contains(1<<64 - 10)
+	assert.False(t, ok)
+	_, ok = MoveSet{m}.Contains(11)
+	assert.False(t, ok)
+	assert.InDelta(t, 20.0/(1<<64), m.Share(), 1e-25)
+
+	// a single remaining point owns the whole ring
+	r := New(func(s string) uint64 { return uint64(len(s)) })
+	_, err := r.AddNode("a", 1)
+	require.NoError(t, err)
+	moves, err := r.AddNode("bb", 1)
+	require.NoError(t, err)
+	assert.Equal(t, MoveSet{{Start: 3, End: 4, From: "a", To: "bb"}}, moves)
+	moves, _ = r.RemoveNode("a")
+	assert.Equal(t, MoveSet{{Start: 4, End: 3, From: "a", To: "bb"}}, moves)
+	assert.Equal(t, map[string]float64{"bb": 1}, r.Shares())
+}
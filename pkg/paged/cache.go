@@ -0,0 +1,79 @@
+package paged
+
+import "container/list"
+
+// CacheStats counts the accesses of the page cache of a List.
+type CacheStats struct {
+	Hits      int `json:"hits"`
+	Misses    int `json:"misses"`
+	Evictions int `json:"evictions"`
+	Cached    int `json:"cached"` // number of cached nodes
+}
+
+// cache holds the recently used nodes in LRU order. Modified nodes are written back to the store when they
+// are evicted.
+type cache struct {
+	capacity int
+	entries  map[PageID]*list.Element
+	lru      list.List // of *node, most recently used first
+	stats    CacheStats
+	evict    func(n *node) error // writes back an evicted node
+}
+
+func newCache(capacity int, evict func(n *node) error) *cache {
+	return &cache{capacity: capacity, entries: map[PageID]*list.Element{}, evict: evict}
+}
+
+// get returns the cached node id or nil.
+func (c *cache) get(id PageID) *node {
+	e, ok := c.entries[id]
+	if !ok {
+		c.stats.Misses++
+		return nil
+	}
+	c.stats.Hits++
+	c.lru.MoveToFront(e)
+	return e.Value.(*node)
+}
+
+// put adds or replaces the node n and evicts the least recently used nodes beyond the capacity.
+func (c *cache) put(n *node) error {
+	if e, ok := c.entries[n.id]; ok {
+		e.Value = n
+		c.lru.MoveToFront(e)
+		return nil
+	}
+	c.entries[n.id] = c.lru.PushFront(n)
+	for c.lru.Len() > c.capacity {
+		e := c.lru.Back()
+		old := e.Value.(*node)
+		if old.dirty {
+			if err := c.evict(old); err != nil {
+				return err
+			}
+		}
+		c.lru.Remove(e)
+		delete(c.entries, old.id)
+		c.stats.Evictions++
+	}
+	return nil
+}
+
+// remove drops the node id without writing it back.
+func (c *cache) remove(id PageID) {
+	if e, ok := c.entries[id]; ok {
+		c.lru.Remove(e)
+		delete(c.entries, id)
+	}
+}
+
+// dirty returns the modified nodes.
+func (c *cache) dirty() []*node {
+	var nodes []*node
+	for e := c.lru.Front(); e != nil; e = e.Next() {
+		if n := e.Value.(*node); n.dirty {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
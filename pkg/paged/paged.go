@@ -0,0 +1,378 @@
+// Package paged is an experimental skip list stored in pages of a PageStore (in memory, in a file, or any other
+// storage), so it can hold more data than fits into memory. Every node occupies one page and its tower
+// references the following nodes by their page IDs. Recently used nodes are kept decoded in an LRU cache;
+// modified nodes are written back when they are evicted or by Flush.
+//
+// Keys and values are byte slices compared by bytes.Compare; typed keys can be encoded with an
+// order-preserving codec like skiplist.IntCodec. Unlike skiplist.SkipList, the paged list has no positional
+// operations, and it must not be used from multiple goroutines concurrently.
+package paged
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+)
+
+const (
+	// MaxLevel is the maximum level of the nodes.
+	MaxLevel = 24
+	// DefaultCacheSize is the default number of cached nodes.
+	DefaultCacheSize = 4096
+	// probability for increasing the level of a node
+	probability = 0.25
+	// minPageSize fits the head page with a tower of MaxLevel
+	minPageSize = 256
+	// headMagic identifies the head page
+	headMagic = "PSL1"
+	// headID is the page of the head
+	headID PageID = 0
+)
+
+var (
+	// ErrTooLarge is returned (wrapped) when a node does not fit into a page.
+	ErrTooLarge = errors.New("paged: node exceeds the page size")
+	// ErrCorrupted is returned (wrapped) when a page cannot be decoded.
+	ErrCorrupted = errors.New("paged: corrupted page")
+)
+
+// Option configures a List.
+type Option func(c *config)
+
+type config struct {
+	cacheSize int
+}
+
+// WithCacheSize overrides the DefaultCacheSize, the number of nodes kept in memory.
+func WithCacheSize(nodes int) Option {
+	if nodes < 1 {
+		log.Panic("Parameter nodes out of range (must be >= 1)")
+	}
+	return func(c *config) {
+		c.cacheSize = nodes
+	}
+}
+
+// node is a decoded node page. The head has the ID 0 and no key.
+type node struct {
+	id    PageID
+	key   []byte
+	value []byte
+	next  []PageID // 0 terminates a level
+	dirty bool     // modified since it was read or written
+}
+
+// List is a skip list stored in a PageStore.
+type List struct {
+	store PageStore
+	cache *cache
+	head  *node // always in memory
+	count int
+}
+
+// Open opens the list stored in store or creates an empty one if the store has no head page.
+func Open(store PageStore, options ...Option) (*List, error) {
+	cfg := config{cacheSize: DefaultCacheSize}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	if store.PageSize() < minPageSize {
+		log.Panicf("PageSize of the store out of range (must be >= %d)", minPageSize)
+	}
+	l := &List{store: store}
+	l.cache = newCache(cfg.cacheSize, l.write)
+	data, err := store.Read(headID)
+	if errors.Is(err, ErrPageNotFound) {
+		l.head = &node{id: headID, dirty: true}
+		return l, l.Flush()
+	} else if err != nil {
+		return nil, err
+	}
+	if l.head, l.count, err = decodeHead(data); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Len returns the number of elements.
+func (l *List) Len() int {
+	return l.count
+}
+
+// Get returns the value of `key` and true or nil and false if the key was not found. The value must not be
+// modified.
+func (l *List) Get(key []byte) ([]byte, bool, error) {
+	x, err := l.lowerBound(key, nil)
+	if err != nil || x == nil || !bytes.Equal(x.key, key) {
+		return nil, false, err
+	}
+	return x.value, true, nil
+}
+
+// Set sets the value of `key`. Returns true if the key was added. Key and value are copied.
+func (l *List) Set(key, value []byte) (bool, error) {
+	update := make([]*node, len(l.head.next), MaxLevel)
+	x, err := l.lowerBound(key, update)
+	if err != nil {
+		return false, err
+	}
+	if x != nil && bytes.Equal(x.key, key) {
+		if encodedSize(key, value, len(x.next)) > l.store.PageSize() {
+			return false, fmt.Errorf("%w: value of %d bytes", ErrTooLarge, len(value))
+		}
+		x.value = bytes.Clone(value)
+		return false, l.modified(x)
+	}
+
+	level := randomLevel()
+	if encodedSize(key, value, level) > l.store.PageSize() {
+		return false, fmt.Errorf("%w: key and value of %d bytes", ErrTooLarge, len(key)+len(value))
+	}
+	id, err := l.store.Allocate()
+	if err != nil {
+		return false, err
+	}
+	for len(l.head.next) < level {
+		l.head.next = append(l.head.next, 0)
+		update = append(update, l.head)
+	}
+	n := &node{id: id, key: bytes.Clone(key), value: bytes.Clone(value), next: make([]PageID, level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = id
+		if err := l.modified(update[i]); err != nil {
+			return false, err
+		}
+	}
+	l.count++
+	l.head.dirty = true
+	return true, l.modified(n)
+}
+
+// Delete removes `key` and returns true if it was found.
+func (l *List) Delete(key []byte) (bool, error) {
+	update := make([]*node, len(l.head.next))
+	x, err := l.lowerBound(key, update)
+	if err != nil || x == nil || !bytes.Equal(x.key, key) {
+		return false, err
+	}
+	for i := range x.next {
+		update[i].next[i] = x.next[i]
+		if err := l.modified(update[i]); err != nil {
+			return false, err
+		}
+	}
+	for len(l.head.next) > 0 && l.head.next[len(l.head.next)-1] == 0 {
+		l.head.next = l.head.next[:len(l.head.next)-1]
+	}
+	l.cache.remove(x.id)
+	l.count--
+	l.head.dirty = true
+	return true, l.store.Free(x.id)
+}
+
+// Range calls fn for the elements with a key >= from in ascending order until fn returns false. A nil from
+// starts at the first element. fn must not modify the list; key and value must not be modified.
+func (l *List) Range(from []byte, fn func(key, value []byte) bool) error {
+	x, err := l.lowerBound(from, nil)
+	for ; err == nil && x != nil; x, err = l.load(x.next[0]) {
+		if !fn(x.key, x.value) {
+			return nil
+		}
+	}
+	return err
+}
+
+// Flush writes all modified nodes and the head to the store.
+func (l *List) Flush() error {
+	for _, n := range l.cache.dirty() {
+		if err := l.write(n); err != nil {
+			return err
+		}
+	}
+	if l.head.dirty {
+		if err := l.store.Write(headID, encodeHead(l.head, l.count)); err != nil {
+			return err
+		}
+		l.head.dirty = false
+	}
+	return nil
+}
+
+// CacheStats returns the statistics of the node cache.
+func (l *List) CacheStats() CacheStats {
+	stats := l.cache.stats
+	stats.Cached = l.cache.lru.Len()
+	return stats
+}
+
+// lowerBound returns the first node with a key >= `key` or nil. If update is not nil, it receives the rightmost
+// node before the key on each level.
+func (l *List) lowerBound(key []byte, update []*node) (*node, error) {
+	x := l.head
+	for i := len(l.head.next) - 1; i >= 0; i-- {
+		for x.next[i] != 0 {
+			next, err := l.load(x.next[i])
+			if err != nil {
+				return nil, err
+			}
+			if bytes.Compare(next.key, key) >= 0 {
+				break
+			}
+			x = next
+		}
+		if update != nil {
+			update[i] = x
+		}
+	}
+	if len(x.next) == 0 {
+		return nil, nil
+	}
+	return l.load(x.next[0])
+}
+
+// load returns the node id from the cache or reads it from the store. The ID 0 terminates a level: nil is
+// returned.
+func (l *List) load(id PageID) (*node, error) {
+	if id == headID {
+		return nil, nil
+	}
+	if n := l.cache.get(id); n != nil {
+		return n, nil
+	}
+	data, err := l.store.Read(id)
+	if err != nil {
+		return nil, err
+	}
+	n, err := decodeNode(id, data)
+	if err != nil {
+		return nil, err
+	}
+	return n, l.cache.put(n)
+}
+
+// modified marks the node n as modified. A node evicted meanwhile is cached again, so the modification is not
+// lost.
+func (l *List) modified(n *node) error {
+	n.dirty = true
+	if n.id == headID {
+		return nil
+	}
+	return l.cache.put(n)
+}
+
+// write writes the node n to the store.
+func (l *List) write(n *node) error {
+	if err := l.store.Write(n.id, encodeNode(n)); err != nil {
+		return err
+	}
+	n.dirty = false
+	return nil
+}
+
+func randomLevel() int {
+	level := 1
+	for level < MaxLevel && rand.Float64() < probability {
+		level++
+	}
+	return level
+}
+
+// encodedSize returns the maximum size of an encoded node.
+func encodedSize(key, value []byte, level int) int {
+	return 1 + level*binary.MaxVarintLen64 + 2*binary.MaxVarintLen64 + len(key) + len(value)
+}
+
+// encodeNode encodes a node as its level, the IDs of its tower, and the key and value prefixed by their lengths.
+func encodeNode(n *node) []byte {
+	buf := make([]byte, 0, encodedSize(n.key, n.value, len(n.next)))
+	buf = append(buf, byte(len(n.next)))
+	for _, id := range n.next {
+		buf = binary.AppendUvarint(buf, uint64(id))
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(n.key)))
+	buf = append(buf, n.key...)
+	buf = binary.AppendUvarint(buf, uint64(len(n.value)))
+	return append(buf, n.value...)
+}
+
+func decodeNode(id PageID, data []byte) (*node, error) {
+	r := pageReader{data: data}
+	n := &node{id: id, next: make([]PageID, r.byte())}
+	for i := range n.next {
+		n.next[i] = PageID(r.uvarint())
+	}
+	n.key = bytes.Clone(r.bytes())
+	n.value = bytes.Clone(r.bytes())
+	if r.err || len(n.next) == 0 || len(n.next) > MaxLevel {
+		return nil, fmt.Errorf("%w: node page %d", ErrCorrupted, id)
+	}
+	return n, nil
+}
+
+// encodeHead encodes the head as magic, the number of elements, its level, and the IDs of its tower.
+func encodeHead(head *node, count int) []byte {
+	buf := []byte(headMagic)
+	buf = binary.AppendUvarint(buf, uint64(count))
+	buf = append(buf, byte(len(head.next)))
+	for _, id := range head.next {
+		buf = binary.AppendUvarint(buf, uint64(id))
+	}
+	return buf
+}
+
+func decodeHead(data []byte) (*node, int, error) {
+	if !bytes.HasPrefix(data, []byte(headMagic)) {
+		return nil, 0, fmt.Errorf("%w: head page", ErrCorrupted)
+	}
+	r := pageReader{data: data[len(headMagic):]}
+	count := int(r.uvarint())
+	head := &node{id: headID, next: make([]PageID, r.byte())}
+	for i := range head.next {
+		head.next[i] = PageID(r.uvarint())
+	}
+	if r.err || len(head.next) > MaxLevel {
+		return nil, 0, fmt.Errorf("%w: head page", ErrCorrupted)
+	}
+	return head, count, nil
+}
+
+// pageReader decodes the fields of a page. A truncated page sets err.
+type pageReader struct {
+	data []byte
+	err  bool
+}
+
+func (r *pageReader) byte() byte {
+	if len(r.data) == 0 {
+		r.err = true
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *pageReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = true
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *pageReader) bytes() []byte {
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		r.err = true
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
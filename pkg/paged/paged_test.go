@@ -0,0 +1,136 @@
+package paged
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func key(i int) []byte {
+	return []byte(fmt.Sprintf("key%05d", i))
+}
+
+// collect returns the keys of all elements in order.
+func collect(t *testing.T, l *List) []string {
+	t.Helper()
+	var keys []string
+	require.NoError(t, l.Range(nil, func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return true
+	}))
+	return keys
+}
+
+func TestList(t *testing.T) {
+	store := NewMemoryStore(512)
+	l, err := Open(store, WithCacheSize(8))
+	require.NoError(t, err)
+	want := map[string]string{}
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 3000; i++ {
+		k := r.IntN(500)
+		if r.IntN(3) == 0 {
+			found, err := l.Delete(key(k))
+			require.NoError(t, err)
+			_, ok := want[string(key(k))]
+			assert.Equal(t, ok, found)
+			delete(want, string(key(k)))
+		} else {
+			v := fmt.Sprint(i)
+			_, err := l.Set(key(k), []byte(v))
+			require.NoError(t, err)
+			want[string(key(k))] = v
+		}
+	}
+	assert.Equal(t, len(want), l.Len())
+	for k, v := range want {
+		value, ok, err := l.Get([]byte(k))
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, v, string(value))
+	}
+	_, ok, err := l.Get([]byte("missing"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	keys := collect(t, l)
+	assert.Len(t, keys, len(want))
+	assert.True(t, slices.IsSorted(keys))
+	stats := l.CacheStats()
+	assert.Equal(t, 8, stats.Cached)
+	assert.Positive(t, stats.Evictions)
+
+	require.NoError(t, l.Flush())
+	assert.Equal(t, len(want)+1, store.Len())
+	reopened, err := Open(store)
+	require.NoError(t, err)
+	assert.Equal(t, len(want), reopened.Len())
+	assert.Equal(t, keys, collect(t, reopened))
+}
+
+func TestListRange(t *testing.T) {
+	l, err := Open(NewMemoryStore(256))
+	require.NoError(t, err)
+	for i := 0; i < 100; i += 10 {
+		_, err := l.Set(key(i), nil)
+		require.NoError(t, err)
+	}
+	var keys []string
+	require.NoError(t, l.Range(key(35), func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return len(keys) < 3
+	}))
+	assert.Equal(t, []string{"key00040", "key00050", "key00060"}, keys)
+
+	_, err = l.Set([]byte("big"), make([]byte, 300))
+	assert.ErrorIs(t, err, ErrTooLarge)
+	assert.Equal(t, 10, l.Len())
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.pages")
+	store, err := OpenFileStore(path, 256)
+	require.NoError(t, err)
+	l, err := Open(store, WithCacheSize(4))
+	require.NoError(t, err)
+	for i := 0; i < 200; i++ {
+		_, err := l.Set(key(i), []byte(fmt.Sprint(i)))
+		require.NoError(t, err)
+	}
+	for i := 0; i < 200; i += 2 {
+		_, err := l.Delete(key(i))
+		require.NoError(t, err)
+	}
+	require.NoError(t, l.Flush())
+	require.NoError(t, store.Close())
+
+	store, err = OpenFileStore(path, 256)
+	require.NoError(t, err)
+	defer store.Close()
+	l, err = Open(store)
+	require.NoError(t, err)
+	assert.Equal(t, 100, l.Len())
+	value, ok, err := l.Get(key(51))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "51", string(value))
+	_, ok, err = l.Get(key(50))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = store.Read(100000)
+	assert.ErrorIs(t, err, ErrPageNotFound)
+}
+
+func TestOpenCorrupted(t *testing.T) {
+	store := NewMemoryStore(256)
+	require.NoError(t, store.Write(0, []byte("nope")))
+	_, err := Open(store)
+	assert.ErrorIs(t, err, ErrCorrupted)
+	assert.Panics(t, func() { WithCacheSize(0) })
+}
@@ -0,0 +1,207 @@
+package paged
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+)
+
+// PageID identifies a page of a PageStore. The page 0 holds the head of a list.
+type PageID uint64
+
+// ErrPageNotFound is returned (wrapped) when a page was never written or was freed.
+var ErrPageNotFound = errors.New("paged: page not found")
+
+// PageStore persists pages of a limited size, e.g. in memory, in a file, or in a memory mapped file.
+type PageStore interface {
+	// PageSize returns the maximum number of bytes of a page.
+	PageSize() int
+	// Read returns the content of the page id as written last. The slice must not be modified.
+	Read(id PageID) ([]byte, error)
+	// Write replaces the content of the page id by data, which has at most PageSize() bytes.
+	Write(id PageID, data []byte) error
+	// Allocate returns an unused page ID other than 0.
+	Allocate() (PageID, error)
+	// Free releases the page id for reuse.
+	Free(id PageID) error
+}
+
+// MemoryStore is a PageStore keeping the pages in memory, e.g. for tests.
+type MemoryStore struct {
+	mu       sync.Mutex
+	pageSize int
+	pages    map[PageID][]byte
+	next     PageID
+	free     []PageID
+}
+
+// NewMemoryStore creates an empty MemoryStore with pages of up to pageSize bytes.
+func NewMemoryStore(pageSize int) *MemoryStore {
+	if pageSize < minPageSize {
+		log.Panicf("Parameter pageSize out of range (must be >= %d)", minPageSize)
+	}
+	return &MemoryStore{pageSize: pageSize, pages: map[PageID][]byte{}, next: 1}
+}
+
+func (m *MemoryStore) PageSize() int {
+	return m.pageSize
+}
+
+func (m *MemoryStore) Read(id PageID) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.pages[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrPageNotFound, id)
+	}
+	return data, nil
+}
+
+func (m *MemoryStore) Write(id PageID, data []byte) error {
+	if len(data) > m.pageSize {
+		return fmt.Errorf("%w: %d bytes exceed the page size", ErrTooLarge, len(data))
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pages[id] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m *MemoryStore) Allocate() (PageID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n := len(m.free); n > 0 {
+		id := m.free[n-1]
+		m.free = m.free[:n-1]
+		return id, nil
+	}
+	m.next++
+	return m.next - 1, nil
+}
+
+func (m *MemoryStore) Free(id PageID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pages, id)
+	m.free = append(m.free, id)
+	return nil
+}
+
+// Len returns the number of written pages.
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.pages)
+}
+
+// filePageHeader is the size of the length prefix of a page within a FileStore.
+const filePageHeader = 4
+
+// FileStore is a PageStore keeping the pages in a file: the page i is stored at the offset i*(pageSize+4) with
+// its length as prefix. Freed pages are reused while the store is open; after reopening they are lost.
+type FileStore struct {
+	mu       sync.Mutex
+	file     *os.File
+	pageSize int
+	next     PageID
+	free     []PageID
+}
+
+// OpenFileStore opens or creates the file at path as a FileStore with pages of up to pageSize bytes. The page size
+// must be the same every time the file is opened.
+func OpenFileStore(path string, pageSize int) (*FileStore, error) {
+	if pageSize < minPageSize {
+		log.Panicf("Parameter pageSize out of range (must be >= %d)", minPageSize)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	slot := int64(pageSize + filePageHeader)
+	next := PageID((info.Size() + slot - 1) / slot)
+	return &FileStore{file: file, pageSize: pageSize, next: max(next, 1)}, nil
+}
+
+func (f *FileStore) PageSize() int {
+	return f.pageSize
+}
+
+func (f *FileStore) Read(id PageID) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var header [filePageHeader]byte
+	if _, err := f.file.ReadAt(header[:], f.offset(id)); err == io.EOF {
+		return nil, fmt.Errorf("%w: %d", ErrPageNotFound, id)
+	} else if err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(header[:])
+	if n == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrPageNotFound, id)
+	}
+	if int(n-1) > f.pageSize {
+		return nil, fmt.Errorf("%w: page %d has an invalid length", ErrCorrupted, id)
+	}
+	data := make([]byte, n-1)
+	if _, err := f.file.ReadAt(data, f.offset(id)+filePageHeader); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (f *FileStore) Write(id PageID, data []byte) error {
+	if len(data) > f.pageSize {
+		return fmt.Errorf("%w: %d bytes exceed the page size", ErrTooLarge, len(data))
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// the stored length is incremented, so 0 marks a freed page
+	buf := binary.BigEndian.AppendUint32(make([]byte, 0, filePageHeader+len(data)), uint32(len(data)+1))
+	_, err := f.file.WriteAt(append(buf, data...), f.offset(id))
+	return err
+}
+
+func (f *FileStore) Allocate() (PageID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n := len(f.free); n > 0 {
+		id := f.free[n-1]
+		f.free = f.free[:n-1]
+		return id, nil
+	}
+	f.next++
+	return f.next - 1, nil
+}
+
+func (f *FileStore) Free(id PageID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.file.WriteAt(make([]byte, filePageHeader), f.offset(id)); err != nil {
+		return err
+	}
+	f.free = append(f.free, id)
+	return nil
+}
+
+// Sync commits the written pages to stable storage.
+func (f *FileStore) Sync() error {
+	return f.file.Sync()
+}
+
+// Close closes the file.
+func (f *FileStore) Close() error {
+	return f.file.Close()
+}
+
+func (f *FileStore) offset(id PageID) int64 {
+	return int64(id) * int64(f.pageSize+filePageHeader)
+}
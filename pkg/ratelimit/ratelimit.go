@@ -0,0 +1,100 @@
+// Package ratelimit implements a sliding window rate limiter on top of skip lists. Every key keeps a log of the
+// times of its admitted events in a skip list; an event is admitted if fewer than `limit` events were admitted
+// within the preceding window. Unlike fixed window counters the limit holds for every window, not only for
+// aligned ones. The memory grows with the number of events within a window, so the limiter suits moderate
+// limits. It is an example of combining CountRange and RemoveBelow.
+package ratelimit
+
+import (
+	"cmp"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// Limiter is a sliding log rate limiter admitting at most `limit` events per key within every window. It can be
+// used from multiple goroutines.
+type Limiter[K cmp.Ordered] struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	logs   map[K]*skiplist.SkipList[int64, struct{}] // admitted events by key, keyed by Unix nanoseconds
+}
+
+// New creates a Limiter admitting at most `limit` events per key within every period of length `window`.
+func New[K cmp.Ordered](limit int, window time.Duration) *Limiter[K] {
+	if limit <= 0 {
+		log.Panic("Parameter limit out of range (must be > 0)")
+	}
+	if window <= 0 {
+		log.Panic("Parameter window out of range (must be > 0)")
+	}
+	return &Limiter[K]{
+		limit:  limit,
+		window: window,
+		logs:   make(map[K]*skiplist.SkipList[int64, struct{}]),
+	}
+}
+
+// Allow reports whether an event of `key` at the time `now` is admitted and records it if so. The window of
+// the event is (now-window, now]; events with a later time (e.g. recorded with a skewed clock) are counted
+// once they are within a window.
+func (l *Limiter[K]) Allow(key K, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := l.logs[key]
+	if events == nil {
+		events = skiplist.NewSkipList[int64, struct{}](skiplist.WithDuplicates())
+		l.logs[key] = events
+	}
+	t := now.UnixNano()
+	events.RemoveBelow(t - int64(l.window) + 1)
+	if events.CountRange(t-int64(l.window)+1, t) >= l.limit {
+		return false
+	}
+	events.Set(t, struct{}{})
+	return true
+}
+
+// Count returns the number of admitted events of `key` within the window ending at `now`.
+func (l *Limiter[K]) Count(key K, now time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := l.logs[key]
+	if events == nil {
+		return 0
+	}
+	t := now.UnixNano()
+	return events.CountRange(t-int64(l.window)+1, t)
+}
+
+// Remaining returns the number of events of `key` which would still be admitted at `now`.
+func (l *Limiter[K]) Remaining(key K, now time.Time) int {
+	return max(0, l.limit-l.Count(key, now))
+}
+
+// Cleanup drops the logs of all keys without events within the window ending at `now`, which would otherwise
+// occupy memory for keys not seen anymore. It should be called periodically. Returns the number of dropped keys.
+func (l *Limiter[K]) Cleanup(now time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cutoff := now.UnixNano() - int64(l.window) + 1
+	n := 0
+	for key, events := range l.logs {
+		events.RemoveBelow(cutoff)
+		if events.Size() == 0 {
+			delete(l.logs, key)
+			n++
+		}
+	}
+	return n
+}
+
+// Keys returns the number of keys with a log.
+func (l *Limiter[K]) Keys() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.logs)
+}
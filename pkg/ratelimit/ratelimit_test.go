@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllow(t *testing.T) {
+	l := New[string](3, time.Second)
+	t0 := time.Unix(1000, 0)
+	assert.True(t, l.Allow("a", t0))
+	assert.True(t, l.Allow("a", t0))
+	assert.True(t, l.Allow("a", t0.Add(500*time.Millisecond)))
+	assert.False(t, l.Allow("a", t0.Add(900*time.Millisecond)))
+	assert.True(t, l.Allow("b", t0.Add(900*time.Millisecond)))
+	assert.Equal(t, 0, l.Remaining("a", t0.Add(900*time.Millisecond)))
+
+	// the two events at t0 leave the window after exactly one second
+	assert.Equal(t, 2, l.Remaining("a", t0.Add(time.Second)))
+	assert.True(t, l.Allow("a", t0.Add(time.Second)))
+	assert.True(t, l.Allow("a", t0.Add(time.Second)))
+	assert.False(t, l.Allow("a", t0.Add(time.Second)))
+	assert.Equal(t, 3, l.Count("a", t0.Add(time.Second)))
+	assert.Equal(t, 0, l.Count("c", t0))
+}
+
+func TestCleanup(t *testing.T) {
+	l := New[int](1, time.Minute)
+	t0 := time.Unix(1000, 0)
+	for k := 0; k < 10; k++ {
+		l.Allow(k, t0.Add(time.Duration(k)*time.Second))
+	}
+	assert.Equal(t, 10, l.Keys())
+	assert.Equal(t, 5, l.Cleanup(t0.Add(time.Minute+4*time.Second)))
+	assert.Equal(t, 5, l.Keys())
+	assert.False(t, l.Allow(7, t0.Add(time.Minute)))
+}
+
+func TestConcurrentAllow(t *testing.T) {
+	l := New[int](100, time.Hour)
+	now := time.Unix(1000, 0)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	admitted := 0
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if l.Allow(1, now) {
+					mu.Lock()
+					admitted++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, admitted)
+}
+
+func TestNewPanics(t *testing.T) {
+	assert.Panics(t, func() { New[int](0, time.Second) })
+	assert.Panics(t, func() { New[int](1, 0) })
+}
@@ -0,0 +1,143 @@
+// Package scheduler runs functions at given times. The pending tasks are indexed by a skip list ordered by their
+// due time, so scheduling and canceling cost O(log(n)) and the next due task is found in O(1).
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// TaskID identifies a scheduled task.
+type TaskID uint64
+
+// Clock provides the time to the Scheduler. It allows tests to control the time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel receiving the time after the duration d elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock of the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// task is a scheduled function.
+type task struct {
+	id TaskID
+	fn func()
+}
+
+// Scheduler runs scheduled functions by Run. Its methods can be used from multiple goroutines.
+type Scheduler struct {
+	mu     sync.Mutex
+	clock  Clock
+	tasks  *skiplist.SkipList[int64, task] // pending tasks by due time in Unix nanoseconds, FIFO for equal times
+	due    map[TaskID]int64                // due times of the pending tasks
+	nextID TaskID
+	wake   chan struct{} // signals Run that the first task changed
+}
+
+// New creates a Scheduler using `clock` or the real time if clock is nil.
+func New(clock Clock) *Scheduler {
+	if clock == nil {
+		clock = realClock{}
+	}
+	return &Scheduler{
+		clock: clock,
+		tasks: skiplist.NewSkipList[int64, task](skiplist.WithDuplicates()),
+		due:   make(map[TaskID]int64),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Schedule schedules fn to be run by Run at the time `at` and returns the ID of the task. Tasks due at the
+// same time are run in the order they were scheduled; tasks in the past are run immediately.
+func (s *Scheduler) Schedule(at time.Time, fn func()) TaskID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	id := s.nextID
+	t := at.UnixNano()
+	_, pos, _ := s.tasks.Set(t, task{id: id, fn: fn})
+	s.due[id] = t
+	if pos == 0 {
+		s.notify()
+	}
+	return id
+}
+
+// After schedules fn to be run after the duration d like Schedule.
+func (s *Scheduler) After(d time.Duration, fn func()) TaskID {
+	return s.Schedule(s.clock.Now().Add(d), fn)
+}
+
+// Cancel removes a pending task. Returns false if the task was already run or canceled.
+func (s *Scheduler) Cancel(id TaskID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.due[id]
+	if !ok {
+		return false
+	}
+	delete(s.due, id)
+	x, pos := s.tasks.Get(t)
+	for ; x.Value.id != id; x = x.Next() {
+		pos++
+	}
+	s.tasks.RemoveByPos(pos)
+	if pos == 0 {
+		s.notify()
+	}
+	return true
+}
+
+// Len returns the number of pending tasks.
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tasks.Size()
+}
+
+// notify wakes up Run to reconsider the first task.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run runs the tasks when they are due until the context is done and returns its error. The tasks are run one
+// after the other by the calling goroutine, so a long running task delays the following ones. Run must not be
+// called concurrently.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		first := s.tasks.First()
+		var timer <-chan time.Time
+		if first != nil {
+			wait := time.Duration(first.Key() - s.clock.Now().UnixNano())
+			if wait <= 0 {
+				s.tasks.RemoveByPos(0)
+				delete(s.due, first.Value.id)
+				s.mu.Unlock()
+				first.Value.fn()
+				continue
+			}
+			timer = s.clock.After(wait)
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-timer:
+		case <-s.wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a Clock advanced manually by the test.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeTimer{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiters = append(waiters, w)
+		} else {
+			w.ch <- c.now
+		}
+	}
+	c.waiters = waiters
+}
+
+func TestScheduler(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	s := New(clock)
+	ran := make(chan string, 10)
+	record := func(name string) func() { return func() { ran <- name } }
+
+	s.After(2*time.Second, record("b"))
+	s.After(time.Second, record("a"))
+	s.After(2*time.Second, record("c"))
+	canceled := s.After(1500*time.Millisecond, record("canceled"))
+	s.After(3*time.Second, record("d"))
+	assert.True(t, s.Cancel(canceled))
+	assert.False(t, s.Cancel(canceled))
+	assert.Equal(t, 4, s.Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	receive := func() string {
+		select {
+		case name := <-ran:
+			return name
+		case <-time.After(time.Second):
+			return "timeout"
+		}
+	}
+	// advance the clock until the scheduler runs a task; Run may not have set its timer yet
+	advanceUntil := func(d time.Duration) string {
+		for i := 0; i < 100; i++ {
+			clock.Advance(d)
+			d = 0
+			select {
+			case name := <-ran:
+				return name
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+		return "timeout"
+	}
+	assert.Equal(t, "a", advanceUntil(time.Second))
+	assert.Equal(t, "b", advanceUntil(time.Second))
+	assert.Equal(t, "c", receive())
+
+	// a task in the past runs immediately
+	s.Schedule(time.Unix(0, 0), record("past"))
+	assert.Equal(t, "past", receive())
+	assert.Equal(t, 1, s.Len())
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestSchedulerRealClock(t *testing.T) {
+	s := New(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ran := make(chan struct{})
+	s.After(time.Millisecond, func() { close(ran) })
+	go s.Run(ctx)
+	select {
+	case <-ran:
+	case <-ctx.Done():
+		t.Fatal("task was not run")
+	}
+}
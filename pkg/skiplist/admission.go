@@ -0,0 +1,26 @@
+package skiplist
+
+import "cmp"
+
+// WithAdmissionControl registers a function consulted on every Set with the key, the value, and the current
+// size of the skip list. If it returns an error the element is not stored and the error is returned by
+// SkipList.TrySet(). This allows enforcing quotas or banning keys within the structure.
+// The type parameters are inferred from `admit`.
+func WithAdmissionControl[K cmp.Ordered, V any](admit func(key K, value V, currentSize int) error) Option {
+	return typedOption(func(s *SkipList[K, V]) {
+		s.admit = admit
+	})
+}
+
+// admission checks whether the element may be stored (see WithKeyBounds and WithAdmissionControl).
+func (s *SkipList[K, V]) admission(key K, value V) error {
+	if s.keyBounds != nil {
+		if err := s.checkKeyBounds(key); err != nil {
+			return err
+		}
+	}
+	if s.admit != nil {
+		return s.admit(key, value, s.count)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package skiplist
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmissionControl(t *testing.T) {
+	errQuota := errors.New("quota exceeded")
+	errBanned := errors.New("banned")
+	s := NewSkipList[int, string](WithAdmissionControl(func(key int, _ string, size int) error {
+		if key == 13 {
+			return errBanned
+		}
+		if size >= 3 {
+			return errQuota
+		}
+		return nil
+	}))
+
+	for k := 0; k < 3; k++ {
+		_, _, created, err := s.TrySet(k, "x")
+		assert.NoError(t, err)
+		assert.True(t, created)
+	}
+	x, pos, created, err := s.TrySet(4, "x")
+	assert.ErrorIs(t, err, errQuota)
+	assert.Nil(t, x)
+	assert.Equal(t, InvalidPos, pos)
+	assert.False(t, created)
+
+	x, pos, created = s.Set(13, "x")
+	assert.Nil(t, x)
+	assert.Equal(t, InvalidPos, pos)
+	assert.False(t, created)
+	assert.Equal(t, 3, s.Size())
+}
@@ -0,0 +1,29 @@
+package skiplist
+
+// Pre-instantiated skip lists for common key and value types. Using them instead of spelling out the type
+// parameters keeps the instantiations of a code base in one place.
+
+// IntStringSkipList is a skip list with int keys and string values.
+type IntStringSkipList = SkipList[int, string]
+
+// StringAnySkipList is a skip list with string keys and values of any type.
+type StringAnySkipList = SkipList[string, any]
+
+// TimeSkipList is a skip list ordered by time with values of any type. The keys are Unix times in
+// nanoseconds as returned by time.Time.UnixNano().
+type TimeSkipList = SkipList[int64, any]
+
+// NewIntStringSkipList creates a new empty IntStringSkipList.
+func NewIntStringSkipList(options ...Option) *IntStringSkipList {
+	return NewSkipList[int, string](options...)
+}
+
+// NewStringAnySkipList creates a new empty StringAnySkipList.
+func NewStringAnySkipList(options ...Option) *StringAnySkipList {
+	return NewSkipList[string, any](options...)
+}
+
+// NewTimeSkipList creates a new empty TimeSkipList.
+func NewTimeSkipList(options ...Option) *TimeSkipList {
+	return NewSkipList[int64, any](options...)
+}
@@ -0,0 +1,25 @@
+package skiplist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliases(t *testing.T) {
+	var s *SkipList[int, string] = NewIntStringSkipList(WithMaxLevel(8))
+	s.Set(1, "one")
+	assert.Equal(t, 1, s.Size())
+
+	a := NewStringAnySkipList()
+	a.Set("x", 42)
+	x, _ := a.Get("x")
+	assert.Equal(t, 42, x.Value)
+
+	now := time.Now()
+	ts := NewTimeSkipList()
+	ts.Set(now.UnixNano(), "later")
+	ts.Set(now.Add(-time.Second).UnixNano(), "earlier")
+	assert.Equal(t, "earlier", ts.First().Value)
+}
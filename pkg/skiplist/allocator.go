@@ -0,0 +1,151 @@
+package skiplist
+
+import (
+	"cmp"
+	"log"
+)
+
+// Allocator provides the memory of the nodes of a skip list (see WithAllocator), e.g. to allocate nodes of
+// short-lived lists in regions which are dropped as a whole instead of collecting every node.
+type Allocator[K cmp.Ordered, V any] interface {
+	// AllocNode returns a zeroed node.
+	AllocNode() *Node[K, V]
+	// AllocLinks returns zeroed slices for the pointers and distances of a node with the given length and
+	// capacity.
+	AllocLinks(level, capacity int) ([]*Node[K, V], []int)
+	// Free is called for every node removed from the list, e.g. by Remove, RemoveRangeByPos, the retention, or
+	// Clear, if the nodes are not shared with a snapshot. Removing operations return the removed node, so the
+	// node must not be reused while the caller may still access it.
+	Free(x *Node[K, V])
+}
+
+// WithAllocator allocates the nodes of the skip list by `alloc` instead of the heap. The nodes built by
+// RebuildInBackground are always allocated on the heap, since the rebuild discards them if it starts over.
+// The type parameters are inferred from `alloc`.
+func WithAllocator[K cmp.Ordered, V any](alloc Allocator[K, V]) Option {
+	return typedOption(func(s *SkipList[K, V]) {
+		s.allocator = alloc
+	})
+}
+
+// HeapAllocator allocates nodes on the heap. This is the default.
+type HeapAllocator[K cmp.Ordered, V any] struct{}
+
+func (HeapAllocator[K, V]) AllocNode() *Node[K, V] {
+	return &Node[K, V]{}
+}
+
+func (HeapAllocator[K, V]) AllocLinks(level, capacity int) ([]*Node[K, V], []int) {
+	return make([]*Node[K, V], level, capacity), make([]int, level, capacity)
+}
+
+func (HeapAllocator[K, V]) Free(*Node[K, V]) {}
+
+// ArenaAllocator allocates nodes and their links from blocks, which reduces the number of allocations and
+// the work of the garbage collector. Memory is never reused: it is released as a whole when the allocator
+// and all skip lists using it are dropped. It is meant for short-lived lists like batch jobs and must not
+// be used concurrently.
+type ArenaAllocator[K cmp.Ordered, V any] struct {
+	blockSize int
+	nodes     []Node[K, V]
+	next      []*Node[K, V]
+	dist      []int
+}
+
+// NewArenaAllocator creates an ArenaAllocator allocating blocks of `blockSize` nodes.
+func NewArenaAllocator[K cmp.Ordered, V any](blockSize int) *ArenaAllocator[K, V] {
+	if blockSize < 1 {
+		log.Panic("Parameter blockSize out of range (must be >= 1)")
+	}
+	return &ArenaAllocator[K, V]{blockSize: blockSize}
+}
+
+func (a *ArenaAllocator[K, V]) AllocNode() *Node[K, V] {
+	if len(a.nodes) == 0 {
+		a.nodes = make([]Node[K, V], a.blockSize)
+	}
+	x := &a.nodes[0]
+	a.nodes = a.nodes[1:]
+	return x
+}
+
+func (a *ArenaAllocator[K, V]) AllocLinks(level, capacity int) ([]*Node[K, V], []int) {
+	if capacity > len(a.next) {
+		// two links per node in the average for the probability 0.5
+		n := max(capacity, 2*a.blockSize)
+		a.next = make([]*Node[K, V], n)
+		a.dist = make([]int, n)
+	}
+	next := a.next[:level:capacity]
+	dist := a.dist[:level:capacity]
+	a.next = a.next[capacity:]
+	a.dist = a.dist[capacity:]
+	return next, dist
+}
+
+func (a *ArenaAllocator[K, V]) Free(*Node[K, V]) {}
+
+// Reserve allocates a block for at least n more nodes and their links at once (see SkipList.Reserve).
+func (a *ArenaAllocator[K, V]) Reserve(n int) {
+	if n > len(a.nodes) {
+		a.nodes = make([]Node[K, V], n)
+	}
+	if 2*n > len(a.next) {
+		a.next = make([]*Node[K, V], 2*n)
+		a.dist = make([]int, 2*n)
+	}
+}
+
+// freeNode passes the removed node x to the allocator if the nodes are not shared with a snapshot.
+func (s *SkipList[K, V]) freeNode(x *Node[K, V]) {
+	if s.allocator != nil && s.refs == nil {
+		s.allocator.Free(x)
+	}
+}
+
+// newNode creates a node with the allocator of the skip list.
+func (s *SkipList[K, V]) newNode(key K, value V, level int, capacity int) *Node[K, V] {
+	if s.allocator == nil {
+		if s.reserved != nil {
+			return s.reservedNode(key, value, level, capacity)
+		}
+		return newNode[K, V](key, value, level, capacity)
+	}
+	return allocNode(s.allocator, key, value, level, capacity)
+}
+
+func allocNode[K cmp.Ordered, V any](alloc Allocator[K, V], key K, value V, level int, capacity int) *Node[K, V] {
+	capacity = max(capacity, level)
+	x := alloc.AllocNode()
+	x.key = key
+	x.Value = value
+	x.next, x.dist = alloc.AllocLinks(level, capacity)
+	return x
+}
+
+// Clear removes all elements. If the nodes are not shared with a snapshot, they are passed to the Free
+// method of the allocator (see WithAllocator). Clearing a snapshot releases the nodes it shares, so the other
+// lists don't copy them on their next write (see Snapshot).
+func (s *SkipList[K, V]) Clear() {
+	s.lazyInit()
+	s.pollRebuild()
+	if s.allocator != nil && s.refs == nil {
+		for x := s.head; x != nil; {
+			next := x.Next()
+			s.allocator.Free(x)
+			x = next
+		}
+	}
+	s.releaseNodes()
+	var dummyKey K
+	var dummyValue V
+	s.head = s.newNode(dummyKey, dummyValue, 0, s.maxLevel)
+	s.count = 0
+	s.deleted = 0
+	s.ids = nil
+	if s.interned != nil {
+		s.interned = newInternMap[K](0)
+	}
+	s.insFirst, s.insLast = nil, nil
+	s.checkWatermarks()
+}
@@ -0,0 +1,98 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingAllocator struct {
+	HeapAllocator[int, int]
+	allocated, freed int
+}
+
+func (a *countingAllocator) AllocNode() *Node[int, int] {
+	a.allocated++
+	return a.HeapAllocator.AllocNode()
+}
+
+func (a *countingAllocator) Free(*Node[int, int]) {
+	a.freed++
+}
+
+func TestAllocator(t *testing.T) {
+	a := &countingAllocator{}
+	s := NewSkipList[int, int](WithAllocator[int, int](a))
+	assert.Equal(t, 1, a.allocated) // head
+	for k := 0; k < 100; k++ {
+		s.Set(k, k)
+	}
+	assert.Equal(t, 101, a.allocated)
+	s.Load([]Pair[int, int]{{1, 1}, {2, 2}})
+	assert.Equal(t, 103, a.allocated)
+	c := s.Compact()
+	assert.Equal(t, 105, a.allocated)
+
+	s.Clear()
+	assert.Equal(t, 3, a.freed)
+	assert.Equal(t, 0, s.Size())
+	require.NoError(t, s.Validate())
+	s.Set(5, 5)
+	require.NoError(t, s.Validate())
+
+	// nodes shared with a snapshot are not freed
+	snap := c.Snapshot()
+	c.Clear()
+	assert.Equal(t, 3, a.freed)
+	assert.Equal(t, 2, snap.Size())
+}
+
+func TestAllocatorFreeRemoved(t *testing.T) {
+	a := &countingAllocator{}
+	s := NewSkipList[int, int](WithAllocator[int, int](a), WithInsertionOrder())
+	for k := 0; k < 100; k++ {
+		s.Set(k, k)
+	}
+	s.Remove(5)
+	s.RemoveByPos(0)
+	s.RemoveBatch([]int{10, 11})
+	assert.Equal(t, 4, a.freed)
+	assert.Equal(t, 10, s.RemoveRangeByPos(0, 10))
+	assert.Equal(t, 14, a.freed)
+	require.NoError(t, s.Validate())
+
+	// the removal copies the nodes shared with the snapshot and frees the copy only
+	snap := s.Snapshot()
+	s.Remove(50)
+	assert.Equal(t, 15, a.freed)
+	require.NoError(t, snap.Validate())
+	x, _ := snap.Get(50)
+	assert.NotNil(t, x)
+
+	s.Clear()
+	assert.Nil(t, s.insFirst)
+	assert.Nil(t, s.insLast)
+	s.Set(1, 1)
+	for k := range s.IterateByInsertion() {
+		assert.Equal(t, 1, k)
+	}
+}
+
+func TestArenaAllocator(t *testing.T) {
+	s := NewSkipList[int, string](WithAllocator(NewArenaAllocator[int, string](16)))
+	for _, k := range makeRandomData(1000) {
+		s.Set(k, "v")
+	}
+	for k := 0; k < 1000; k += 3 {
+		s.Remove(k)
+	}
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 666, s.Size())
+	snap := s.Snapshot()
+	s.Set(5000, "w")
+	require.NoError(t, snap.Validate())
+	require.NoError(t, s.Validate())
+
+	assert.Panics(t, func() { NewArenaAllocator[int, int](0) })
+}
@@ -0,0 +1,64 @@
+package skiplist
+
+import "slices"
+
+// SetBatch sets the values of the pairs like calls of TrySet in the order of the slice. The pairs are sorted by
+// key first (a sorted copy is made unless they are sorted already) and merged by MergeSorted, so every search
+// continues from the search path of the previous key instead of descending from the head. A pair rejected by
+// WithKeyBounds or WithAdmissionControl, including one overwriting an existing key, stops the batch with its
+// error; the pairs with smaller keys remain set. The slice is not modified.
+func (s *SkipList[K, V]) SetBatch(pairs []Pair[K, V]) error {
+	pairs = s.sortedPairs(pairs)
+	return s.MergeSorted(func(yield func(K, V) bool) {
+		for _, p := range pairs {
+			if !yield(p.Key, p.Value) {
+				return
+			}
+		}
+	}, nil)
+}
+
+// RemoveBatch removes the elements with the keys like calls of Remove and returns the number of removed
+// elements. The keys are sorted first (a sorted copy is made unless they are sorted already), and every search
+// continues from the search path of the previous key instead of descending from the head. The slice is not
+// modified.
+func (s *SkipList[K, V]) RemoveBatch(keys []K) int {
+	s.lazyInit()
+	s.pollRebuild()
+	s.ensureOwned()
+	if !slices.IsSortedFunc(keys, s.compare) {
+		keys = slices.Clone(keys)
+		slices.SortFunc(keys, s.compare)
+	}
+	update := make([]*Node[K, V], s.Level(), s.maxLevel)
+	updatePos := make([]int, s.Level(), s.maxLevel)
+	for i := range update {
+		update[i] = s.head
+		updatePos[i] = -1
+	}
+	n := 0
+	for _, key := range keys {
+		// unlinking may have lowered the level
+		update = update[:s.Level()]
+		updatePos = updatePos[:s.Level()]
+		x := s.head
+		pos := -1
+		for i := len(update) - 1; i >= 0; i-- {
+			if updatePos[i] > pos {
+				x, pos = update[i], updatePos[i]
+			}
+			for x.next[i] != nil && s.less(x.next[i].key, key) {
+				pos += x.dist[i]
+				x = x.next[i]
+			}
+			update[i] = x
+			updatePos[i] = pos
+		}
+		s.touched(key)
+		if len(x.next) > 0 && x.next[0] != nil && x.next[0].key == key {
+			s.unlink(update, x.next[0])
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,83 @@
+package skiplist
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBatch(t *testing.T) {
+	s := NewSkipList[int, string]()
+	s.Set(2, "old")
+	input := []Pair[int, string]{{5, "a"}, {1, "b"}, {2, "c"}, {5, "d"}}
+	require.NoError(t, s.SetBatch(input))
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{1, 2, 5}, slices.Collect(s.Keys()))
+	assert.Equal(t, []string{"b", "c", "d"}, valuesOf(s))
+	assert.Equal(t, 5, input[0].Key)
+
+	pairs := make([]Pair[int, string], 1000)
+	for i := range pairs {
+		pairs[i] = Pair[int, string]{Key: 3 * i, Value: "x"}
+	}
+	require.NoError(t, s.SetBatch(pairs))
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 1003, s.Size())
+
+	d := NewSkipList[int, string](WithDuplicates())
+	require.NoError(t, d.SetBatch(input))
+	assert.Equal(t, []string{"b", "c", "a", "d"}, valuesOf(d))
+
+	b := NewSkipList[int, string](WithKeyBounds[int, string](0, 3))
+	assert.ErrorIs(t, b.SetBatch(input), ErrKeyOutOfBounds)
+	assert.Equal(t, []int{1, 2}, slices.Collect(b.Keys()))
+
+	// the admission control also rejects overwrites
+	banned := errors.New("banned")
+	a := NewSkipList[int, string](WithAdmissionControl(func(_ int, value string, _ int) error {
+		if value == "c" {
+			return banned
+		}
+		return nil
+	}))
+	a.Set(2, "old")
+	assert.ErrorIs(t, a.SetBatch(input), banned)
+	assert.Equal(t, []int{1, 2}, slices.Collect(a.Keys()))
+	assert.Equal(t, []string{"b", "old"}, valuesOf(a))
+}
+
+func TestRemoveBatch(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 1000; k++ {
+		s.Set(k, k)
+	}
+	keys := []int{500, 3, 999, 0, 3, 2000, 250}
+	assert.Equal(t, 5, s.RemoveBatch(keys))
+	assert.Equal(t, 500, keys[0])
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 995, s.Size())
+	for _, k := range keys {
+		x, _ := s.Get(k)
+		assert.Nil(t, x)
+	}
+
+	all := make([]int, 1000)
+	for k := range all {
+		all[k] = k
+	}
+	assert.Equal(t, 995, s.RemoveBatch(all))
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 0, s.Size())
+	assert.Equal(t, 0, s.RemoveBatch(all))
+
+	d := NewSkipList[int, int](WithDuplicates(), WithDescending())
+	for _, k := range []int{1, 2, 2, 3} {
+		d.Set(k, k)
+	}
+	assert.Equal(t, 3, d.RemoveBatch([]int{2, 3, 2, 4}))
+	require.NoError(t, d.Validate())
+	assert.Equal(t, []int{1}, slices.Collect(d.Keys()))
+}
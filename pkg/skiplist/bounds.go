@@ -0,0 +1,58 @@
+package skiplist
+
+// Floor returns the last node with a key <= `key` and its position 0...n-1, or nil and InvalidPos if all keys
+// are greater. With duplicates the last of the equal keys is returned.
+func (s *SkipList[K, V]) Floor(key K) (*Node[K, V], int) {
+	return s.before(key, true)
+}
+
+// Lower returns the last node with a key < `key` and its position, or nil and InvalidPos if there is none.
+func (s *SkipList[K, V]) Lower(key K) (*Node[K, V], int) {
+	return s.before(key, false)
+}
+
+// Ceiling returns the first node with a key >= `key` and its position 0...n-1, or nil and InvalidPos if all
+// keys are smaller. With duplicates the first of the equal keys is returned.
+func (s *SkipList[K, V]) Ceiling(key K) (*Node[K, V], int) {
+	if x, pos := s.lowerBound(key); x != nil {
+		return x, pos
+	}
+	return nil, InvalidPos
+}
+
+// Higher returns the first node with a key > `key` and its position, or nil and InvalidPos if there is none.
+func (s *SkipList[K, V]) Higher(key K) (*Node[K, V], int) {
+	if x, pos := s.upperBound(key); x != nil {
+		return x, pos
+	}
+	return nil, InvalidPos
+}
+
+// before returns the last node with a key < `key` (<= if inclusive) and its position.
+func (s *SkipList[K, V]) before(key K, inclusive bool) (*Node[K, V], int) {
+	s.lazyInit()
+	x := s.head
+	pos := -1
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && (s.less(x.next[i].key, key) || inclusive && x.next[i].key == key) {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+	}
+	if x == s.head {
+		return nil, InvalidPos
+	}
+	return x, pos
+}
+
+// RangeGet returns the nodes with lo <= key <= hi found by a single descent and a walk on level 0. Soft deleted
+// elements are skipped (see MarkDeleted). Use Range or RangeSeq to process large ranges without collecting them.
+func (s *SkipList[K, V]) RangeGet(lo, hi K) []*Node[K, V] {
+	var nodes []*Node[K, V]
+	for x, _ := s.lowerBound(lo); x != nil && !s.less(hi, x.key); x = x.Next() {
+		if !x.deleted {
+			nodes = append(nodes, x)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,65 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBounds(t *testing.T) {
+	s := Collect(pairSeq(10, 20, 20, 30), WithDuplicates())
+	check := func(x *Node[int, int], pos int, wantKey, wantPos int) {
+		t.Helper()
+		if wantPos == InvalidPos {
+			assert.Nil(t, x)
+		} else if assert.NotNil(t, x) {
+			assert.Equal(t, wantKey, x.Key())
+			assert.Same(t, s.GetByPos(wantPos), x)
+		}
+		assert.Equal(t, wantPos, pos)
+	}
+
+	x, pos := s.Floor(20)
+	check(x, pos, 20, 2)
+	x, pos = s.Floor(25)
+	check(x, pos, 20, 2)
+	x, pos = s.Floor(5)
+	check(x, pos, 0, InvalidPos)
+	x, pos = s.Floor(99)
+	check(x, pos, 30, 3)
+
+	x, pos = s.Lower(20)
+	check(x, pos, 10, 0)
+	x, pos = s.Lower(10)
+	check(x, pos, 0, InvalidPos)
+
+	x, pos = s.Ceiling(20)
+	check(x, pos, 20, 1)
+	x, pos = s.Ceiling(11)
+	check(x, pos, 20, 1)
+	x, pos = s.Ceiling(31)
+	check(x, pos, 0, InvalidPos)
+
+	x, pos = s.Higher(20)
+	check(x, pos, 30, 3)
+	x, pos = s.Higher(30)
+	check(x, pos, 0, InvalidPos)
+
+	empty := NewSkipList[int, int]()
+	x, pos = empty.Floor(1)
+	assert.Nil(t, x)
+	assert.Equal(t, InvalidPos, pos)
+}
+
+func TestRangeGet(t *testing.T) {
+	s := Collect(pairSeq(1, 3, 5, 7, 9))
+	s.MarkDeleted(5)
+	nodes := s.RangeGet(2, 9)
+	keys := make([]int, len(nodes))
+	for i, x := range nodes {
+		keys[i] = x.Key()
+	}
+	assert.Equal(t, []int{3, 7, 9}, keys)
+	assert.Empty(t, s.RangeGet(10, 20))
+	assert.Empty(t, s.RangeGet(9, 1))
+}
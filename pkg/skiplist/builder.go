@@ -0,0 +1,76 @@
+package skiplist
+
+import (
+	"cmp"
+	"math"
+)
+
+// builder creates a node structure from elements appended in strictly ascending key order in O(n).
+// Instead of random levels it assigns the ideal level distribution of the probability p: every
+// round(1/p)-th element reaches level 2, every round(1/p)^2-th element level 3, and so on.
+type builder[K cmp.Ordered, V any] struct {
+	head     *Node[K, V]
+	last     []*Node[K, V] // last node on each level
+	lastPos  []int         // position of the last node on each level
+	n        int
+	base     int
+	maxLevel int
+	alloc    Allocator[K, V] // allocator of the nodes or nil for the heap
+	back     bool            // set the backward links (see WithBackPointers)
+}
+
+func newBuilder[K cmp.Ordered, V any](maxLevel int, p float64) *builder[K, V] {
+	var dummyKey K
+	var dummyValue V
+	return &builder[K, V]{
+		head:     newNode[K, V](dummyKey, dummyValue, 0, maxLevel),
+		last:     make([]*Node[K, V], 0, maxLevel),
+		lastPos:  make([]int, 0, maxLevel),
+		base:     max(2, int(math.Round(1/p))),
+		maxLevel: maxLevel,
+	}
+}
+
+func (b *builder[K, V]) level() int {
+	level := 1
+	for i := b.n + 1; i%b.base == 0 && level < b.maxLevel; i /= b.base {
+		level++
+	}
+	return level
+}
+
+// append adds a node behind all previously appended nodes. The key must be greater than all keys before.
+func (b *builder[K, V]) append(key K, value V) *Node[K, V] {
+	level := b.level()
+	for len(b.last) < level {
+		b.head.extendLevel(len(b.last) + 1)
+		b.last = append(b.last, b.head)
+		b.lastPos = append(b.lastPos, -1)
+	}
+	var x *Node[K, V]
+	if b.alloc != nil {
+		x = allocNode(b.alloc, key, value, level, level)
+	} else {
+		x = newNode[K, V](key, value, level, level)
+	}
+	if b.back {
+		x.linkPrev(b.last[0], b.head)
+	}
+	for i := 0; i < level; i++ {
+		b.last[i].next[i] = x
+		b.last[i].dist[i] = b.n - b.lastPos[i]
+		b.last[i] = x
+		b.lastPos[i] = b.n
+	}
+	b.n++
+	return x
+}
+
+// finish terminates all levels and returns the head and the number of elements.
+func (b *builder[K, V]) finish() (*Node[K, V], int) {
+	for i := range b.last {
+		b.last[i].next[i] = nil
+		b.last[i].dist[i] = b.n - b.lastPos[i]
+	}
+	return b.head, b.n
+}
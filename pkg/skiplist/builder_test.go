@@ -0,0 +1,32 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 8, 100} {
+		b := newBuilder[int, int](DefaultMaxLevel, DefaultProbability)
+		for k := 0; k < n; k++ {
+			b.append(k, k)
+		}
+		s := NewSkipList[int, int]()
+		s.head, s.count = b.finish()
+		require.NoError(t, s.Validate())
+		assert.Equal(t, n, s.Size())
+		for k := 0; k < n; k++ {
+			assert.Equal(t, k, s.GetByPos(k).Key())
+		}
+	}
+
+	b := newBuilder[int, int](3, 0.25)
+	for k := 0; k < 64; k++ {
+		b.append(k, k)
+	}
+	head, _ := b.finish()
+	assert.Equal(t, 3, head.Level())
+	assert.Equal(t, []int{1, 4, 16}, head.dist)
+}
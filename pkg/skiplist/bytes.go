@@ -0,0 +1,20 @@
+package skiplist
+
+import "unsafe"
+
+// bytesKey returns a string key sharing the memory of b without copying it. The key must not be retained
+// beyond the lookup, since b may be modified afterwards.
+func bytesKey[K ~string](b []byte) K {
+	return K(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+// GetBytes is like SkipList.Get for a key given as byte slice, e.g. read off the wire. The key is compared
+// without converting it to a string, so the lookup does not allocate.
+func GetBytes[K ~string, V any](s *SkipList[K, V], key []byte) (*Node[K, V], int) {
+	return s.Get(bytesKey[K](key))
+}
+
+// CountPrefixBytes is like CountPrefix for a prefix given as byte slice.
+func CountPrefixBytes[K ~string, V any](s *SkipList[K, V], prefix []byte) int {
+	return CountPrefix(s, bytesKey[K](prefix))
+}
@@ -0,0 +1,35 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBytes(t *testing.T) {
+	s := NewSkipList[string, int]()
+	for i, k := range []string{"apple", "banana", "cherry"} {
+		s.Set(k, i)
+	}
+	buf := []byte("banana")
+	x, pos := GetBytes(s, buf)
+	assert.Equal(t, 1, x.Value)
+	assert.Equal(t, 1, pos)
+	x, pos = GetBytes(s, []byte("durian"))
+	assert.Nil(t, x)
+	assert.Equal(t, InvalidPos, pos)
+	x, _ = GetBytes(s, nil)
+	assert.Nil(t, x)
+
+	// the stored key is not affected by modifying the buffer
+	buf[0] = 'B'
+	x, _ = s.Get("banana")
+	assert.NotNil(t, x)
+
+	assert.Equal(t, 3, CountPrefixBytes(s, nil))
+	assert.Equal(t, 1, CountPrefixBytes(s, []byte("ch")))
+	assert.Equal(t, 0, CountPrefixBytes(s, []byte("x")))
+
+	allocs := testing.AllocsPerRun(100, func() { GetBytes(s, buf) })
+	assert.Zero(t, allocs)
+}
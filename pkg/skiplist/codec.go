@@ -0,0 +1,144 @@
+package skiplist
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrInvalidEncoding is returned when an encoded key cannot be decoded.
+var ErrInvalidEncoding = errors.New("skiplist: invalid key encoding")
+
+// OrderPreservingCodec encodes keys such that comparing the encoded forms with bytes.Compare yields the
+// same order as comparing the keys themselves. This allows searching serialized (e.g. memory-mapped) skip
+// lists without decoding every candidate key. Encodings are self-delimiting, so encoded keys may be
+// concatenated to composite keys.
+type OrderPreservingCodec[K any] interface {
+	// AppendKey appends the encoding of key to dst and returns the extended buffer.
+	AppendKey(dst []byte, key K) []byte
+	// DecodeKey decodes the key at the start of src and returns it with the number of bytes consumed.
+	DecodeKey(src []byte) (K, int, error)
+}
+
+// IntCodec encodes signed integers as 8 bytes big endian with a flipped sign bit.
+type IntCodec[K ~int | ~int8 | ~int16 | ~int32 | ~int64] struct{}
+
+func (IntCodec[K]) AppendKey(dst []byte, key K) []byte {
+	return binary.BigEndian.AppendUint64(dst, uint64(key)^(1<<63))
+}
+
+func (IntCodec[K]) DecodeKey(src []byte) (K, int, error) {
+	if len(src) < 8 {
+		return 0, 0, ErrInvalidEncoding
+	}
+	return K(int64(binary.BigEndian.Uint64(src) ^ (1 << 63))), 8, nil
+}
+
+// UintCodec encodes unsigned integers as 8 bytes big endian.
+type UintCodec[K ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr] struct{}
+
+func (UintCodec[K]) AppendKey(dst []byte, key K) []byte {
+	return binary.BigEndian.AppendUint64(dst, uint64(key))
+}
+
+func (UintCodec[K]) DecodeKey(src []byte) (K, int, error) {
+	if len(src) < 8 {
+		return 0, 0, ErrInvalidEncoding
+	}
+	return K(binary.BigEndian.Uint64(src)), 8, nil
+}
+
+// FloatCodec encodes floats as 8 bytes of their IEEE 754 double representation. Negative numbers have all
+// bits flipped, positive numbers only the sign bit. Like cmp.Compare, NaNs are ordered before all other
+// values and -0 equals +0: both are encoded canonically, so the sign of a zero and NaN payloads are lost.
+type FloatCodec[K ~float32 | ~float64] struct{}
+
+func (FloatCodec[K]) AppendKey(dst []byte, key K) []byte {
+	f := float64(key)
+	var bits uint64
+	switch {
+	case math.IsNaN(f):
+		bits = 0
+	case f == 0:
+		bits = 1 << 63
+	default:
+		bits = math.Float64bits(f)
+		if bits&(1<<63) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+	}
+	return binary.BigEndian.AppendUint64(dst, bits)
+}
+
+func (FloatCodec[K]) DecodeKey(src []byte) (K, int, error) {
+	if len(src) < 8 {
+		return 0, 0, ErrInvalidEncoding
+	}
+	bits := binary.BigEndian.Uint64(src)
+	if bits&(1<<63) != 0 {
+		bits &^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+	return K(math.Float64frombits(bits)), 8, nil
+}
+
+// StringCodec encodes strings with every 0x00 byte escaped as 0x00 0xFF and terminated by 0x00 0x01.
+type StringCodec[K ~string] struct{}
+
+func (StringCodec[K]) AppendKey(dst []byte, key K) []byte {
+	for i := 0; i < len(key); i++ {
+		dst = append(dst, key[i])
+		if key[i] == 0 {
+			dst = append(dst, 0xFF)
+		}
+	}
+	return append(dst, 0x00, 0x01)
+}
+
+func (StringCodec[K]) DecodeKey(src []byte) (K, int, error) {
+	buf := make([]byte, 0, len(src))
+	for i := 0; i < len(src); i++ {
+		if src[i] != 0 {
+			buf = append(buf, src[i])
+			continue
+		}
+		if i+1 >= len(src) {
+			break
+		}
+		switch src[i+1] {
+		case 0x01:
+			return K(buf), i + 2, nil
+		case 0xFF:
+			buf = append(buf, 0)
+			i++
+		default:
+			return "", 0, ErrInvalidEncoding
+		}
+	}
+	return "", 0, ErrInvalidEncoding
+}
+
+// TimeCodec encodes a time as its Unix seconds (like IntCodec) followed by 4 bytes of nanoseconds.
+// The location is not encoded; decoded times are in UTC.
+type TimeCodec struct{}
+
+func (TimeCodec) AppendKey(dst []byte, key time.Time) []byte {
+	dst = IntCodec[int64]{}.AppendKey(dst, key.Unix())
+	return binary.BigEndian.AppendUint32(dst, uint32(key.Nanosecond()))
+}
+
+func (TimeCodec) DecodeKey(src []byte) (time.Time, int, error) {
+	sec, n, err := IntCodec[int64]{}.DecodeKey(src)
+	if err != nil || len(src) < n+4 {
+		return time.Time{}, 0, ErrInvalidEncoding
+	}
+	nsec := binary.BigEndian.Uint32(src[n:])
+	if nsec >= 1e9 {
+		return time.Time{}, 0, ErrInvalidEncoding
+	}
+	return time.Unix(sec, int64(nsec)).UTC(), n + 4, nil
+}
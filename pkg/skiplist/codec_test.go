@@ -0,0 +1,65 @@
+package skiplist
+
+import (
+	"bytes"
+	"cmp"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checkCodec[K cmp.Ordered](t *testing.T, c OrderPreservingCodec[K], keys []K) {
+	t.Helper()
+	for _, a := range keys {
+		ea := c.AppendKey(nil, a)
+		d, n, err := c.DecodeKey(append(ea, 0x42))
+		require.NoError(t, err)
+		assert.Equal(t, len(ea), n)
+		assert.Equal(t, 0, cmp.Compare(a, d), "roundtrip of %v", a)
+		for _, b := range keys {
+			eb := c.AppendKey(nil, b)
+			assert.Equal(t, cmp.Compare(a, b), bytes.Compare(ea, eb), "compare %v and %v", a, b)
+		}
+	}
+}
+
+func TestCodecs(t *testing.T) {
+	checkCodec[int](t, IntCodec[int]{}, []int{math.MinInt, -1000, -1, 0, 1, 255, 256, math.MaxInt})
+	checkCodec[int8](t, IntCodec[int8]{}, []int8{-128, -1, 0, 1, 127})
+	checkCodec[uint32](t, UintCodec[uint32]{}, []uint32{0, 1, 255, 256, math.MaxUint32})
+	checkCodec[float64](t, FloatCodec[float64]{}, []float64{math.NaN(), math.Inf(-1), -1e300, -1, -1e-300,
+		0, math.Copysign(0, -1), 1e-300, 0.5, 1, math.MaxFloat64, math.Inf(1)})
+	checkCodec[float32](t, FloatCodec[float32]{}, []float32{-3.5, -1, 0, 1, 3.5})
+	checkCodec[string](t, StringCodec[string]{}, []string{"", "\x00", "\x00\x00", "\x00\x01", "\x00\xff",
+		"a", "a\x00", "a\x00b", "ab", "b", "\xff", "\xff\xff"})
+}
+
+func TestTimeCodec(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	times := []time.Time{time.Unix(-1, 999), time.Unix(0, 0), base, base.Add(1), base.Add(time.Second - 1),
+		base.Add(time.Hour)}
+	for _, a := range times {
+		ea := TimeCodec{}.AppendKey(nil, a)
+		d, n, err := TimeCodec{}.DecodeKey(ea)
+		require.NoError(t, err)
+		assert.Equal(t, 12, n)
+		assert.True(t, a.Equal(d))
+		for _, b := range times {
+			assert.Equal(t, a.Compare(b), bytes.Compare(ea, TimeCodec{}.AppendKey(nil, b)))
+		}
+	}
+}
+
+func TestCodecInvalid(t *testing.T) {
+	_, _, err := IntCodec[int]{}.DecodeKey([]byte{1, 2})
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+	_, _, err = StringCodec[string]{}.DecodeKey([]byte("abc"))
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+	_, _, err = StringCodec[string]{}.DecodeKey([]byte{'a', 0, 2})
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+	_, _, err = TimeCodec{}.DecodeKey(make([]byte, 10))
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+}
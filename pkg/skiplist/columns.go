@@ -0,0 +1,33 @@
+package skiplist
+
+// ExportColumns returns the keys and the values in ascending order as two separate slices of equal length,
+// e.g. as the columns of an Arrow record or for vectorized scans. Soft deleted elements are skipped (see
+// MarkDeleted). Compressed values are exported decompressed.
+func (s *SkipList[K, V]) ExportColumns() ([]K, []V) {
+	keys := make([]K, 0, s.LiveSize())
+	values := make([]V, 0, s.LiveSize())
+	for x := s.First(); x != nil; x = x.Next() {
+		if !x.deleted {
+			keys = append(keys, x.key)
+			values = append(values, s.ValueOf(x))
+		}
+	}
+	return keys, values
+}
+
+// ExportColumnsRange fills keys and values with the elements with from <= key <= to in ascending order and
+// returns the number of elements written. It stops when the shorter of both slices is full, so a range can be
+// exported in batches into reused buffers; CountRange gives an upper bound of the size needed for the whole
+// range. Soft deleted elements are skipped. Compressed values are exported decompressed.
+func (s *SkipList[K, V]) ExportColumnsRange(from, to K, keys []K, values []V) int {
+	n := 0
+	limit := min(len(keys), len(values))
+	for x, _ := s.lowerBound(from); x != nil && n < limit && !s.less(to, x.key); x = x.Next() {
+		if !x.deleted {
+			keys[n] = x.key
+			values[n] = s.ValueOf(x)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,49 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportColumns(t *testing.T) {
+	s := NewSkipList[int, string]()
+	keys, values := s.ExportColumns()
+	assert.Empty(t, keys)
+	assert.Empty(t, values)
+
+	for _, k := range []int{4, 1, 3, 2, 5} {
+		s.Set(k, string(rune('a'+k-1)))
+	}
+	s.MarkDeleted(2)
+	keys, values = s.ExportColumns()
+	assert.Equal(t, []int{1, 3, 4, 5}, keys)
+	assert.Equal(t, []string{"a", "c", "d", "e"}, values)
+}
+
+func TestExportColumnsRange(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 10; k++ {
+		s.Set(k, k*10)
+	}
+	s.MarkDeleted(4)
+	keys := make([]int, s.CountRange(2, 7))
+	values := make([]int, len(keys))
+	n := s.ExportColumnsRange(2, 7, keys, values)
+	assert.Equal(t, []int{2, 3, 5, 6, 7}, keys[:n])
+	assert.Equal(t, []int{20, 30, 50, 60, 70}, values[:n])
+
+	// batches into reused buffers
+	keys, values = keys[:2], values[:3]
+	var all []int
+	for from := 0; ; {
+		n := s.ExportColumnsRange(from, 8, keys, values)
+		all = append(all, keys[:n]...)
+		if n < len(keys) {
+			break
+		}
+		from = keys[n-1] + 1
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 5, 6, 7, 8}, all)
+	assert.Equal(t, 0, s.ExportColumnsRange(7, 2, keys, values))
+}
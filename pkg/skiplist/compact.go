@@ -0,0 +1,103 @@
+package skiplist
+
+import (
+	"context"
+	"time"
+)
+
+// Compact returns a new skip list with the content of s rebuilt with an ideal level distribution. The nodes
+// are allocated freshly in key order, which releases memory fragmented by many modifications once s is
+// dropped. The options of s (including hooks) are preserved. An EventCompact is emitted on s.
+func (s *SkipList[K, V]) Compact() *SkipList[K, V] {
+	dst := s.emptyClone()
+	s.compactInto(dst)
+	return dst
+}
+
+// CompactWithReport compacts the skip list like Compact and returns a report comparing s and the result.
+// Measuring both lists costs two additional passes.
+func (s *SkipList[K, V]) CompactWithReport() (*SkipList[K, V], MaintenanceReport) {
+	start := time.Now()
+	dst := s.Compact()
+	duration := time.Since(start)
+	report := newMaintenanceReport("Compact", s, dst)
+	report.Duration = duration
+	return dst, report
+}
+
+// CloneExact returns a deep copy of the skip list which reproduces the exact structure: every node has the
+// same level and distances as in s. Operations replayed on the clone and on s take the same paths as long as
+// they draw the same levels, which makes structure dependent behavior reproducible in tests and debugging
+// sessions. The options of s are preserved; note that a stateful level function (e.g. WithSeed) is shared.
+func (s *SkipList[K, V]) CloneExact() *SkipList[K, V] {
+	c := s.emptyClone()
+	c.head = s.copyNodes()
+	c.count = s.count
+	c.deleted = s.deleted
+	c.nextID = s.nextID
+	c.nextSeq = s.nextSeq
+	if c.stableIDs {
+		c.indexIDs()
+	}
+	if c.interned != nil {
+		c.indexKeys()
+	}
+	return c
+}
+
+// emptyClone returns a list without nodes having the options of s.
+func (s *SkipList[K, V]) emptyClone() *SkipList[K, V] {
+	s.lazyInit()
+	c := &SkipList[K, V]{config: s.config}
+	c.admit = s.admit
+	c.keyBounds = s.keyBounds
+	c.keyLevelFunc = s.keyLevelFunc
+	if s.interned != nil {
+		c.interned = newInternMap[K](0)
+	}
+	c.onPressure = s.onPressure
+	c.retention = s.retention
+	c.sizer = s.sizer
+	c.allocator = s.allocator
+	c.copier = s.copier
+	c.codec = s.codec
+	c.tieBreak = s.tieBreak
+	return c
+}
+
+// CompactInto rebuilds the content of s with an ideal level distribution into dst, replacing the content
+// of dst. The options of dst are kept. An EventCompact is emitted on s.
+func (s *SkipList[K, V]) CompactInto(dst *SkipList[K, V]) {
+	s.lazyInit()
+	dst.lazyInit()
+	s.compactInto(dst)
+}
+
+// compactInto implements CompactInto; dst may be an empty clone without head.
+func (s *SkipList[K, V]) compactInto(dst *SkipList[K, V]) {
+	start := time.Now()
+	end := s.trace(context.Background(), "Compact", s.count)
+	b := newBuilder[K, V](dst.maxLevel, dst.p)
+	b.alloc = dst.allocator
+	b.back = dst.backPointers
+	for x := s.First(); x != nil; x = x.Next() {
+		y := b.append(x.key, x.Value)
+		y.deleted = x.deleted
+		y.id = x.id
+		y.modified = x.modified
+		y.seq = x.seq
+	}
+	dst.releaseNodes()
+	dst.head, dst.count = b.finish()
+	dst.deleted = s.deleted
+	dst.nextSeq = max(dst.nextSeq, s.nextSeq)
+	if dst.stableIDs {
+		dst.nextID = max(dst.nextID, s.nextID)
+		dst.indexIDs()
+	}
+	if dst.interned != nil {
+		dst.indexKeys()
+	}
+	end(dst.count)
+	s.emit(Event{Type: EventCompact, Level: dst.Level(), Count: dst.count, Duration: time.Since(start)})
+}
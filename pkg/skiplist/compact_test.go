@@ -0,0 +1,71 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	var events []Event
+	s := NewSkipList[int, int](WithMaxLevel(8), WithEventHandler(func(e Event) { events = append(events, e) }))
+	for _, k := range makeRandomData(1000) {
+		s.Set(k, k)
+	}
+	for k := 0; k < 1000; k += 3 {
+		s.Remove(k)
+	}
+
+	c := s.Compact()
+	require.NoError(t, c.Validate())
+	assert.Equal(t, s.Size(), c.Size())
+	assert.Equal(t, 8, c.maxLevel)
+	for x, y := s.First(), c.First(); x != nil; x, y = x.Next(), y.Next() {
+		assert.Equal(t, x.Key(), y.Key())
+		assert.Equal(t, x.Value, y.Value)
+		assert.NotSame(t, x, y)
+	}
+	assert.Equal(t, EventCompact, events[len(events)-1].Type)
+	assert.Equal(t, s.Size(), events[len(events)-1].Count)
+
+	// the compacted list keeps working with the preserved options
+	c.Set(2000, 1)
+	assert.NotEmpty(t, events)
+
+	dst := NewSkipList[int, int]()
+	dst.Set(-1, -1)
+	s.CompactInto(dst)
+	require.NoError(t, dst.Validate())
+	assert.Equal(t, s.Size(), dst.Size())
+	x, _ := dst.Get(-1)
+	assert.Nil(t, x)
+
+	// zero value lists
+	var zero SkipList[int, int]
+	s.CompactInto(&zero)
+	require.NoError(t, zero.Validate())
+	assert.Equal(t, s.Size(), zero.Size())
+	var empty SkipList[int, int]
+	empty.CompactInto(dst)
+	assert.Equal(t, 0, dst.Size())
+}
+
+func TestCloneExact(t *testing.T) {
+	s := NewSkipList[int, int](WithSeed(7))
+	for _, k := range makeRandomData(500) {
+		s.Set(k, k)
+	}
+	s.MarkDeleted(10)
+	c := s.CloneExact()
+	require.NoError(t, c.Validate())
+	requireSameStructure(t, s, c)
+	assert.Equal(t, s.LiveSize(), c.LiveSize())
+
+	// the clone does not share nodes
+	c.Set(1000, 1000)
+	c.First().Value = -1
+	assert.Equal(t, 500, s.Size())
+	assert.Equal(t, 0, s.First().Value)
+	require.NoError(t, s.Validate())
+}
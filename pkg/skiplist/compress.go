@@ -0,0 +1,73 @@
+package skiplist
+
+import "cmp"
+
+// valueCodec compresses the stored values (see WithValueCodec).
+type valueCodec[V any] struct {
+	compress   func(V) V
+	decompress func(V) V
+	size       func(V) int
+}
+
+// CompressionStats counts the values compressed by the codec of WithValueCodec and their sizes in bytes before and
+// after the compression since the skip list was created.
+type CompressionStats struct {
+	Values          int   `json:"values"`
+	RawBytes        int64 `json:"rawBytes"`
+	CompressedBytes int64 `json:"compressedBytes"`
+}
+
+// Ratio returns the compressed size relative to the raw size of the values, e.g. 0.25 if the values were
+// compressed to a quarter, or 1 if no values were compressed.
+func (c CompressionStats) Ratio() float64 {
+	if c.RawBytes == 0 {
+		return 1
+	}
+	return float64(c.CompressedBytes) / float64(c.RawBytes)
+}
+
+// WithValueCodec stores the values compressed, trading CPU for memory on large values like JSON documents.
+// All values passed in (e.g. by Set, Load, MergeSorted, or UpdateRange) are compressed before they are stored,
+// and all values passed out (e.g. by GetCopy, Range, Reduce, Diff, or the ConcurrentSkipList) are decompressed.
+// Only Node.Value and GetRef expose the compressed value; ValueOf decompresses it. compress must return a value not sharing memory with its argument (which makes WithValueCopier
+// unnecessary), and decompress must return a value not sharing memory with the stored one.
+// The type parameters are inferred from `compress`.
+func WithValueCodec[K cmp.Ordered, V ~string | ~[]byte](compress, decompress func(V) V) Option {
+	return typedOption(func(s *SkipList[K, V]) {
+		s.codec = &valueCodec[V]{
+			compress:   compress,
+			decompress: decompress,
+			size:       func(v V) int { return len(v) },
+		}
+	})
+}
+
+// ValueOf returns the value of the node x of the skip list, decompressed if the list has a value codec (see
+// WithValueCodec).
+func (s *SkipList[K, V]) ValueOf(x *Node[K, V]) V {
+	if s.codec != nil {
+		return s.codec.decompress(x.Value)
+	}
+	return x.Value
+}
+
+// CompressionStats returns the statistics of the value codec (see WithValueCodec).
+func (s *SkipList[K, V]) CompressionStats() CompressionStats {
+	return s.compression
+}
+
+// storeValue returns the value to store for a value passed in: it is compressed or copied (see WithValueCodec
+// and WithValueCopier).
+func (s *SkipList[K, V]) storeValue(value V) V {
+	if s.codec != nil {
+		stored := s.codec.compress(value)
+		s.compression.Values++
+		s.compression.RawBytes += int64(s.codec.size(value))
+		s.compression.CompressedBytes += int64(s.codec.size(stored))
+		return stored
+	}
+	if s.copier != nil {
+		return s.copier(value)
+	}
+	return value
+}
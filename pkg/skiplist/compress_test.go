@@ -0,0 +1,132 @@
+package skiplist
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deflate(v []byte) []byte {
+	var buf bytes.Buffer
+	w, _ := flate.NewWriter(&buf, flate.BestSpeed)
+	_, _ = w.Write(v)
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func inflate(v []byte) []byte {
+	out, err := io.ReadAll(flate.NewReader(bytes.NewReader(v)))
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func TestValueCodec(t *testing.T) {
+	s := NewSkipList[int, []byte](WithValueCodec[int](deflate, inflate))
+	doc := []byte(`{"items":[` + strings.Repeat(`{"name":"value"},`, 100) + `{}]}`)
+	for i := 0; i < 10; i++ {
+		s.Set(i, doc)
+	}
+	x, _ := s.Get(3)
+	assert.Less(t, len(x.Value), len(doc))
+	assert.Equal(t, doc, s.ValueOf(x))
+	v, ok := s.GetCopy(3)
+	require.True(t, ok)
+	assert.Equal(t, doc, v)
+
+	s.Range(2, 4, func(key int, value []byte) bool {
+		assert.Equal(t, doc, value)
+		return true
+	})
+	pairs := s.AppendRange(nil, 0, 9)
+	require.Len(t, pairs, 10)
+	assert.Equal(t, doc, pairs[9].Value)
+
+	stats := s.CompressionStats()
+	assert.Equal(t, 10, stats.Values)
+	assert.Equal(t, int64(10*len(doc)), stats.RawBytes)
+	assert.Less(t, stats.Ratio(), 0.2)
+	assert.Equal(t, 1.0, NewSkipList[int, []byte]().CompressionStats().Ratio())
+}
+
+func TestValueCodecString(t *testing.T) {
+	s := NewSkipList[string, string](WithValueCodec[string](strings.ToUpper, strings.ToLower))
+	s.Set("a", "hello")
+	x, _ := s.Get("a")
+	assert.Equal(t, "HELLO", x.Value)
+	v, _ := s.GetCopy("a")
+	assert.Equal(t, "hello", v)
+}
+
+func TestValueCodecHidden(t *testing.T) {
+	codec := WithValueCodec[int](strings.ToUpper, strings.ToLower)
+	s := NewSkipList[int, string](codec)
+	s.Load([]Pair[int, string]{{1, "a"}, {2, "b"}, {3, "c"}})
+	x, _ := s.Get(1)
+	assert.Equal(t, "A", x.Value)
+	assert.Equal(t, "abc", Reduce(s, 1, 3, "", func(acc string, _ int, v string) string { return acc + v }))
+	assert.Equal(t, "abc", ReduceParallel(s, 1, 3, "", func(acc string, _ int, v string) string { return acc + v },
+		func(a, b string) string { return a + b }, 2))
+	groups := GroupBy(s, func(k int) int { return k / 2 }, func(acc string, _ int, v string) string { return acc + v },
+		codec)
+	assert.Equal(t, []string{"a", "bc"}, slices.Collect(groups.Values()))
+	odd := GroupBy(s, func(k int) int { return k % 2 }, func(acc string, _ int, v string) string { return acc + v },
+		codec)
+	assert.Equal(t, []string{"b", "ac"}, slices.Collect(odd.Values()))
+	mapped := MapValues(s, func(_ int, v string) string { return v + v }, codec)
+	assert.Equal(t, []string{"aa", "bb", "cc"}, slices.Collect(mapped.Values()))
+	assert.Equal(t, "b", s.AsSortedSlice().Value(1))
+	assert.Equal(t, []Group[int, string]{{Key: 2, Values: []string{"b"}}}, s.GetRangeGrouped(2, 2))
+
+	base := s.Snapshot()
+	s.Set(2, "x")
+	s.Remove(3)
+	changes := s.Diff(base, func(a, b string) bool { return a == b })
+	assert.Equal(t, []Change[int, string]{
+		{Key: 2, Base: "b", HasBase: true, Value: "x"},
+		{Key: 3, Base: "c", HasBase: true, Removed: true},
+	}, changes)
+	var buf bytes.Buffer
+	require.NoError(t, WriteDiff(&buf, changes, intStringCodec))
+	applied, err := base.ApplyDiff(&buf, intStringCodec, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.Equal(t, []string{"a", "x"}, slices.Collect(base.Values()))
+
+	n := NewNested[int, int, string](codec)
+	n.Set(1, 1, "a")
+	n.ForEach(func(_, _ int, v string) bool {
+		assert.Equal(t, "a", v)
+		return true
+	})
+
+	c := NewConcurrentSkipList[int, string](codec)
+	for k, v := range map[int]string{1: "a", 2: "b", 3: "c", 4: "d"} {
+		c.Set(k, v)
+	}
+	for _, mode := range []ReadMode{Fast, Consistent} {
+		var values []string
+		c.Range(1, 4, mode, func(_ int, v string) bool {
+			values = append(values, v)
+			return true
+		})
+		assert.Equal(t, []string{"a", "b", "c", "d"}, values)
+	}
+	v, _ := c.Remove(1)
+	assert.Equal(t, "a", v)
+	_, v, _ = c.PopFirst()
+	assert.Equal(t, "b", v)
+	_, v, _ = c.PopLast()
+	assert.Equal(t, "d", v)
+	_, v, err = c.WaitFirst(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "c", v)
+}
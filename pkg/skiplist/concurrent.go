@@ -0,0 +1,335 @@
+package skiplist
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// ReadMode selects the consistency of reading operations of the ConcurrentSkipList.
+type ReadMode int
+
+const (
+	// Fast reads do not block writers for longer than a short step. Sizes may not yet reflect
+	// concurrently running writes and ranges may observe writes done while they are iterated.
+	Fast ReadMode = iota
+	// Consistent reads observe a single state of the skip list, which existed at one point in time.
+	Consistent
+)
+
+// rangeChunk is the number of elements read with one lock acquisition by Fast ranges.
+const rangeChunk = 64
+
+// ConcurrentSkipList wraps a SkipList with a read-write mutex, so it can be used from multiple goroutines.
+type ConcurrentSkipList[K cmp.Ordered, V any] struct {
+	mu      sync.RWMutex
+	list    *SkipList[K, V]
+	size    atomic.Int64
+	changed chan struct{} // closed by the next insert to wake up WaitFirst, nil if nobody waits
+	loadMu  sync.Mutex
+	loads   map[K]*loadCall[V]    // running loads of GetOrLoad by key
+	snap    *sharedSnapshot[K, V] // snapshot read by Consistent scans, nil if none is running
+}
+
+// sharedSnapshot is a snapshot read by concurrent Consistent scans.
+type sharedSnapshot[K cmp.Ordered, V any] struct {
+	list    *SkipList[K, V]
+	readers int
+}
+
+// NewConcurrentSkipList creates a new empty ConcurrentSkipList object.
+func NewConcurrentSkipList[K cmp.Ordered, V any](options ...Option) *ConcurrentSkipList[K, V] {
+	return &ConcurrentSkipList[K, V]{list: NewSkipList[K, V](options...)}
+}
+
+// Set sets the value of `key`. Returns true if a new element was created.
+func (c *ConcurrentSkipList[K, V]) Set(key K, value V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _, created := c.list.Set(key, value)
+	c.modified(created)
+	return created
+}
+
+// SetIdempotent sets the value of `key` unless the request `requestID` was applied before like
+// SkipList.SetIdempotent. The check and the write are atomic, so concurrent appliers of redelivered requests
+// apply each request once.
+func (c *ConcurrentSkipList[K, V]) SetIdempotent(requestID uint64, key K, value V) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	applied, created, err := c.list.setIdempotent(requestID, key, value)
+	if applied {
+		c.modified(created)
+	}
+	return applied, err
+}
+
+// modified updates the size after a modification and wakes up the waiters of WaitFirst if an element was
+// inserted. The write lock must be held.
+func (c *ConcurrentSkipList[K, V]) modified(inserted bool) {
+	c.size.Store(int64(c.list.Size()))
+	if inserted && c.changed != nil {
+		close(c.changed)
+		c.changed = nil
+	}
+}
+
+// Get returns the value of `key` and true or the zero value and false if the key was not found.
+func (c *ConcurrentSkipList[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.GetCopy(key)
+}
+
+// Remove removes `key` and returns its value and true or the zero value and false if the key was not found.
+func (c *ConcurrentSkipList[K, V]) Remove(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	x, _ := c.list.Remove(key)
+	c.size.Store(int64(c.list.Size()))
+	if x == nil {
+		var zero V
+		return zero, false
+	}
+	return c.list.ValueOf(x), true
+}
+
+// GetByPos returns the key and the value at position k or false if k is out of range.
+func (c *ConcurrentSkipList[K, V]) GetByPos(k int) (K, V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	x := c.list.GetByPos(k)
+	if x == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return x.key, c.list.ValueOf(x), true
+}
+
+// RemoveByPos removes the element at position k and returns its key and value or false if k is out of range.
+func (c *ConcurrentSkipList[K, V]) RemoveByPos(k int) (K, V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	x := c.list.RemoveByPos(k)
+	c.modified(false)
+	if x == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return x.key, c.list.ValueOf(x), true
+}
+
+// View calls fn with the wrapped list while holding the read lock, so a sequence of reads (e.g. a position
+// lookup followed by a range) observes a single state. fn must not modify the list or keep references to it.
+func (c *ConcurrentSkipList[K, V]) View(fn func(s *SkipList[K, V])) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	fn(c.list)
+}
+
+// Update calls fn with the wrapped list while holding the write lock, so compound modifications (e.g. finding a
+// position and removing the element there) are atomic. fn must not keep references to the list.
+func (c *ConcurrentSkipList[K, V]) Update(fn func(s *SkipList[K, V])) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	size := c.list.Size()
+	fn(c.list)
+	c.modified(c.list.Size() > size)
+}
+
+// All returns an iterator over all elements in ascending order, which reads like Range in the given mode.
+func (c *ConcurrentSkipList[K, V]) All(mode ReadMode) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c.scan(nil, nil, mode, yield)
+	}
+}
+
+// Size returns the number of elements. In Fast mode the size is read without locking and does not wait
+// for running writes, in Consistent mode it waits for them.
+func (c *ConcurrentSkipList[K, V]) Size(mode ReadMode) int {
+	if mode == Fast {
+		return int(c.size.Load())
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Size()
+}
+
+// CountRange returns the number of elements with from <= key <= to. The count is always consistent.
+func (c *ConcurrentSkipList[K, V]) CountRange(from, to K) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.CountRange(from, to)
+}
+
+// Range calls fn for all elements with from <= key <= to in ascending order until fn returns false.
+// fn is called without holding the lock, so it may access the list.
+//
+// In Fast mode the elements are read in small chunks, writers may modify the list between the chunks.
+// In Consistent mode the range is read from an O(1) snapshot (see SkipList.Snapshot). Consistent reads running
+// at the same time share one snapshot as long as the list is not modified. The first write while a snapshot
+// is read copies all nodes in O(n) under the write lock; writes after the last reader of the snapshot is done
+// don't copy.
+func (c *ConcurrentSkipList[K, V]) Range(from, to K, mode ReadMode, fn func(key K, value V) bool) {
+	c.scan(&from, &to, mode, fn)
+}
+
+// scan calls fn for the elements with from <= key <= to like Range. A nil bound is unlimited.
+func (c *ConcurrentSkipList[K, V]) scan(from, to *K, mode ReadMode, fn func(key K, value V) bool) {
+	seek := func(s *SkipList[K, V], from *K) *Node[K, V] {
+		if from == nil {
+			return s.First()
+		}
+		x, _ := s.lowerBound(*from)
+		return x
+	}
+	inRange := func(x *Node[K, V]) bool {
+		return x != nil && (to == nil || !c.list.less(*to, x.key))
+	}
+	if mode == Consistent {
+		snap := c.acquireSnapshot()
+		defer c.releaseSnapshot(snap)
+		for x := seek(snap.list, from); inRange(x); x = x.Next() {
+			if !fn(x.key, snap.list.ValueOf(x)) {
+				return
+			}
+		}
+		return
+	}
+
+	keys := make([]K, 0, rangeChunk)
+	values := make([]V, 0, rangeChunk)
+	for {
+		keys, values = keys[:0], values[:0]
+		c.mu.RLock()
+		x := seek(c.list, from)
+		// the chunk is extended to all elements with the key of its last element, so the next chunk starts
+		// behind them (see updateRange)
+		for ; inRange(x) && (len(keys) < rangeChunk || x.key == keys[len(keys)-1]); x = x.Next() {
+			keys = append(keys, x.key)
+			values = append(values, c.list.ValueOf(x))
+		}
+		more := inRange(x)
+		if more {
+			next := x.key
+			from = &next
+		}
+		c.mu.RUnlock()
+
+		for i := range keys {
+			if !fn(keys[i], values[i]) {
+				return
+			}
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// acquireSnapshot returns the shared snapshot of the list, which is taken anew if the list was modified since
+// the last one was taken. The snapshot must be released by releaseSnapshot.
+func (c *ConcurrentSkipList[K, V]) acquireSnapshot() *sharedSnapshot[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// every modification of the list drops its reference to shared nodes (see ensureOwned)
+	if c.snap == nil || c.snap.list.refs != c.list.refs {
+		c.snap = &sharedSnapshot[K, V]{list: c.list.Snapshot()}
+	}
+	c.snap.readers++
+	return c.snap
+}
+
+// releaseSnapshot releases a snapshot returned by acquireSnapshot. The nodes are released by the last reader.
+func (c *ConcurrentSkipList[K, V]) releaseSnapshot(snap *sharedSnapshot[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if snap.readers--; snap.readers > 0 {
+		return
+	}
+	snap.list.releaseNodes()
+	if c.snap == snap {
+		c.snap = nil
+	}
+}
+
+// UpdateRange replaces the value of every element with from <= key <= to by fn(key, value) like
+// SkipList.UpdateRange and returns the number of updated elements. The range is updated in chunks of about
+// rangeChunk elements, each under the write lock: every value is replaced atomically and readers never observe
+// a partial update of an element, but other operations may run between the chunks, so elements inserted
+// meanwhile into the already updated part of the range are not updated. fn is called while holding the write
+// lock and must not access the list.
+func (c *ConcurrentSkipList[K, V]) UpdateRange(from, to K, fn func(key K, value V) V) int {
+	total := 0
+	for {
+		c.mu.Lock()
+		n, next := c.list.updateRange(from, to, rangeChunk, fn)
+		if next != nil {
+			from = next.key
+		}
+		c.mu.Unlock()
+		total += n
+		if next == nil {
+			return total
+		}
+	}
+}
+
+// PopFirst removes the element with the smallest key and returns it. The bool is false if the list is empty.
+func (c *ConcurrentSkipList[K, V]) PopFirst() (K, V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.popFirst()
+}
+
+// PopLast removes the element with the largest key and returns it. The bool is false if the list is empty.
+func (c *ConcurrentSkipList[K, V]) PopLast() (K, V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pop(c.list.PopMax())
+}
+
+func (c *ConcurrentSkipList[K, V]) popFirst() (K, V, bool) {
+	return c.pop(c.list.PopMin())
+}
+
+// pop returns the element of the removed node x.
+func (c *ConcurrentSkipList[K, V]) pop(x *Node[K, V]) (K, V, bool) {
+	if x == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	c.size.Store(int64(c.list.Size()))
+	return x.key, c.list.ValueOf(x), true
+}
+
+// WaitFirst removes the element with the smallest key and returns it like PopFirst, but blocks until the list
+// is not empty. Returns the error of the context if it is done before. Multiple waiters are woken up by every
+// insert and compete for the elements; each element is returned to a single waiter.
+func (c *ConcurrentSkipList[K, V]) WaitFirst(ctx context.Context) (K, V, error) {
+	for {
+		c.mu.Lock()
+		if key, value, ok := c.popFirst(); ok {
+			c.mu.Unlock()
+			return key, value, nil
+		}
+		if c.changed == nil {
+			c.changed = make(chan struct{})
+		}
+		changed := c.changed
+		c.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			var key K
+			var value V
+			return key, value, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package skiplist
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentSkipList(t *testing.T) {
+	c := NewConcurrentSkipList[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for k := w; k < 1000; k += 4 {
+				c.Set(k, k)
+			}
+		}(w)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			n := 0
+			c.Range(0, 1000, Consistent, func(_, _ int) bool { n++; return true })
+			c.Range(0, 1000, Fast, func(_, _ int) bool { return true })
+			assert.LessOrEqual(t, n, c.Size(Consistent))
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, 1000, c.Size(Fast))
+	assert.Equal(t, 1000, c.Size(Consistent))
+	assert.Equal(t, 11, c.CountRange(10, 20))
+
+	v, ok := c.Get(5)
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+	v, ok = c.Remove(5)
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+	_, ok = c.Remove(5)
+	assert.False(t, ok)
+	assert.Equal(t, 999, c.Size(Fast))
+}
+
+func TestConcurrentSkipListRange(t *testing.T) {
+	c := NewConcurrentSkipList[int, int]()
+	for k := 0; k < 500; k++ {
+		c.Set(k, 2*k)
+	}
+	for _, mode := range []ReadMode{Fast, Consistent} {
+		var keys []int
+		c.Range(10, 300, mode, func(k, v int) bool {
+			assert.Equal(t, 2*k, v)
+			keys = append(keys, k)
+			return true
+		})
+		assert.Len(t, keys, 291)
+		assert.Equal(t, 10, keys[0])
+		assert.Equal(t, 300, keys[290])
+
+		n := 0
+		c.Range(0, 500, mode, func(_, _ int) bool { n++; return n < 100 })
+		assert.Equal(t, 100, n)
+	}
+}
+
+func TestConcurrentSkipListRangeDuplicates(t *testing.T) {
+	c := NewConcurrentSkipList[int, int](WithDuplicates())
+	for i := 0; i < 3*rangeChunk; i++ {
+		c.Set(1, i)
+		c.Set(2, i)
+	}
+	c.Set(0, 0)
+	c.Set(3, 0)
+	for _, mode := range []ReadMode{Fast, Consistent} {
+		var keys []int
+		for k := range c.All(mode) {
+			keys = append(keys, k)
+		}
+		require.Len(t, keys, 6*rangeChunk+2)
+		assert.True(t, slices.IsSorted(keys))
+
+		n := 0
+		c.Range(1, 1, mode, func(_, _ int) bool { n++; return true })
+		assert.Equal(t, 3*rangeChunk, n)
+	}
+}
+
+func TestConcurrentSkipListSharedSnapshot(t *testing.T) {
+	c := NewConcurrentSkipList[int, int]()
+	for k := 0; k < 10; k++ {
+		c.Set(k, k)
+	}
+	outer, inner, after := 0, 0, 0
+	c.Range(0, 100, Consistent, func(k, _ int) bool {
+		outer++
+		if k > 0 {
+			return true
+		}
+		snap := c.snap
+		c.Range(0, 100, Consistent, func(int, int) bool {
+			assert.Same(t, snap, c.snap)
+			assert.Equal(t, 2, snap.readers)
+			inner++
+			return true
+		})
+		// a write invalidates the shared snapshot for the following reads only
+		c.Set(50, 50)
+		c.Range(0, 100, Consistent, func(int, int) bool {
+			assert.NotSame(t, snap, c.snap)
+			after++
+			return true
+		})
+		return true
+	})
+	assert.Equal(t, []int{10, 10, 11}, []int{outer, inner, after})
+	assert.Nil(t, c.snap)
+	assert.Equal(t, int32(1), *c.list.refs)
+}
+
+func TestConcurrentSkipListWaitFirst(t *testing.T) {
+	c := NewConcurrentSkipList[int, string]()
+	_, _, ok := c.PopFirst()
+	assert.False(t, ok)
+	c.Set(2, "b")
+	c.Set(1, "a")
+	key, value, ok := c.PopFirst()
+	assert.True(t, ok)
+	assert.Equal(t, 1, key)
+	assert.Equal(t, "a", value)
+	key, _, err := c.WaitFirst(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, key)
+
+	// waiters block until elements arrive, every element is received once
+	const n = 100
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan int, n)
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				key, _, err := c.WaitFirst(ctx)
+				if err != nil {
+					return
+				}
+				received <- key
+			}
+		}()
+	}
+	for k := 0; k < n; k++ {
+		c.Set(k, "v")
+	}
+	var keys []int
+	for len(keys) < n {
+		keys = append(keys, <-received)
+	}
+	cancel()
+	wg.Wait()
+	var want []int
+	for k := 0; k < n; k++ {
+		want = append(want, k)
+	}
+	assert.ElementsMatch(t, want, keys)
+	assert.Equal(t, 0, c.Size(Consistent))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c = NewConcurrentSkipList[int, string]()
+	_, _, err = c.WaitFirst(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestConcurrentSkipListPositions(t *testing.T) {
+	c := NewConcurrentSkipList[int, int]()
+	for k := 0; k < 300; k++ {
+		c.Set(k, k*2)
+	}
+	key, value, ok := c.GetByPos(10)
+	require.True(t, ok)
+	assert.Equal(t, 10, key)
+	assert.Equal(t, 20, value)
+	_, _, ok = c.GetByPos(300)
+	assert.False(t, ok)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				_, _, ok := c.RemoveByPos(0)
+				assert.True(t, ok)
+			}
+		}()
+	}
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				prev := -1
+				for k := range c.All(Fast) {
+					assert.Greater(t, k, prev)
+					prev = k
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, c.Size(Fast))
+
+	// remove every second element atomically by position
+	c.Update(func(s *SkipList[int, int]) {
+		for pos := 0; pos < s.Size(); pos++ {
+			s.RemoveByPos(pos)
+		}
+	})
+	assert.Equal(t, 50, c.Size(Fast))
+	c.View(func(s *SkipList[int, int]) {
+		require.NoError(t, s.Validate())
+	})
+	var keys []int
+	for k := range c.All(Consistent) {
+		keys = append(keys, k)
+		if len(keys) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int{201, 203, 205}, keys)
+}
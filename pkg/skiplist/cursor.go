@@ -0,0 +1,87 @@
+package skiplist
+
+import (
+	"cmp"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCursor is returned (wrapped) when a cursor token cannot be parsed.
+var ErrInvalidCursor = errors.New("skiplist: invalid cursor")
+
+// cursorFormat is the first byte of the tokens of started cursors.
+const cursorFormat = 1
+
+// Cursor marks the position of a paginated traversal by the last key returned, so it does not depend on the
+// nodes or positions of a particular skip list. It stays valid across modifications, rebuilds, and process
+// restarts (see Cursor.Marshal): if the last key was removed meanwhile, the traversal continues with the next
+// existing key. The zero Cursor starts at the first element. Lists with duplicates are not supported.
+type Cursor[K cmp.Ordered] struct {
+	after   K
+	started bool
+}
+
+// CursorAfter returns a cursor continuing a traversal after `key`.
+func CursorAfter[K cmp.Ordered](key K) Cursor[K] {
+	return Cursor[K]{after: key, started: true}
+}
+
+// Key returns the last key returned before the cursor and true, or false for a cursor at the start.
+func (c Cursor[K]) Key() (K, bool) {
+	return c.after, c.started
+}
+
+// Page returns up to `limit` nodes following the cursor, skipping soft deleted ones, and the cursor to
+// continue with. The returned cursor equals c if no nodes follow.
+func (s *SkipList[K, V]) Page(c Cursor[K], limit int) ([]*Node[K, V], Cursor[K]) {
+	x := s.Resolve(c)
+	var nodes []*Node[K, V]
+	for ; x != nil && len(nodes) < limit; x = x.Next() {
+		if !x.deleted {
+			nodes = append(nodes, x)
+			c = CursorAfter(x.key)
+		}
+	}
+	return nodes, c
+}
+
+// Resolve returns the first node following the cursor (including soft deleted ones) or nil if there is none.
+func (s *SkipList[K, V]) Resolve(c Cursor[K]) *Node[K, V] {
+	if !c.started {
+		return s.First()
+	}
+	x, _ := s.upperBound(c.after)
+	return x
+}
+
+// Marshal encodes the cursor into a URL safe token using the key codec `keys`. The token of the zero cursor
+// is the empty string.
+func (c Cursor[K]) Marshal(keys OrderPreservingCodec[K]) string {
+	if !c.started {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(keys.AppendKey([]byte{cursorFormat}, c.after))
+}
+
+// ParseCursor decodes a token created by Cursor.Marshal with the same key codec.
+func ParseCursor[K cmp.Ordered](token string, keys OrderPreservingCodec[K]) (Cursor[K], error) {
+	if token == "" {
+		return Cursor[K]{}, nil
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor[K]{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if len(buf) == 0 || buf[0] != cursorFormat {
+		return Cursor[K]{}, fmt.Errorf("%w: unknown format", ErrInvalidCursor)
+	}
+	key, n, err := keys.DecodeKey(buf[1:])
+	if err != nil {
+		return Cursor[K]{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if n != len(buf)-1 {
+		return Cursor[K]{}, fmt.Errorf("%w: trailing bytes", ErrInvalidCursor)
+	}
+	return CursorAfter(key), nil
+}
@@ -0,0 +1,57 @@
+package skiplist
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keysOf[K cmp.Ordered, V any](nodes []*Node[K, V]) []K {
+	var keys []K
+	for _, x := range nodes {
+		keys = append(keys, x.Key())
+	}
+	return keys
+}
+
+func TestPage(t *testing.T) {
+	s := NewSkipList[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		s.Set(k, i)
+	}
+	s.MarkDeleted("b")
+	page, c := s.Page(Cursor[string]{}, 3)
+	assert.Equal(t, []string{"a", "c", "d"}, keysOf(page))
+	key, ok := c.Key()
+	assert.True(t, ok)
+	assert.Equal(t, "d", key)
+
+	// the cursor survives a restart from a rebuilt list, where its key is missing
+	token := c.Marshal(StringCodec[string]{})
+	rebuilt := s.Compact()
+	rebuilt.Remove("d")
+	c, err := ParseCursor(token, StringCodec[string]{})
+	require.NoError(t, err)
+	page, c = rebuilt.Page(c, 3)
+	assert.Equal(t, []string{"e", "f", "g"}, keysOf(page))
+	page, c2 := rebuilt.Page(c, 3)
+	assert.Empty(t, page)
+	assert.Equal(t, c, c2)
+}
+
+func TestCursorTokens(t *testing.T) {
+	assert.Equal(t, "", Cursor[int]{}.Marshal(IntCodec[int]{}))
+	c, err := ParseCursor("", IntCodec[int]{})
+	require.NoError(t, err)
+	assert.Equal(t, Cursor[int]{}, c)
+	c, err = ParseCursor(CursorAfter(-42).Marshal(IntCodec[int]{}), IntCodec[int]{})
+	require.NoError(t, err)
+	assert.Equal(t, CursorAfter(-42), c)
+
+	for _, token := range []string{"!", "AA", "AQID", CursorAfter(1).Marshal(IntCodec[int]{}) + "AA"} {
+		_, err = ParseCursor(token, IntCodec[int]{})
+		assert.ErrorIs(t, err, ErrInvalidCursor, token)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !skiplist_debug
+
+package skiplist
+
+// debugChecks is true if the operations verify their assumptions on every call (build tag skiplist_debug).
+const debugChecks = false
+
+func (s *SkipList[K, V]) checkInsertion(pred *Node[K, V], key K) {}
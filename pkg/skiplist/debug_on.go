@@ -0,0 +1,23 @@
+//go:build skiplist_debug
+
+package skiplist
+
+import (
+	"fmt"
+	"log"
+)
+
+// debugChecks is true if the operations verify their assumptions on every call (build tag skiplist_debug).
+const debugChecks = true
+
+// checkInsertion verifies that the nodes surrounding the insertion point of a new node with `key` bracket the
+// key, where pred is the node behind which the new node is linked. An inconsistent key order, e.g. caused by
+// NaN keys which are not equal to themselves, is reported at once instead of corrupting the list.
+func (s *SkipList[K, V]) checkInsertion(pred *Node[K, V], key K) {
+	if pred != s.head && !s.ordered(pred.key, key) {
+		log.Panic(fmt.Errorf("%w: inserting %v behind %v violates the key order", ErrCorrupted, key, pred.key))
+	}
+	if next := pred.Next(); next != nil && !s.ordered(key, next.key) {
+		log.Panic(fmt.Errorf("%w: inserting %v before %v violates the key order", ErrCorrupted, key, next.key))
+	}
+}
@@ -0,0 +1,23 @@
+//go:build skiplist_debug
+
+package skiplist
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInsertion(t *testing.T) {
+	assert.True(t, debugChecks)
+	s := NewSkipList[float64, int]()
+	for k := 0; k < 10; k++ {
+		s.Set(float64(k), k)
+	}
+	assert.NotPanics(t, func() { s.Set(math.NaN(), 0) })
+	// NaN is not equal to itself, so a second NaN is not found and would be inserted next to the first one
+	assert.Panics(t, func() { s.Set(math.NaN(), 1) })
+	assert.Equal(t, 11, s.Size())
+	assert.NoError(t, s.Validate())
+}
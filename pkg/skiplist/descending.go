@@ -0,0 +1,55 @@
+package skiplist
+
+import "cmp"
+
+// WithDescending orders the keys descending, e.g. for a leaderboard with the highest score at position 0. All
+// order related operations follow the order of the list: First() returns the largest key, a range from `from`
+// to `to` requires from >= to, and the bounds and ranks count the elements preceding a key in the list.
+func WithDescending() Option {
+	return func(c *config) {
+		c.descending = true
+	}
+}
+
+// keyLess reports whether the key a precedes the key b in the ascending or descending order.
+func keyLess[K cmp.Ordered](descending bool, a, b K) bool {
+	if descending {
+		return cmp.Less(b, a)
+	}
+	return cmp.Less(a, b)
+}
+
+// less reports whether the key a precedes the key b in the order of the list.
+func (s *SkipList[K, V]) less(a, b K) bool {
+	if s.interned != nil && sameString(a, b) {
+		return false
+	}
+	return keyLess(s.descending, a, b)
+}
+
+// earlierKey returns the key of a and b which comes first in the order of the list.
+func (s *SkipList[K, V]) earlierKey(a, b K) K {
+	if s.less(b, a) {
+		return b
+	}
+	return a
+}
+
+// laterKey returns the key of a and b which comes last in the order of the list.
+func (s *SkipList[K, V]) laterKey(a, b K) K {
+	if s.less(a, b) {
+		return b
+	}
+	return a
+}
+
+// compare compares the keys a and b like cmp.Compare in the order of the list.
+func (s *SkipList[K, V]) compare(a, b K) int {
+	if s.interned != nil && sameString(a, b) {
+		return 0
+	}
+	if s.descending {
+		return cmp.Compare(b, a)
+	}
+	return cmp.Compare(a, b)
+}
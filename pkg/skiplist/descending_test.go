@@ -0,0 +1,93 @@
+package skiplist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescending(t *testing.T) {
+	s := NewSkipList[int, int](WithDescending())
+	for _, k := range []int{30, 10, 50, 20, 40} {
+		s.Set(k, 0)
+	}
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{50, 40, 30, 20, 10}, collectKeys(s.Iterator()))
+	assert.Equal(t, 50, s.First().Key())
+	assert.Equal(t, 40, s.GetByPos(1).Key())
+	_, pos := s.Get(20)
+	assert.Equal(t, 3, pos)
+	assert.Equal(t, []int{2, 0, 5}, s.Ranks([]int{35, 60, 0}))
+
+	var keys []int
+	s.Range(45, 20, func(key int, _ int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{40, 30, 20}, keys)
+	assert.Equal(t, []int{40, 30}, collectKeys(s.IteratorRange(40, 25)))
+
+	s.Remove(40)
+	assert.Equal(t, []int{50, 30, 20, 10}, collectKeys(s.Iterator()))
+	require.NoError(t, s.Validate())
+}
+
+func TestDescendingLoad(t *testing.T) {
+	s := NewSkipList[int, int](WithDescending())
+	assert.Error(t, s.LoadSorted([]Pair[int, int]{{1, 0}, {2, 0}}))
+	s.Load([]Pair[int, int]{{1, 0}, {3, 0}, {2, 0}})
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{3, 2, 1}, collectKeys(s.Iterator()))
+}
+
+func TestDescendingViewsAndPolicies(t *testing.T) {
+	s := NewSkipList[int, int](WithDescending())
+	for k := 10; k <= 90; k += 10 {
+		s.Set(k, k)
+	}
+	var keys []int
+	s.SubList(80, 20).Range(70, 40, func(key int, _ int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{70, 60, 50, 40}, keys)
+	keys = nil
+	s.SubList(80, 20).SubList(90, 60).Range(90, 10, func(key int, _ int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{80, 70, 60}, keys)
+
+	m := MapValues(s, func(_ int, v int) string { return "" })
+	require.NoError(t, m.Validate())
+	assert.Equal(t, 90, m.First().Key())
+
+	now := time.Unix(100, 0)
+	r := NewSkipList[int64, int](WithDescending(),
+		WithRetention[int64, int](10*time.Second, func() time.Time { return now }, func(k int64) time.Time {
+			return time.Unix(k, 0)
+		}))
+	for _, k := range []int64{95, 85, 99, 80} {
+		r.Set(k, 0)
+	}
+	assert.Equal(t, int64(99), r.First().Key())
+	assert.Equal(t, int64(95), r.Last().Key())
+	assert.Equal(t, 2, r.Size())
+	require.NoError(t, r.Validate())
+
+	// the budget fits two of three elements
+	pairs := []Pair[int, int]{{1, 0}, {2, 0}, {3, 0}}
+	small := NewSkipList[int, int](WithDescending())
+	large := NewSkipList[int, int](WithDescending())
+	small.Load(pairs[:2])
+	budget := int(float64(small.EstimatedMemory())/pressureRatio) + 1
+	small.Load(pairs)
+	large.Load(pairs)
+	small.memBudget, large.memBudget = budget, budget
+	EvictSmallest(small)
+	EvictLargest(large)
+	assert.Equal(t, []int{3, 2}, collectKeys(small.Iterator()))
+	assert.Equal(t, []int{2, 1}, collectKeys(large.Iterator()))
+}
@@ -0,0 +1,193 @@
+package skiplist
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidDiff is returned (wrapped) when a serialized diff cannot be decoded.
+var ErrInvalidDiff = errors.New("skiplist: invalid diff")
+
+// maxDiffField limits the length of a single encoded key or value of a serialized diff.
+const maxDiffField = 64 << 20
+
+// flags of a serialized change
+const (
+	diffHasBase = 1 << iota
+	diffRemoved
+)
+
+// Change is the modification of a single key between a base version and a later version of a skip list.
+type Change[K cmp.Ordered, V any] struct {
+	Key     K
+	Base    V    // value of the base version if HasBase is set
+	HasBase bool // the key existed in the base version
+	Value   V    // new value if Removed is not set
+	Removed bool // the key was removed
+}
+
+// Diff returns the changes turning base into s in key order, e.g. with base being a snapshot taken at the last
+// synchronization. Values are compared by `equal`. Soft deleted elements count as missing. Lists with
+// duplicates are not supported.
+func (s *SkipList[K, V]) Diff(base *SkipList[K, V], equal func(a, b V) bool) []Change[K, V] {
+	var changes []Change[K, V]
+	x, y := liveNode(s.First()), liveNode(base.First())
+	for x != nil || y != nil {
+		switch {
+		case y == nil || x != nil && s.less(x.key, y.key):
+			changes = append(changes, Change[K, V]{Key: x.key, Value: s.ValueOf(x)})
+			x = liveNode(x.Next())
+		case x == nil || s.less(y.key, x.key):
+			changes = append(changes, Change[K, V]{Key: y.key, Base: base.ValueOf(y), HasBase: true, Removed: true})
+			y = liveNode(y.Next())
+		default:
+			if value, baseValue := s.ValueOf(x), base.ValueOf(y); !equal(value, baseValue) {
+				changes = append(changes, Change[K, V]{Key: x.key, Base: baseValue, HasBase: true, Value: value})
+			}
+			x, y = liveNode(x.Next()), liveNode(y.Next())
+		}
+	}
+	return changes
+}
+
+// liveNode returns the first node starting at x which is not soft deleted.
+func liveNode[K cmp.Ordered, V any](x *Node[K, V]) *Node[K, V] {
+	for x != nil && x.deleted {
+		x = x.Next()
+	}
+	return x
+}
+
+// DiffCodec serializes the keys and values of a diff.
+type DiffCodec[K cmp.Ordered, V any] struct {
+	Keys        OrderPreservingCodec[K]
+	AppendValue func(dst []byte, value V) []byte // appends the encoding of value to dst
+	DecodeValue func(src []byte) (V, error)      // decodes a value encoded by AppendValue
+}
+
+// WriteDiff serializes the changes to w. Every change is written as a flags byte followed by the key, the base
+// value (if any), and the new value (if not removed), each prefixed by its length as an uvarint.
+func WriteDiff[K cmp.Ordered, V any](w io.Writer, changes []Change[K, V], codec DiffCodec[K, V]) error {
+	bw := bufio.NewWriter(w)
+	var buf, field []byte
+	for _, c := range changes {
+		var flags byte
+		if c.HasBase {
+			flags |= diffHasBase
+		}
+		if c.Removed {
+			flags |= diffRemoved
+		}
+		buf = append(buf[:0], flags)
+		field = codec.Keys.AppendKey(field[:0], c.Key)
+		buf = appendDiffField(buf, field)
+		if c.HasBase {
+			buf = appendDiffField(buf, codec.AppendValue(field[:0], c.Base))
+		}
+		if !c.Removed {
+			buf = appendDiffField(buf, codec.AppendValue(field[:0], c.Value))
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func appendDiffField(dst, field []byte) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(field)))
+	return append(dst, field...)
+}
+
+// ApplyDiff reads a diff written by WriteDiff from r and applies its changes one by one while reading. A change
+// whose base state does not match the local state of its key (the key was changed locally since the base
+// version) is a conflict: it is passed to onConflict together with the local node (nil if the key is missing)
+// and only applied if onConflict returns true. With a nil onConflict conflicting changes are skipped. Changes
+// whose result equals the local state are neither applied nor reported. Local and base values are compared
+// by their encodings. Returns the number of applied changes; on an error the changes read before remain
+// applied. Lists with duplicates are not supported.
+func (s *SkipList[K, V]) ApplyDiff(r io.Reader, codec DiffCodec[K, V],
+	onConflict func(c Change[K, V], local *Node[K, V]) bool) (int, error) {
+	br := bufio.NewReader(r)
+	applied := 0
+	var key, base, value, local []byte
+	for {
+		flags, err := br.ReadByte()
+		if err == io.EOF {
+			return applied, nil
+		} else if err != nil {
+			return applied, err
+		}
+		if flags&^(diffHasBase|diffRemoved) != 0 {
+			return applied, fmt.Errorf("%w: unknown flags %#x", ErrInvalidDiff, flags)
+		}
+		var c Change[K, V]
+		c.HasBase = flags&diffHasBase != 0
+		c.Removed = flags&diffRemoved != 0
+		if key, err = readDiffField(br, key); err != nil {
+			return applied, err
+		}
+		if c.Key, _, err = codec.Keys.DecodeKey(key); err != nil {
+			return applied, fmt.Errorf("%w: %w", ErrInvalidDiff, err)
+		}
+		if c.HasBase {
+			if base, err = readDiffField(br, base); err != nil {
+				return applied, err
+			}
+			if c.Base, err = codec.DecodeValue(base); err != nil {
+				return applied, fmt.Errorf("%w: %w", ErrInvalidDiff, err)
+			}
+		}
+		if !c.Removed {
+			if value, err = readDiffField(br, value); err != nil {
+				return applied, err
+			}
+			if c.Value, err = codec.DecodeValue(value); err != nil {
+				return applied, fmt.Errorf("%w: %w", ErrInvalidDiff, err)
+			}
+		}
+
+		x, _ := s.Get(c.Key)
+		if x != nil && x.deleted {
+			x = nil
+		}
+		if x != nil {
+			local = codec.AppendValue(local[:0], s.ValueOf(x))
+		}
+		if x == nil && c.Removed || x != nil && !c.Removed && bytes.Equal(local, value) {
+			continue
+		}
+		if x == nil && c.HasBase || x != nil && (!c.HasBase || !bytes.Equal(local, base)) {
+			if onConflict == nil || !onConflict(c, x) {
+				continue
+			}
+		}
+		if c.Removed {
+			s.Remove(c.Key)
+		} else {
+			s.Set(c.Key, c.Value)
+		}
+		applied++
+	}
+}
+
+// readDiffField reads a length prefixed field of a serialized diff into buf.
+func readDiffField(r *bufio.Reader, buf []byte) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return buf, fmt.Errorf("%w: truncated record", ErrInvalidDiff)
+	}
+	if n > maxDiffField {
+		return buf, fmt.Errorf("%w: field of %d bytes exceeds the limit", ErrInvalidDiff, n)
+	}
+	buf = append(buf[:0], make([]byte, n)...)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return buf, fmt.Errorf("%w: truncated record", ErrInvalidDiff)
+	}
+	return buf, nil
+}
@@ -0,0 +1,89 @@
+package skiplist
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var intStringCodec = DiffCodec[int, string]{
+	Keys:        IntCodec[int]{},
+	AppendValue: func(dst []byte, v string) []byte { return append(dst, v...) },
+	DecodeValue: func(src []byte) (string, error) { return string(src), nil },
+}
+
+func TestDiff(t *testing.T) {
+	base := NewSkipList[int, string]()
+	for k := 0; k < 5; k++ {
+		base.Set(k, strconv.Itoa(k))
+	}
+	s := base.Snapshot()
+	s.Set(1, "one")
+	s.Remove(2)
+	s.Set(7, "7")
+	s.MarkDeleted(4)
+	changes := s.Diff(base, func(a, b string) bool { return a == b })
+	assert.Equal(t, []Change[int, string]{
+		{Key: 1, Base: "1", HasBase: true, Value: "one"},
+		{Key: 2, Base: "2", HasBase: true, Removed: true},
+		{Key: 4, Base: "4", HasBase: true, Removed: true},
+		{Key: 7, Value: "7"},
+	}, changes)
+}
+
+func TestApplyDiff(t *testing.T) {
+	base := NewSkipList[int, string]()
+	for k := 0; k < 6; k++ {
+		base.Set(k, strconv.Itoa(k))
+	}
+	remote := base.Snapshot()
+	remote.Set(1, "one")
+	remote.Remove(2)
+	remote.Set(3, "three")
+	remote.Remove(4)
+	remote.Set(7, "7")
+	remote.Set(8, "8")
+	var buf bytes.Buffer
+	require.NoError(t, WriteDiff(&buf, remote.Diff(base, func(a, b string) bool { return a == b }), intStringCodec))
+
+	local := base.Snapshot()
+	local.Set(3, "drei") // conflict: changed on both sides
+	local.Remove(4)      // same change on both sides
+	local.Set(8, "acht") // conflict: added on both sides
+	var conflicts []int
+	applied, err := local.ApplyDiff(bytes.NewReader(buf.Bytes()), intStringCodec,
+		func(c Change[int, string], x *Node[int, string]) bool {
+			conflicts = append(conflicts, c.Key)
+			require.NotNil(t, x)
+			return c.Key == 8 // accept the remote value only for 8
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 4, applied)
+	assert.Equal(t, []int{3, 8}, conflicts)
+	assert.Equal(t, []string{"0", "one", "drei", "5", "7", "8"}, valuesOf(local))
+
+	// a nil callback skips all conflicts
+	local = base.Snapshot()
+	local.Remove(1)
+	applied, err = local.ApplyDiff(bytes.NewReader(buf.Bytes()), intStringCodec, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, applied)
+	assert.Equal(t, []string{"0", "three", "5", "7", "8"}, valuesOf(local))
+}
+
+func TestApplyDiffInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteDiff(&buf, []Change[int, string]{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}},
+		intStringCodec))
+	s := NewSkipList[int, string]()
+	applied, err := s.ApplyDiff(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), intStringCodec, nil)
+	assert.ErrorIs(t, err, ErrInvalidDiff)
+	assert.Equal(t, 1, applied)
+	_, err = s.ApplyDiff(bytes.NewReader([]byte{0x80}), intStringCodec, nil)
+	assert.ErrorIs(t, err, ErrInvalidDiff)
+	_, err = s.ApplyDiff(bytes.NewReader([]byte{0, 2, 1, 2, 0}), intStringCodec, nil)
+	assert.ErrorIs(t, err, ErrInvalidDiff)
+}
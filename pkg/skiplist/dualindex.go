@@ -0,0 +1,167 @@
+package skiplist
+
+import (
+	"cmp"
+	"log"
+	"sync"
+)
+
+// DualIndex maps keys to values and keeps the keys ordered by their values at the same time, e.g. members
+// and scores of a sorted set. It maintains two skip lists in sync: key -> value and value -> key. Keys with
+// equal values are ordered by key.
+type DualIndex[K cmp.Ordered, V cmp.Ordered] struct {
+	forward *SkipList[K, V]
+	reverse *SkipList[V, K] // with duplicates, equal values ordered by key
+}
+
+// NewDualIndex creates a new empty DualIndex object.
+func NewDualIndex[K cmp.Ordered, V cmp.Ordered]() *DualIndex[K, V] {
+	return &DualIndex[K, V]{
+		forward: NewSkipList[K, V](),
+		reverse: NewSkipList[V, K](WithDuplicates()),
+	}
+}
+
+// Set sets the value of `key` and moves the key to its new place in the value order. Returns true if the key
+// was added.
+func (d *DualIndex[K, V]) Set(key K, value V) bool {
+	x, _ := d.forward.Get(key)
+	if x != nil {
+		if x.Value == value {
+			return false
+		}
+		d.reverse.RemoveByPos(d.reversePos(x.Value, key))
+	}
+	d.forward.Set(key, value)
+	_, pos := d.reverse.lowerBound(value)
+	for y := d.reverse.GetByPos(pos); y != nil && y.key == value && cmp.Less(y.Value, key); y = y.Next() {
+		pos++
+	}
+	d.reverse.insertAt(pos, value, key)
+	return x == nil
+}
+
+// Get returns the value of `key` and true or the zero value and false if the key was not found.
+func (d *DualIndex[K, V]) Get(key K) (V, bool) {
+	return d.forward.GetCopy(key)
+}
+
+// Remove removes `key` and returns true if it was found.
+func (d *DualIndex[K, V]) Remove(key K) bool {
+	x, _ := d.forward.Remove(key)
+	if x == nil {
+		return false
+	}
+	d.reverse.RemoveByPos(d.reversePos(x.Value, key))
+	return true
+}
+
+// Size returns the number of keys.
+func (d *DualIndex[K, V]) Size() int {
+	return d.forward.Size()
+}
+
+// RankByValue returns the position 0...n-1 of `key` in the value order or InvalidPos if the key was not found.
+func (d *DualIndex[K, V]) RankByValue(key K) int {
+	x, _ := d.forward.Get(key)
+	if x == nil {
+		return InvalidPos
+	}
+	return d.reversePos(x.Value, key)
+}
+
+// GetByValuePos returns the key and the value at the position k of the value order. The bool is false if k is
+// out of range.
+func (d *DualIndex[K, V]) GetByValuePos(k int) (K, V, bool) {
+	y := d.reverse.GetByPos(k)
+	if y == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return y.Value, y.key, true
+}
+
+// RangeByValue calls fn for all keys with from <= value <= to in the value order until fn returns false.
+func (d *DualIndex[K, V]) RangeByValue(from, to V, fn func(key K, value V) bool) {
+	for y, _ := d.reverse.lowerBound(from); y != nil && !cmp.Less(to, y.key); y = y.Next() {
+		if !fn(y.Value, y.key) {
+			return
+		}
+	}
+}
+
+// reversePos returns the position of the pair of `value` and `key` within the reverse list.
+func (d *DualIndex[K, V]) reversePos(value V, key K) int {
+	y, pos := d.reverse.lowerBound(value)
+	for ; y != nil && y.key == value; y = y.Next() {
+		if y.Value == key {
+			return pos
+		}
+		pos++
+	}
+	log.Panicf("%v: key %v is missing in the value order of DualIndex", ErrCorrupted, key)
+	return InvalidPos
+}
+
+// ConcurrentDualIndex wraps a DualIndex with a read-write mutex, so it can be used from multiple goroutines.
+// Both directions are updated atomically: readers never observe a key in only one of them.
+type ConcurrentDualIndex[K cmp.Ordered, V cmp.Ordered] struct {
+	mu    sync.RWMutex
+	index *DualIndex[K, V]
+}
+
+// NewConcurrentDualIndex creates a new empty ConcurrentDualIndex object.
+func NewConcurrentDualIndex[K cmp.Ordered, V cmp.Ordered]() *ConcurrentDualIndex[K, V] {
+	return &ConcurrentDualIndex[K, V]{index: NewDualIndex[K, V]()}
+}
+
+// Set sets the value of `key` like DualIndex.Set.
+func (c *ConcurrentDualIndex[K, V]) Set(key K, value V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.index.Set(key, value)
+}
+
+// Get returns the value of `key` like DualIndex.Get.
+func (c *ConcurrentDualIndex[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.index.Get(key)
+}
+
+// Remove removes `key` like DualIndex.Remove.
+func (c *ConcurrentDualIndex[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.index.Remove(key)
+}
+
+// Size returns the number of keys.
+func (c *ConcurrentDualIndex[K, V]) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.index.Size()
+}
+
+// RankByValue returns the position of `key` in the value order like DualIndex.RankByValue.
+func (c *ConcurrentDualIndex[K, V]) RankByValue(key K) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.index.RankByValue(key)
+}
+
+// GetByValuePos returns the key and value at position k of the value order like DualIndex.GetByValuePos.
+func (c *ConcurrentDualIndex[K, V]) GetByValuePos(k int) (K, V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.index.GetByValuePos(k)
+}
+
+// RangeByValue calls fn for the keys with from <= value <= to like DualIndex.RangeByValue. The read lock is
+// held during the whole iteration, so fn must not modify the index.
+func (c *ConcurrentDualIndex[K, V]) RangeByValue(from, to V, fn func(key K, value V) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.index.RangeByValue(from, to, fn)
+}
@@ -0,0 +1,86 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDualIndex(t *testing.T) {
+	d := NewDualIndex[string, int]()
+	assert.True(t, d.Set("carol", 30))
+	assert.True(t, d.Set("bob", 20))
+	assert.True(t, d.Set("alice", 20))
+	assert.True(t, d.Set("dave", 10))
+	assert.False(t, d.Set("dave", 10))
+	assert.Equal(t, 4, d.Size())
+
+	order := func() []string {
+		var keys []string
+		d.RangeByValue(0, 100, func(k string, _ int) bool {
+			keys = append(keys, k)
+			return true
+		})
+		return keys
+	}
+	// equal values are ordered by key
+	assert.Equal(t, []string{"dave", "alice", "bob", "carol"}, order())
+	assert.Equal(t, 2, d.RankByValue("bob"))
+	assert.Equal(t, InvalidPos, d.RankByValue("eve"))
+
+	assert.False(t, d.Set("dave", 25))
+	assert.Equal(t, []string{"alice", "bob", "dave", "carol"}, order())
+	v, ok := d.Get("dave")
+	assert.True(t, ok)
+	assert.Equal(t, 25, v)
+
+	k, v, ok := d.GetByValuePos(3)
+	assert.True(t, ok)
+	assert.Equal(t, "carol", k)
+	assert.Equal(t, 30, v)
+	_, _, ok = d.GetByValuePos(4)
+	assert.False(t, ok)
+
+	assert.True(t, d.Remove("alice"))
+	assert.False(t, d.Remove("alice"))
+	assert.Equal(t, []string{"bob", "dave", "carol"}, order())
+
+	var keys []string
+	d.RangeByValue(20, 25, func(k string, _ int) bool {
+		keys = append(keys, k)
+		return len(keys) < 1
+	})
+	assert.Equal(t, []string{"bob"}, keys)
+}
+
+func TestConcurrentDualIndex(t *testing.T) {
+	c := NewConcurrentDualIndex[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for k := 0; k < 200; k++ {
+				c.Set(k, (k*7+g)%50)
+				c.RankByValue(k)
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 200, c.Size())
+	n := 0
+	prev := -1
+	c.RangeByValue(0, 50, func(k int, v int) bool {
+		assert.LessOrEqual(t, prev, v)
+		prev = v
+		n++
+		return true
+	})
+	assert.Equal(t, 200, n)
+	assert.True(t, c.Remove(5))
+	_, ok := c.Get(5)
+	assert.False(t, ok)
+	_, _, ok = c.GetByValuePos(0)
+	assert.True(t, ok)
+}
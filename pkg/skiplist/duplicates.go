@@ -0,0 +1,30 @@
+package skiplist
+
+import "cmp"
+
+// WithDuplicates allows multiple elements with equal keys (multimap semantics). Set always inserts a new
+// element behind all elements with an equal key, so elements with equal keys keep their insertion order.
+func WithDuplicates() Option {
+	return func(c *config) {
+		c.duplicates = true
+	}
+}
+
+// Group holds all values of one key in the order of the skip list.
+type Group[K cmp.Ordered, V any] struct {
+	Key    K
+	Values []V
+}
+
+// GetRangeGrouped returns the elements with from <= key <= to grouped by their keys in ascending order.
+func (s *SkipList[K, V]) GetRangeGrouped(from, to K) []Group[K, V] {
+	var groups []Group[K, V]
+	for x, _ := s.lowerBound(from); x != nil && !s.less(to, x.key); x = x.Next() {
+		if len(groups) == 0 || groups[len(groups)-1].Key != x.key {
+			groups = append(groups, Group[K, V]{Key: x.key})
+		}
+		g := &groups[len(groups)-1]
+		g.Values = append(g.Values, s.ValueOf(x))
+	}
+	return groups
+}
@@ -0,0 +1,99 @@
+package skiplist
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicates(t *testing.T) {
+	s := NewSkipList[int, string](WithDuplicates())
+	s.Set(2, "a")
+	s.Set(1, "b")
+	_, pos, created := s.Set(2, "c")
+	assert.True(t, created)
+	assert.Equal(t, 2, pos)
+	s.Set(2, "d")
+	s.Set(3, "e")
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 5, s.Size())
+
+	x, pos := s.Get(2)
+	assert.Equal(t, "a", x.Value)
+	assert.Equal(t, 1, pos)
+
+	assert.Equal(t, []Group[int, string]{
+		{Key: 2, Values: []string{"a", "c", "d"}},
+		{Key: 3, Values: []string{"e"}},
+	}, s.GetRangeGrouped(2, 10))
+	assert.Empty(t, s.GetRangeGrouped(4, 10))
+
+	x, _ = s.Remove(2)
+	assert.Equal(t, "a", x.Value)
+	x, _ = s.Get(2)
+	assert.Equal(t, "c", x.Value)
+	require.NoError(t, s.Validate())
+}
+
+func TestDuplicatesRandom(t *testing.T) {
+	s := NewSkipList[int, int](WithDuplicates())
+	for i, k := range makeRandomData(300) {
+		s.Set(k%30, i)
+	}
+	require.NoError(t, s.Validate())
+	for _, g := range s.GetRangeGrouped(0, 30) {
+		assert.Len(t, g.Values, 10)
+		assert.IsIncreasing(t, g.Values)
+	}
+
+	r := s.RebuildInBackground(context.Background())
+	s.Set(5, 1000)
+	s.Remove(7)
+	require.NoError(t, r.Wait())
+	require.NoError(t, s.Validate())
+	groups := s.GetRangeGrouped(5, 7)
+	assert.Len(t, groups[0].Values, 11)
+	assert.Equal(t, 1000, groups[0].Values[10])
+	assert.Len(t, groups[2].Values, 9)
+}
+
+// requireInsertionOrder checks that the values of equal keys are ascending like the sequence numbers they were
+// inserted with.
+func requireInsertionOrder(t *testing.T, s *SkipList[int, int]) {
+	t.Helper()
+	require.NoError(t, s.Validate())
+	for _, g := range s.GetRangeGrouped(0, 30) {
+		require.True(t, slices.IsSorted(g.Values), "key %d: %v", g.Key, g.Values)
+	}
+}
+
+func TestDuplicatesStableOrder(t *testing.T) {
+	s := NewSkipList[int, int](WithDuplicates())
+	var pairs []Pair[int, int]
+	for i, k := range makeRandomData(300) {
+		s.Set(k%30, i)
+		pairs = append(pairs, Pair[int, int]{Key: k % 30, Value: i})
+	}
+	requireInsertionOrder(t, s)
+	requireInsertionOrder(t, s.Compact())
+	requireInsertionOrder(t, s.CloneExact())
+
+	snap := s.Snapshot()
+	s.Set(3, 1000)
+	s.Remove(4)
+	requireInsertionOrder(t, s)
+	requireInsertionOrder(t, snap)
+
+	l := NewSkipList[int, int](WithDuplicates())
+	l.Load(pairs)
+	requireInsertionOrder(t, l)
+	assert.Equal(t, 300, l.Size())
+
+	require.NoError(t, l.MergeSorted(pairSeq(1, 1, 2), nil))
+	require.NoError(t, l.Validate())
+	groups := l.GetRangeGrouped(1, 1)
+	assert.Equal(t, []int{10, 10}, groups[0].Values[len(groups[0].Values)-2:])
+}
@@ -0,0 +1,54 @@
+package skiplist
+
+import "time"
+
+// EventType identifies a structural event within the skip list.
+type EventType int
+
+const (
+	EventLevelGrow   EventType = iota // the level of the head was increased by an insert
+	EventLevelShrink                  // the level of the head was decreased by a removal
+	EventRebuild                      // the skip list was rebuilt with a new level distribution
+	EventCompact                      // the content was compacted into a fresh skip list
+	EventTrim                         // elements were evicted automatically
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventLevelGrow:
+		return "LevelGrow"
+	case EventLevelShrink:
+		return "LevelShrink"
+	case EventRebuild:
+		return "Rebuild"
+	case EventCompact:
+		return "Compact"
+	case EventTrim:
+		return "Trim"
+	}
+	return "Unknown"
+}
+
+// Event describes a structural change or a maintenance operation of the skip list.
+type Event struct {
+	Type     EventType
+	Level    int           // level of the head after the event
+	Count    int           // number of affected elements (e.g. evicted or rebuilt elements)
+	Duration time.Duration // duration of maintenance operations, zero for level changes
+}
+
+// EventHandler is called synchronously for every emitted Event. It must not modify the skip list.
+type EventHandler func(Event)
+
+// WithEventHandler registers a handler receiving structural events, e.g. for metrics or logging.
+func WithEventHandler(handler EventHandler) Option {
+	return func(c *config) {
+		c.onEvent = handler
+	}
+}
+
+func (s *SkipList[K, V]) emit(e Event) {
+	if s.onEvent != nil {
+		s.onEvent(e)
+	}
+}
@@ -0,0 +1,34 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventsLevelChanges(t *testing.T) {
+	var events []Event
+	data := []testData{{1, 1, 0}, {2, 3, 1}, {3, 1, 2}}
+	s := NewSkipList[int, int](
+		WithLevelFunc(createPlayBackLevelFunc(data)),
+		WithEventHandler(func(e Event) { events = append(events, e) }),
+	)
+
+	for _, x := range data {
+		s.Set(x.key, x.pos)
+	}
+	assert.Equal(t, []Event{
+		{Type: EventLevelGrow, Level: 1},
+		{Type: EventLevelGrow, Level: 3},
+	}, events)
+
+	events = nil
+	s.Remove(2)
+	assert.Equal(t, []Event{{Type: EventLevelShrink, Level: 1}}, events)
+
+	events = nil
+	s.RemoveByPos(0)
+	s.RemoveByPos(0)
+	assert.Equal(t, []Event{{Type: EventLevelShrink, Level: 0}}, events)
+	assert.Equal(t, "LevelShrink", events[0].Type.String())
+}
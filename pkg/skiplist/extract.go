@@ -0,0 +1,159 @@
+package skiplist
+
+import "context"
+
+// ExtractRange removes the elements with from <= key <= to and returns them as a new skip list with the options
+// of s, e.g. to split a shard. The nodes are not copied: the range is cut out by relinking its boundaries on
+// every level in O(log(n)). Only if the moved nodes have to be accounted (soft deleted nodes, stable IDs, or a
+// running rebuild), they are walked once in O(k).
+func (s *SkipList[K, V]) ExtractRange(from, to K) *SkipList[K, V] {
+	s.lazyInit()
+	end := s.trace(context.Background(), "ExtractRange", s.count)
+	s.pollRebuild()
+	s.ensureOwned()
+	if s.less(to, from) {
+		end(0)
+		return s.extract(nil, nil, nil, nil)
+	}
+	before, beforePos := s.searchPath(from, false)
+	last, lastPos := s.searchPath(to, true)
+	dst := s.extract(before, beforePos, last, lastPos)
+	end(dst.count)
+	return dst
+}
+
+// RemoveRangeByPos removes the elements at the positions i...j-1, e.g. RemoveRangeByPos(10000, Size()) keeps
+// the first 10000 elements only. Positions out of range are clipped. The range is cut out like ExtractRange in
+// O(log(n)) plus O(k) for accounting the removed nodes or passing them to the allocator (see WithAllocator) if
+// necessary. Returns the number of removed elements.
+func (s *SkipList[K, V]) RemoveRangeByPos(i, j int) int {
+	end := s.trace(context.Background(), "RemoveRange", s.count)
+	i, j = max(i, 0), min(j, s.count)
+	if i >= j {
+		end(0)
+		return 0
+	}
+	s.pollRebuild()
+	s.ensureOwned()
+	before, beforePos := s.searchPathByPos(i)
+	last, lastPos := s.searchPathByPos(j)
+	removed := s.extract(before, beforePos, last, lastPos)
+	if s.allocator != nil {
+		for x := removed.First(); x != nil; {
+			next := x.Next()
+			s.freeNode(x)
+			x = next
+		}
+	}
+	end(removed.count)
+	return removed.count
+}
+
+// extract moves the nodes between the search paths before and last (see searchPath) into a new list with the
+// options of s. The paths are nil for an empty range.
+func (s *SkipList[K, V]) extract(before []*Node[K, V], beforePos []int, last []*Node[K, V],
+	lastPos []int) *SkipList[K, V] {
+	dst := s.emptyClone()
+	var dummyKey K
+	var dummyValue V
+	dst.head = dst.newNode(dummyKey, dummyValue, 0, dst.maxLevel)
+	dst.nextID = s.nextID
+	dst.nextSeq = s.nextSeq
+	if len(before) == 0 {
+		return dst
+	}
+	k := lastPos[0] - beforePos[0]
+	if k == 0 {
+		return dst
+	}
+	if s.iterationGuard {
+		s.checkIterators("remove")
+	}
+
+	// positions within dst are the positions within s minus beforePos[0]+1
+	dst.head.extendLevel(s.Level())
+	for i := range before {
+		a, b := before[i], last[i]
+		if a == b {
+			// no node of the range on this level
+			a.dist[i] -= k
+			dst.head.dist[i] = k + 1
+			continue
+		}
+		dst.head.next[i] = a.next[i]
+		dst.head.dist[i] = beforePos[i] + a.dist[i] - beforePos[0]
+		a.next[i] = b.next[i]
+		a.dist[i] = lastPos[i] + b.dist[i] - k - beforePos[i]
+		b.next[i] = nil
+		b.dist[i] = k - (lastPos[i] - beforePos[0] - 1)
+	}
+	if s.backPointers {
+		dst.First().prev = nil
+		if x := before[0].next[0]; x != nil {
+			x.linkPrev(before[0], s.head)
+		}
+	}
+	dst.count = k
+	s.count -= k
+	s.insChain = false
+	s.version++
+	s.adaptLevel()
+	dst.adaptLevel()
+	s.checkWatermarks()
+
+	if s.deleted > 0 || s.stableIDs || s.rebuild != nil || s.interned != nil {
+		for x := dst.First(); x != nil; x = x.Next() {
+			if s.interned != nil {
+				dst.interned[x.key] = s.interned[x.key]
+				s.releaseKey(x.key, before[0], before[0].next[0])
+			}
+			if x.deleted {
+				s.deleted--
+				dst.deleted++
+			}
+			if s.stableIDs {
+				delete(s.ids, x.id)
+			}
+			s.touched(x.key)
+		}
+		if dst.stableIDs {
+			dst.indexIDs()
+		}
+	}
+	return dst
+}
+
+// searchPath returns the rightmost nodes with a key < `key` (<= if inclusive) on every level and their
+// positions.
+func (s *SkipList[K, V]) searchPath(key K, inclusive bool) ([]*Node[K, V], []int) {
+	update := make([]*Node[K, V], s.Level())
+	updatePos := make([]int, s.Level())
+	x := s.head
+	pos := -1
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && (s.less(x.next[i].key, key) || inclusive && x.next[i].key == key) {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		update[i] = x
+		updatePos[i] = pos
+	}
+	return update, updatePos
+}
+
+// searchPathByPos returns the rightmost nodes with a position < k on every level and their positions.
+func (s *SkipList[K, V]) searchPathByPos(k int) ([]*Node[K, V], []int) {
+	update := make([]*Node[K, V], s.Level())
+	updatePos := make([]int, s.Level())
+	x := s.head
+	pos := -1
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && pos+x.dist[i] < k {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		update[i] = x
+		updatePos[i] = pos
+	}
+	return update, updatePos
+}
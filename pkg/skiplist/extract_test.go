@@ -0,0 +1,92 @@
+package skiplist
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRange(t *testing.T) {
+	for _, r := range [][2]int{{0, 1000}, {-5, 2000}, {100, 200}, {0, 0}, {500, 999}, {300, 299}, {2000, 3000}} {
+		s := NewSkipList[int, int](WithSeed(uint64(r[0] + 10)))
+		for _, k := range rand.New(rand.NewSource(1)).Perm(1000) {
+			s.Set(k, k*10)
+		}
+		var want []int
+		for k := 0; k < 1000; k++ {
+			if k >= r[0] && k <= r[1] {
+				want = append(want, k)
+			}
+		}
+
+		dst := s.ExtractRange(r[0], r[1])
+		require.NoError(t, s.Validate(), "range %v", r)
+		require.NoError(t, dst.Validate(), "range %v", r)
+		assert.Equal(t, len(want), dst.Size())
+		assert.Equal(t, 1000-len(want), s.Size())
+		if len(want) > 0 {
+			assert.Equal(t, want, slices.Collect(dst.Keys()))
+		}
+		for key, value := range dst.All() {
+			assert.Equal(t, key*10, value)
+			x, _ := s.Get(key)
+			assert.Nil(t, x)
+		}
+
+		// both lists stay usable
+		dst.Set(r[0], 1)
+		s.Set(r[0], 1)
+		require.NoError(t, s.Validate())
+		require.NoError(t, dst.Validate())
+	}
+}
+
+func TestExtractRangeAccounting(t *testing.T) {
+	s := Collect(pairSeq(1, 2, 2, 3, 4, 5), WithDuplicates(), WithStableIDs())
+	s.MarkDeleted(4)
+	s.MarkDeleted(1)
+	id := s.GetByPos(1).ID()
+
+	dst := s.ExtractRange(2, 4)
+	assert.Equal(t, []int{2, 2, 3}, slices.Collect(dst.Keys()))
+	assert.Equal(t, []int{5}, slices.Collect(s.Keys()))
+	assert.Equal(t, 4, dst.Size())
+	assert.Equal(t, 3, dst.LiveSize())
+	assert.Equal(t, 2, s.Size())
+	assert.Equal(t, 1, s.LiveSize())
+	assert.Nil(t, s.GetByID(id))
+	assert.Equal(t, 2, dst.GetByID(id).Key())
+	require.NoError(t, s.Validate())
+	require.NoError(t, dst.Validate())
+}
+
+func TestRemoveRangeByPos(t *testing.T) {
+	for _, r := range [][2]int{{0, 1000}, {-5, 2000}, {100, 200}, {0, 1}, {999, 1000}, {10, 10}, {300, 299}} {
+		s := NewSkipList[int, int](WithSeed(uint64(r[0] + 10)))
+		for _, k := range rand.New(rand.NewSource(1)).Perm(1000) {
+			s.Set(k, k)
+		}
+		var want []int
+		for k := 0; k < 1000; k++ {
+			if k < r[0] || k >= r[1] {
+				want = append(want, k)
+			}
+		}
+		assert.Equal(t, 1000-len(want), s.RemoveRangeByPos(r[0], r[1]), "range %v", r)
+		require.NoError(t, s.Validate(), "range %v", r)
+		assert.Equal(t, len(want), s.Size())
+		if len(want) > 0 {
+			assert.Equal(t, want, slices.Collect(s.Keys()))
+		}
+	}
+
+	s := Collect(pairSeq(1, 2, 3, 4), WithStableIDs())
+	id := s.GetByPos(3).ID()
+	s.MarkDeleted(4)
+	assert.Equal(t, 2, s.RemoveRangeByPos(2, 10))
+	assert.Equal(t, 2, s.LiveSize())
+	assert.Nil(t, s.GetByID(id))
+}
@@ -0,0 +1,153 @@
+package skiplist
+
+import (
+	"log"
+	"reflect"
+)
+
+// SkipListFunc is a skip list ordering its keys by a less function instead of the cmp.Ordered constraint, so
+// keys like structs, time.Time, []byte, or composite tuples can be used. It provides the key and position
+// operations of SkipList; the policies, snapshots, and the other extensions of SkipList are not supported.
+// Keys a and b are equal if neither less(a, b) nor less(b, a).
+type SkipListFunc[K any, V any] struct {
+	config
+	less  func(a, b K) bool
+	head  *Node[K, V]
+	count int
+}
+
+// NewSkipListFunc creates a new empty SkipListFunc ordering the keys by less, which must be a strict weak
+// order. Only the options generating the levels and WithBackPointers apply: WithProbability, WithMaxLevel,
+// WithLevelFunc, WithSeed, WithCryptoSeed, and WithBackPointers. Other options panic.
+func NewSkipListFunc[K any, V any](less func(a, b K) bool, options ...Option) *SkipListFunc[K, V] {
+	s := &SkipListFunc[K, V]{
+		config: config{
+			p:         DefaultProbability,
+			maxLevel:  DefaultMaxLevel,
+			levelFunc: defaultLevelFunc,
+		},
+		less: less,
+	}
+	for _, opt := range options {
+		opt(&s.config)
+	}
+	if len(s.typed) > 0 {
+		log.Panicf("Typed options are not applicable to %T", s)
+	}
+	rest := s.config
+	rest.p, rest.maxLevel, rest.levelFunc, rest.backPointers = 0, 0, nil, false
+	if !reflect.ValueOf(rest).IsZero() {
+		log.Panicf("Options other than WithProbability, WithMaxLevel, WithLevelFunc, WithSeed, WithCryptoSeed, "+
+			"and WithBackPointers are not applicable to %T", s)
+	}
+	s.head = newNode[K, V](*new(K), *new(V), 0, s.maxLevel)
+	return s
+}
+
+// First returns the first node or nil if the list is empty.
+func (s *SkipListFunc[K, V]) First() *Node[K, V] {
+	return s.head.Next()
+}
+
+// Size returns the number of elements.
+func (s *SkipListFunc[K, V]) Size() int {
+	return s.count
+}
+
+// Level returns the current level of the list.
+func (s *SkipListFunc[K, V]) Level() int {
+	return s.head.Level()
+}
+
+// Set sets the value of `key` like SkipList.Set. Returns the node, its position 0...n-1, and true if a new
+// node was created.
+func (s *SkipListFunc[K, V]) Set(key K, value V) (*Node[K, V], int, bool) {
+	update := make([]*Node[K, V], s.Level(), s.maxLevel)
+	updatePos := make([]int, s.Level(), s.maxLevel)
+	x, pos := s.search(key, update, updatePos)
+	if x.Next() != nil && !s.less(key, x.Next().key) {
+		x = x.Next()
+		x.Value = value
+		return x, pos + 1, false
+	}
+
+	level := s.levelFunc(s.p, s.maxLevel)
+	if level > s.Level() {
+		update, updatePos = growHead(s.head, update, updatePos, level, s.count)
+	}
+	x = newNode(key, value, level, level)
+	linkNode(s.head, update, updatePos, pos, x)
+	if s.backPointers {
+		x.linkBack(update[0], s.head)
+	}
+	s.count++
+	return x, pos + 1, true
+}
+
+// Get returns the node with `key` and its position 0...n-1 or nil and InvalidPos if it was not found.
+func (s *SkipListFunc[K, V]) Get(key K) (*Node[K, V], int) {
+	x, pos := s.search(key, nil, nil)
+	if x = x.Next(); x != nil && !s.less(key, x.key) {
+		return x, pos + 1
+	}
+	return nil, InvalidPos
+}
+
+// GetByPos returns the node at position k or nil if k is out of range.
+func (s *SkipListFunc[K, V]) GetByPos(k int) *Node[K, V] {
+	if k < 0 || k >= s.count {
+		return nil
+	}
+	x, _ := descendByPos(s.head, k+1, nil, nil)
+	return x
+}
+
+// Remove removes the element with `key` and returns it with its former position or nil and InvalidPos if the
+// key was not found.
+func (s *SkipListFunc[K, V]) Remove(key K) (*Node[K, V], int) {
+	update := make([]*Node[K, V], s.Level())
+	x, pos := s.search(key, update, make([]int, s.Level()))
+	if x = x.Next(); x == nil || s.less(key, x.key) {
+		return nil, InvalidPos
+	}
+	s.unlink(update, x)
+	return x, pos + 1
+}
+
+// RemoveByPos removes the element at position k and returns it or nil if k is out of range.
+func (s *SkipListFunc[K, V]) RemoveByPos(k int) *Node[K, V] {
+	if k < 0 || k >= s.count {
+		return nil
+	}
+	update := make([]*Node[K, V], s.Level())
+	x, _ := descendByPos(s.head, k, update, make([]int, s.Level()))
+	x = x.Next()
+	s.unlink(update, x)
+	return x
+}
+
+// Range calls fn for the elements with from <= key <= to in ascending order until fn returns false.
+func (s *SkipListFunc[K, V]) Range(from, to K, fn func(key K, value V) bool) {
+	x, _ := s.search(from, nil, nil)
+	for x = x.Next(); x != nil && !s.less(to, x.key); x = x.Next() {
+		if !fn(x.key, x.Value) {
+			return
+		}
+	}
+}
+
+// search returns the rightmost node with a key < `key` and its position. If update and updatePos are not nil,
+// they receive the rightmost nodes before the key on each level and their positions.
+func (s *SkipListFunc[K, V]) search(key K, update []*Node[K, V], updatePos []int) (*Node[K, V], int) {
+	return descend(s.head, func(y *Node[K, V]) bool { return s.less(y.key, key) }, update, updatePos)
+}
+
+// unlink removes the node x. update holds the rightmost nodes on each level before x.
+func (s *SkipListFunc[K, V]) unlink(update []*Node[K, V], x *Node[K, V]) {
+	unlinkNode(s.head, update, x)
+	if s.backPointers {
+		x.unlinkBack()
+	}
+	shrinkHead(s.head)
+	s.count--
+}
@@ -0,0 +1,97 @@
+package skiplist
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tuple struct {
+	tenant string
+	seq    int
+}
+
+func lessTuple(a, b tuple) bool {
+	if a.tenant != b.tenant {
+		return a.tenant < b.tenant
+	}
+	return a.seq < b.seq
+}
+
+func TestSkipListFunc(t *testing.T) {
+	s := NewSkipListFunc[tuple, int](lessTuple, WithSeed(4))
+	r := rand.New(rand.NewPCG(1, 1))
+	want := map[tuple]int{}
+	for i := 0; i < 2000; i++ {
+		k := tuple{tenant: string(rune('a' + r.IntN(5))), seq: r.IntN(100)}
+		if r.IntN(4) == 0 {
+			x, _ := s.Remove(k)
+			_, ok := want[k]
+			assert.Equal(t, ok, x != nil)
+			delete(want, k)
+		} else {
+			s.Set(k, i)
+			want[k] = i
+		}
+	}
+	keys := make([]tuple, 0, len(want))
+	for k := range want {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessTuple(keys[i], keys[j]) })
+	require.Equal(t, len(keys), s.Size())
+	for pos, k := range keys {
+		x := s.GetByPos(pos)
+		require.NotNil(t, x)
+		assert.Equal(t, k, x.Key())
+		y, p := s.Get(k)
+		assert.Same(t, x, y)
+		assert.Equal(t, pos, p)
+		assert.Equal(t, want[k], x.Value)
+	}
+
+	var tenant []int
+	s.Range(tuple{"b", 0}, tuple{"b", 1 << 30}, func(key tuple, value int) bool {
+		assert.Equal(t, "b", key.tenant)
+		tenant = append(tenant, key.seq)
+		return true
+	})
+	assert.True(t, sort.IntsAreSorted(tenant))
+
+	for s.Size() > 0 {
+		x := s.RemoveByPos(s.Size() / 2)
+		require.NotNil(t, x)
+	}
+	assert.Nil(t, s.First())
+	assert.Equal(t, 0, s.Level())
+}
+
+func TestSkipListFuncKeys(t *testing.T) {
+	times := NewSkipListFunc[time.Time, string](time.Time.Before)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	times.Set(base.Add(time.Hour), "b")
+	times.Set(base, "a")
+	times.Set(base.In(time.FixedZone("X", 3600)), "A")
+	assert.Equal(t, 2, times.Size())
+	assert.Equal(t, "A", times.First().Value)
+
+	blobs := NewSkipListFunc[[]byte, int](func(a, b []byte) bool { return bytes.Compare(a, b) < 0 })
+	blobs.Set([]byte{2}, 2)
+	blobs.Set([]byte{1, 5}, 1)
+	x, pos := blobs.Get([]byte{2})
+	require.NotNil(t, x)
+	assert.Equal(t, 1, pos)
+	assert.Panics(t, func() { NewSkipListFunc[int, int](nil, WithValueCopier[int, int](nil)) })
+	for _, opt := range []Option{WithDuplicates(), WithDescending(), WithStableIDs(), WithKeyInterning(),
+		WithAutoRepair()} {
+		assert.Panics(t, func() { NewSkipListFunc[int, int](nil, opt) })
+	}
+	assert.NotPanics(t, func() {
+		NewSkipListFunc[int, int](nil, WithProbability(0.25), WithMaxLevel(8), WithSeed(1), WithCryptoSeed())
+	})
+}
@@ -0,0 +1,79 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+)
+
+// ErrModifiedWhileIterating is wrapped by an IterationError (see WithIterationGuard).
+var ErrModifiedWhileIterating = errors.New("skiplist: modified while an iterator is open")
+
+// IterationError reports a structural modification of a skip list while an iterator was open.
+type IterationError struct {
+	Op   string // the modification: "insert", "remove", or "replace"
+	Site string // file and line where the open iterator was created
+}
+
+func (e *IterationError) Error() string {
+	return fmt.Sprintf("%v: %s while the iterator created at %s is not closed", ErrModifiedWhileIterating, e.Op,
+		e.Site)
+}
+
+func (e *IterationError) Unwrap() error {
+	return ErrModifiedWhileIterating
+}
+
+// WithIterationGuard is a debug mode detecting structural modifications (inserts, removals, and replacing the
+// content) while an iterator of the skip list is open. An iterator is open until it is exhausted or closed by
+// Iterator.Close(); pinned iterators (see Pinned) are not guarded since they are safe. A violation is
+// reported by an *IterationError containing the place where the iterator was created; the stack at the
+// time of the report shows the modifying call. onViolation receives the error, if it is nil the
+// modification panics.
+func WithIterationGuard(onViolation func(err error)) Option {
+	return func(c *config) {
+		c.iterationGuard = true
+		c.onViolation = onViolation
+	}
+}
+
+// guardIterator registers an open iterator.
+func (s *SkipList[K, V]) guardIterator(it *Iterator[K, V]) {
+	if s.iterators == nil {
+		s.iterators = make(map[*Iterator[K, V]]string)
+	}
+	s.iterators[it] = callerSite()
+	it.guarded = s
+}
+
+// checkIterators reports a structural modification `op` if iterators are open.
+func (s *SkipList[K, V]) checkIterators(op string) {
+	if len(s.iterators) == 0 {
+		return
+	}
+	var site string
+	for _, site = range s.iterators {
+		break
+	}
+	err := &IterationError{Op: op, Site: site}
+	if s.onViolation == nil {
+		log.Panic(err)
+	}
+	s.onViolation(err)
+}
+
+// callerSite returns the file and line of the first caller outside of this package.
+func callerSite() string {
+	pc := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(3, pc)])
+	for {
+		frame, more := frames.Next()
+		internal := strings.HasPrefix(frame.Function, "github.com/andremueller/goskiplist/pkg/skiplist.") &&
+			!strings.HasSuffix(frame.File, "_test.go")
+		if !internal || !more {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+	}
+}
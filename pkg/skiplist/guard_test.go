@@ -0,0 +1,54 @@
+package skiplist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterationGuard(t *testing.T) {
+	var errs []error
+	s := NewSkipList[int, int](WithIterationGuard(func(err error) { errs = append(errs, err) }))
+	for k := 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	assert.Empty(t, errs)
+
+	it := s.Iterator()
+	it.Next()
+	s.Set(3, 33) // overriding a value is no structural modification
+	assert.Empty(t, errs)
+	s.RemoveByPos(5)
+	s.Set(20, 20)
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], ErrModifiedWhileIterating)
+	var iterErr *IterationError
+	require.ErrorAs(t, errs[0], &iterErr)
+	assert.Equal(t, "remove", iterErr.Op)
+	assert.True(t, strings.HasSuffix(iterErr.Site, "guard_test.go:19"), iterErr.Site)
+	assert.Equal(t, "insert", errs[1].(*IterationError).Op)
+
+	it.Close()
+	errs = nil
+	s.Set(21, 21)
+	assert.Empty(t, errs)
+
+	// exhausted and pinned iterators are not open
+	for it := s.IteratorRange(0, 3); it.Next(); {
+	}
+	it = s.Iterator(Pinned())
+	s.Load([]Pair[int, int]{{1, 1}})
+	assert.Empty(t, errs)
+	it.Close()
+}
+
+func TestIterationGuardPanics(t *testing.T) {
+	s := NewSkipList[int, int](WithIterationGuard(nil))
+	s.Set(1, 1)
+	it := s.Iterator()
+	assert.Panics(t, func() { s.Remove(1) })
+	it.Close()
+	assert.NotPanics(t, func() { s.Remove(1) })
+}
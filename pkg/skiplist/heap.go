@@ -0,0 +1,69 @@
+package skiplist
+
+import "cmp"
+
+// Heap is a priority queue with the method set of container/heap (Push, Pop, Fix, Remove) on top of a skip
+// list, easing the migration of code written against container/heap. The elements are ordered by priority,
+// equal priorities in insertion order. Unlike container/heap it needs no heap.Interface, Pop returns the
+// element with the smallest priority in O(1), and elements are addressed by their nodes instead of indices.
+type Heap[P cmp.Ordered, V any] struct {
+	list *SkipList[P, V]
+}
+
+// NewHeap creates an empty Heap configured by options, e.g. WithDescending for a max heap.
+func NewHeap[P cmp.Ordered, V any](options ...Option) *Heap[P, V] {
+	return &Heap[P, V]{list: NewSkipList[P, V](append(options, WithDuplicates())...)}
+}
+
+// Len returns the number of elements.
+func (h *Heap[P, V]) Len() int {
+	return h.list.Size()
+}
+
+// Push adds `value` with `priority` and returns its node, which addresses the element for Fix and Remove.
+func (h *Heap[P, V]) Push(priority P, value V) *Node[P, V] {
+	x, _, _ := h.list.Set(priority, value)
+	return x
+}
+
+// Pop removes the element with the smallest priority and returns it. The bool is false if the heap is empty.
+func (h *Heap[P, V]) Pop() (P, V, bool) {
+	x := h.list.PopMin()
+	if x == nil {
+		var priority P
+		var value V
+		return priority, value, false
+	}
+	return x.key, h.list.ValueOf(x), true
+}
+
+// Peek returns the element with the smallest priority without removing it. The bool is false if the heap is
+// empty.
+func (h *Heap[P, V]) Peek() (P, V, bool) {
+	x := h.list.Min()
+	if x == nil {
+		var priority P
+		var value V
+		return priority, value, false
+	}
+	return x.key, h.list.ValueOf(x), true
+}
+
+// Fix changes the priority of the element of node x and returns its new node, which replaces x. Returns nil if
+// x is not contained in the heap. Elements with the same priority as x are passed linearly to find x.
+func (h *Heap[P, V]) Fix(x *Node[P, V], priority P) *Node[P, V] {
+	if !h.Remove(x) {
+		return nil
+	}
+	return h.Push(priority, h.list.ValueOf(x))
+}
+
+// Remove removes the element of node x and returns false if x is not contained in the heap.
+func (h *Heap[P, V]) Remove(x *Node[P, V]) bool {
+	pos := h.list.position(x)
+	if pos == InvalidPos {
+		return false
+	}
+	h.list.RemoveByPos(pos)
+	return true
+}
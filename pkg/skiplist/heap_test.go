@@ -0,0 +1,40 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeap(t *testing.T) {
+	h := NewHeap[int, string]()
+	_, _, ok := h.Pop()
+	assert.False(t, ok)
+	h.Push(3, "c")
+	b := h.Push(2, "b")
+	h.Push(2, "b2")
+	h.Push(1, "a")
+	assert.Equal(t, 4, h.Len())
+
+	priority, value, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, priority)
+	assert.Equal(t, "a", value)
+
+	b = h.Fix(b, 4)
+	assert.Equal(t, 4, b.Key())
+	assert.Nil(t, h.Fix(&Node[int, string]{key: 2}, 0))
+	var values []string
+	for h.Len() > 0 {
+		_, value, _ := h.Pop()
+		values = append(values, value)
+	}
+	assert.Equal(t, []string{"a", "b2", "c", "b"}, values)
+	assert.False(t, h.Remove(b))
+
+	maxHeap := NewHeap[int, string](WithDescending())
+	maxHeap.Push(1, "a")
+	maxHeap.Push(5, "e")
+	priority, _, _ = maxHeap.Pop()
+	assert.Equal(t, 5, priority)
+}
@@ -0,0 +1,188 @@
+package skiplist
+
+import (
+	"cmp"
+	"log"
+)
+
+// History records the modifications of a skip list made through it as inverse operations, so they can be
+// undone and redone step by step, e.g. in an interactive editor built on the positional API. Undo and Redo
+// restore the previous states including the positions, values, soft deleted flags, stable IDs, and
+// modification times of the elements. Only the last `depth` steps are kept.
+//
+// The list must not be modified otherwise while the history is used: a modification detected by Undo or Redo
+// discards the recorded steps. Elements removed by policies during a modification (WithMemoryBudget,
+// WithRetention) are not restored; the steps locate their elements by key (see Tx), so they are not confused
+// by them.
+type History[K cmp.Ordered, V any] struct {
+	list    *SkipList[K, V]
+	depth   int
+	undo    []historyStep[K, V]
+	redo    []historyStep[K, V]
+	version uint64 // version of the list after the last recorded step
+}
+
+// historyStep is a recorded modification of the element with the key of its states at `offset` among the
+// elements with an equal key (see keyOffset): before is the state of the element before (nil if it was
+// inserted), after the state after the modification (nil if it was removed).
+type historyStep[K cmp.Ordered, V any] struct {
+	offset        int
+	before, after *Node[K, V]
+}
+
+// NewHistory creates a history of the modifications of s keeping the last `depth` steps.
+func NewHistory[K cmp.Ordered, V any](s *SkipList[K, V], depth int) *History[K, V] {
+	if depth < 1 {
+		log.Panic("Parameter depth out of range (must be >= 1)")
+	}
+	s.lazyInit()
+	return &History[K, V]{list: s, depth: depth, version: s.version}
+}
+
+// List returns the skip list of the history.
+func (h *History[K, V]) List() *SkipList[K, V] {
+	return h.list
+}
+
+// Set sets the value of `key` like SkipList.TrySet as one step.
+func (h *History[K, V]) Set(key K, value V) (*Node[K, V], int, bool, error) {
+	h.check()
+	var before *Node[K, V]
+	if !h.list.duplicates {
+		if x, _ := h.list.Get(key); x != nil {
+			before = nodeState(x)
+		}
+	}
+	first := h.list.firstPos(key)
+	x, pos, created, err := h.list.TrySet(key, value)
+	if err == nil {
+		h.record(h.list.keyOffset(pos, first), before, nodeState(x))
+	}
+	return x, pos, created, err
+}
+
+// InsertBefore inserts a new element directly before `node` like SkipList.InsertBefore as one step.
+func (h *History[K, V]) InsertBefore(node *Node[K, V], key K, value V) (*Node[K, V], int, error) {
+	h.check()
+	first := h.list.firstPos(key)
+	x, pos, err := h.list.InsertBefore(node, key, value)
+	if err == nil {
+		h.record(h.list.keyOffset(pos, first), nil, nodeState(x))
+	}
+	return x, pos, err
+}
+
+// InsertAfter inserts a new element directly behind `node` like SkipList.InsertAfter as one step.
+func (h *History[K, V]) InsertAfter(node *Node[K, V], key K, value V) (*Node[K, V], int, error) {
+	h.check()
+	first := h.list.firstPos(key)
+	x, pos, err := h.list.InsertAfter(node, key, value)
+	if err == nil {
+		h.record(h.list.keyOffset(pos, first), nil, nodeState(x))
+	}
+	return x, pos, err
+}
+
+// Remove removes the element with `key` like SkipList.Remove as one step.
+func (h *History[K, V]) Remove(key K) (*Node[K, V], int) {
+	h.check()
+	x, pos := h.list.Remove(key)
+	if x != nil {
+		// Remove removes the first node with the key
+		h.record(0, nodeState(x), nil)
+	}
+	return x, pos
+}
+
+// RemoveByPos removes the element at position k like SkipList.RemoveByPos as one step.
+func (h *History[K, V]) RemoveByPos(k int) *Node[K, V] {
+	h.check()
+	x := h.list.RemoveByPos(k)
+	if x != nil {
+		h.record(h.list.keyOffset(k, h.list.firstPos(x.key)), nodeState(x), nil)
+	}
+	return x
+}
+
+// Undo reverts the last step. Returns false if there is no step to undo.
+func (h *History[K, V]) Undo() bool {
+	h.check()
+	if len(h.undo) == 0 {
+		return false
+	}
+	step := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.apply(step.offset, step.after, step.before)
+	h.redo = append(h.redo, step)
+	return true
+}
+
+// Redo repeats the last undone step. Returns false if there is no step to redo.
+func (h *History[K, V]) Redo() bool {
+	h.check()
+	if len(h.redo) == 0 {
+		return false
+	}
+	step := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.apply(step.offset, step.before, step.after)
+	h.undo = append(h.undo, step)
+	return true
+}
+
+// CanUndo returns true if there is a step to undo.
+func (h *History[K, V]) CanUndo() bool {
+	h.check()
+	return len(h.undo) > 0
+}
+
+// CanRedo returns true if there is a step to redo.
+func (h *History[K, V]) CanRedo() bool {
+	h.check()
+	return len(h.redo) > 0
+}
+
+// check discards the recorded steps if the list was modified otherwise.
+func (h *History[K, V]) check() {
+	if h.list.version != h.version {
+		h.undo = nil
+		h.redo = nil
+		h.version = h.list.version
+	}
+}
+
+// record adds a new step and discards the steps to redo.
+func (h *History[K, V]) record(offset int, before, after *Node[K, V]) {
+	if len(h.undo) == h.depth {
+		h.undo = append(h.undo[:0], h.undo[1:]...)
+	}
+	h.undo = append(h.undo, historyStep[K, V]{offset: offset, before: before, after: after})
+	h.redo = nil
+	h.version = h.list.version
+}
+
+// apply turns the element at `offset` among the elements with its key from the state `from` into the state
+// `to`.
+func (h *History[K, V]) apply(offset int, from, to *Node[K, V]) {
+	s := h.list
+	switch {
+	case from == nil:
+		s.restore(offset, to)
+	case to == nil:
+		s.removeAt(from.key, offset)
+	default:
+		s.ensureOwned()
+		if x, _ := s.nodeAt(to.key, offset); x != nil {
+			x.Value = to.Value
+			s.markDeleted(x, to.deleted)
+			x.modified = to.modified
+		}
+	}
+	h.version = s.version
+}
+
+// nodeState returns a copy of the element state of x without its links.
+func nodeState[K cmp.Ordered, V any](x *Node[K, V]) *Node[K, V] {
+	return &Node[K, V]{key: x.key, Value: x.Value, id: x.id, modified: x.modified, deleted: x.deleted,
+		seq: x.seq}
+}
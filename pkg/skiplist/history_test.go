@@ -0,0 +1,102 @@
+package skiplist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	s := NewSkipList[int, string](WithDuplicates())
+	h := NewHistory(s, 10)
+	h.Set(1, "a")
+	h.Set(3, "c")
+	x, _, _, err := h.Set(3, "d")
+	require.NoError(t, err)
+	_, pos, err := h.InsertBefore(x, 3, "b")
+	require.NoError(t, err)
+	assert.Equal(t, 2, pos)
+	h.RemoveByPos(0)
+	assert.Equal(t, []string{"c", "b", "d"}, valuesOf(s))
+
+	require.True(t, h.Undo())
+	assert.Equal(t, []string{"a", "c", "b", "d"}, valuesOf(s))
+	require.True(t, h.Undo())
+	assert.Equal(t, []string{"a", "c", "d"}, valuesOf(s))
+	require.True(t, h.Redo())
+	assert.Equal(t, []string{"a", "c", "b", "d"}, valuesOf(s))
+	for h.Undo() {
+	}
+	assert.Equal(t, 0, s.Size())
+	assert.False(t, h.CanUndo())
+	for h.Redo() {
+	}
+	assert.Equal(t, []string{"c", "b", "d"}, valuesOf(s))
+	require.NoError(t, s.Validate())
+
+	require.True(t, h.Undo())
+	h.Remove(3)
+	assert.False(t, h.CanRedo())
+	assert.Equal(t, []string{"a", "b", "d"}, valuesOf(s))
+}
+
+func TestHistoryValues(t *testing.T) {
+	s := NewSkipList[int, string]()
+	h := NewHistory(s, 2)
+	h.Set(1, "a")
+	h.Set(1, "b")
+	s.MarkDeleted(1)
+	h.Set(1, "c")
+	x, _ := s.Get(1)
+	assert.False(t, x.Deleted())
+
+	require.True(t, h.Undo())
+	assert.Equal(t, "b", x.Value)
+	assert.True(t, x.Deleted())
+	require.True(t, h.Undo())
+	assert.Equal(t, "a", x.Value)
+	assert.False(t, h.Undo(), "depth exceeded")
+	require.True(t, h.Redo())
+	assert.Equal(t, "b", x.Value)
+}
+
+func TestHistoryStale(t *testing.T) {
+	s := NewSkipList[int, int]()
+	h := NewHistory(s, 5)
+	h.Set(1, 1)
+	h.Set(2, 2)
+	s.Set(0, 0)
+	assert.False(t, h.Undo())
+	assert.Equal(t, 3, s.Size())
+	assert.Panics(t, func() { NewHistory(s, 0) })
+}
+
+func TestHistoryAfterPruning(t *testing.T) {
+	now := time.Unix(1008, 0)
+	s := NewSkipList[int, int](WithRetention[int, int](10*time.Second, func() time.Time { return now },
+		func(k int) time.Time { return time.Unix(int64(k), 0) }), WithDuplicates())
+	h := NewHistory(s, 10)
+	for k := 1000; k <= 1008; k += 2 {
+		h.Set(k, 0)
+	}
+	h.Set(1006, 1)
+
+	// the insert prunes 1000 and shifts the positions
+	now = time.Unix(1011, 0)
+	_, pos, _, err := h.Set(1006, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 5, pos)
+	require.True(t, h.Undo())
+	assert.Equal(t, []int{1002, 1004, 1006, 1006, 1008}, collectKeys(s.Iterator()))
+	assert.Equal(t, []int{0, 0, 0, 1, 0}, valuesOf(s))
+	require.True(t, h.Redo())
+	assert.Equal(t, []int{0, 0, 0, 1, 2, 0}, valuesOf(s))
+
+	h.RemoveByPos(3)
+	assert.Equal(t, []int{0, 0, 0, 2, 0}, valuesOf(s))
+	require.True(t, h.Undo())
+	assert.Equal(t, []int{0, 0, 0, 1, 2, 0}, valuesOf(s))
+	require.NoError(t, s.Validate())
+}
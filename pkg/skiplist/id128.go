@@ -0,0 +1,133 @@
+package skiplist
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ID128 is a 16 byte identifier like a UUID or a ULID used as key. It holds the raw bytes as string, so it is
+// cmp.Ordered and compares lexicographically byte by byte without formatting the ID as text. For ULIDs this is
+// the order of their creation times. Conversions from and to [16]byte do not allocate for map lookups, but
+// NewID128 copies the bytes once.
+type ID128 string
+
+// NewID128 returns the key of the 16 bytes b.
+func NewID128(b [16]byte) ID128 {
+	return ID128(b[:])
+}
+
+// Bytes returns the 16 bytes of the ID.
+func (id ID128) Bytes() [16]byte {
+	var b [16]byte
+	copy(b[:], id)
+	return b
+}
+
+// ParseUUID parses a UUID in the canonical form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func ParseUUID(s string) (ID128, error) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return "", fmt.Errorf("%w: UUID %q", ErrInvalidEncoding, s)
+	}
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil {
+		return "", fmt.Errorf("%w: UUID %q", ErrInvalidEncoding, s)
+	}
+	return ID128(b), nil
+}
+
+// UUIDString formats the ID in the canonical form of a UUID.
+func (id ID128) UUIDString() string {
+	b := id.Bytes()
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// crockford is the Base32 alphabet of ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ParseULID parses a ULID in its 26 character Base32 form (case insensitive).
+func ParseULID(s string) (ID128, error) {
+	if len(s) != 26 || s[0] > '7' {
+		return "", fmt.Errorf("%w: ULID %q", ErrInvalidEncoding, s)
+	}
+	var b [16]byte
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(crockford, upper(s[i]))
+		if v < 0 {
+			return "", fmt.Errorf("%w: ULID %q", ErrInvalidEncoding, s)
+		}
+		// shift the 130 bit number left by 5 bits and add v
+		carry := byte(v)
+		for j := 15; j >= 0; j-- {
+			next := b[j] >> 3
+			b[j] = b[j]<<5 | carry
+			carry = next
+		}
+	}
+	return NewID128(b), nil
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// ULIDString formats the ID in the Base32 form of a ULID.
+func (id ID128) ULIDString() string {
+	b := id.Bytes()
+	var s [26]byte
+	for i := 25; i >= 0; i-- {
+		s[i] = crockford[b[15]&31]
+		// shift the 128 bit number right by 5 bits
+		for j := 15; j > 0; j-- {
+			b[j] = b[j]>>5 | b[j-1]<<3
+		}
+		b[0] >>= 5
+	}
+	return string(s[:])
+}
+
+// ULIDTime returns the timestamp of a ULID, which are its first 48 bits as Unix milliseconds.
+func (id ID128) ULIDTime() time.Time {
+	b := id.Bytes()
+	ms := binary.BigEndian.Uint64(b[0:8]) >> 16
+	return time.UnixMilli(int64(ms))
+}
+
+// ULIDBounds returns the smallest and the largest ULID with a timestamp between from and to (inclusive, in
+// millisecond precision). Times before the Unix epoch are clamped to it.
+func ULIDBounds(from, to time.Time) (ID128, ID128) {
+	var lo, hi [16]byte
+	binary.BigEndian.PutUint64(lo[0:8], uint64(max(from.UnixMilli(), 0))<<16)
+	binary.BigEndian.PutUint64(hi[0:8], uint64(max(to.UnixMilli(), 0))<<16|0xFFFF)
+	for i := 8; i < 16; i++ {
+		hi[i] = 0xFF
+	}
+	return NewID128(lo), NewID128(hi)
+}
+
+// RangeULIDTime calls fn for the elements of s whose ULID keys were created between from and to (inclusive) in
+// ascending order until fn returns false, like Range with the bounds of ULIDBounds.
+func RangeULIDTime[V any](s *SkipList[ID128, V], from, to time.Time, fn func(key ID128, value V) bool) {
+	lo, hi := ULIDBounds(from, to)
+	s.Range(lo, hi, fn)
+}
+
+// ID128Codec encodes IDs as their 16 bytes.
+type ID128Codec struct{}
+
+func (ID128Codec) AppendKey(dst []byte, key ID128) []byte {
+	b := key.Bytes()
+	return append(dst, b[:]...)
+}
+
+func (ID128Codec) DecodeKey(src []byte) (ID128, int, error) {
+	if len(src) < 16 {
+		return "", 0, ErrInvalidEncoding
+	}
+	return ID128(src[:16]), 16, nil
+}
@@ -0,0 +1,53 @@
+package skiplist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUID(t *testing.T) {
+	id, err := ParseUUID("123e4567-e89b-12d3-a456-426614174000")
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x12), id.Bytes()[0])
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", id.UUIDString())
+	_, err = ParseUUID("123e4567e89b12d3a456426614174000")
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+	_, err = ParseUUID("123e4567-e89b-12d3-a456-42661417400g")
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+}
+
+func TestULID(t *testing.T) {
+	id, err := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", id.ULIDString())
+	assert.Equal(t, int64(1469922850259), id.ULIDTime().UnixMilli())
+	lower, err := ParseULID("01arz3ndektsv4rrffq69g5fav")
+	require.NoError(t, err)
+	assert.Equal(t, id, lower)
+	_, err = ParseULID("81ARZ3NDEKTSV4RRFFQ69G5FAV")
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+	_, err = ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAU!")
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+	checkCodec[ID128](t, ID128Codec{}, []ID128{id, NewID128([16]byte{}), NewID128([16]byte{0xff})})
+}
+
+func TestRangeULIDTime(t *testing.T) {
+	base := time.UnixMilli(1700000000000)
+	s := NewSkipList[ID128, int]()
+	for i := 0; i < 10; i++ {
+		lo, _ := ULIDBounds(base.Add(time.Duration(i)*time.Second), base)
+		b := lo.Bytes()
+		b[15] = byte(i)
+		s.Set(NewID128(b), i)
+	}
+	var got []int
+	RangeULIDTime(s, base.Add(2*time.Second), base.Add(4*time.Second), func(key ID128, value int) bool {
+		got = append(got, value)
+		assert.Equal(t, base.Add(time.Duration(value)*time.Second), key.ULIDTime())
+		return true
+	})
+	assert.Equal(t, []int{2, 3, 4}, got)
+}
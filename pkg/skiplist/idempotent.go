@@ -0,0 +1,68 @@
+package skiplist
+
+import "log"
+
+// defaultRequestWindow is the number of request IDs remembered by SetIdempotent without WithIdempotencyWindow.
+const defaultRequestWindow = 4096
+
+// WithIdempotencyWindow sets the number of the most recently applied request IDs remembered by SetIdempotent.
+// A replay is only detected while its request ID is within the window, so the window must cover the redelivery
+// horizon of the pipeline. The default is 4096.
+func WithIdempotencyWindow(n int) Option {
+	if n < 1 {
+		log.Panic("Parameter n out of range (must be >= 1)")
+	}
+	return func(c *config) {
+		c.requestWindow = n
+	}
+}
+
+// requestWindow remembers the last applied request IDs in a ring buffer.
+type requestWindow struct {
+	ids     []uint64
+	next    int // index of the oldest ID once the ring is full
+	applied map[uint64]struct{}
+}
+
+// add remembers id and forgets the oldest ID if the window is full.
+func (w *requestWindow) add(id uint64, size int) {
+	if len(w.ids) < size {
+		w.ids = append(w.ids, id)
+	} else {
+		delete(w.applied, w.ids[w.next])
+		w.ids[w.next] = id
+		w.next = (w.next + 1) % size
+	}
+	w.applied[id] = struct{}{}
+}
+
+// SetIdempotent sets the value of `key` like TrySet unless the request `requestID` was applied before, e.g. for
+// pipelines with at-least-once delivery which redeliver a write after a failure. Returns false for a replay,
+// which leaves the list unchanged. The IDs of the most recent requests are remembered (see
+// WithIdempotencyWindow); requests rejected by the admission control are not remembered. The window is not
+// reset by Clear and not carried over to lists derived from s, e.g. by Snapshot or Compact.
+func (s *SkipList[K, V]) SetIdempotent(requestID uint64, key K, value V) (bool, error) {
+	applied, _, err := s.setIdempotent(requestID, key, value)
+	return applied, err
+}
+
+// setIdempotent is SetIdempotent additionally reporting whether a node was created.
+func (s *SkipList[K, V]) setIdempotent(requestID uint64, key K, value V) (bool, bool, error) {
+	s.lazyInit()
+	if s.requests == nil {
+		s.requests = &requestWindow{applied: make(map[uint64]struct{})}
+	}
+	if _, ok := s.requests.applied[requestID]; ok {
+		return false, false, nil
+	}
+	_, _, created, err := s.TrySet(key, value)
+	if err != nil {
+		return false, false, err
+	}
+	size := s.requestWindow
+	if size == 0 {
+		size = defaultRequestWindow
+	}
+	s.requests.add(requestID, size)
+	return true, created, nil
+}
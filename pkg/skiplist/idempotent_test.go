@@ -0,0 +1,74 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetIdempotent(t *testing.T) {
+	var s SkipList[string, int]
+	applied, err := s.SetIdempotent(1, "a", 1)
+	require.NoError(t, err)
+	assert.True(t, applied)
+	s.Set("a", 2)
+	applied, _ = s.SetIdempotent(1, "a", 1)
+	assert.False(t, applied)
+	x, _ := s.Get("a")
+	assert.Equal(t, 2, x.Value)
+
+	// the snapshot does not share the window
+	snap := s.Snapshot()
+	applied, _ = snap.SetIdempotent(1, "a", 1)
+	assert.True(t, applied)
+
+	b := NewSkipList[string, int](WithKeyBounds[string, int]("a", "m"))
+	_, err = b.SetIdempotent(2, "z", 1)
+	assert.ErrorIs(t, err, ErrKeyOutOfBounds)
+	applied, err = b.SetIdempotent(2, "b", 1)
+	require.NoError(t, err)
+	assert.True(t, applied)
+}
+
+func TestIdempotencyWindow(t *testing.T) {
+	s := NewSkipList[int, int](WithIdempotencyWindow(3))
+	for id := uint64(1); id <= 4; id++ {
+		applied, _ := s.SetIdempotent(id, int(id), 0)
+		assert.True(t, applied)
+	}
+	// request 1 dropped out of the window
+	applied, _ := s.SetIdempotent(1, 1, 0)
+	assert.True(t, applied)
+	for id := uint64(3); id <= 4; id++ {
+		applied, _ := s.SetIdempotent(id, int(id), 0)
+		assert.False(t, applied)
+	}
+	assert.Panics(t, func() { WithIdempotencyWindow(0) })
+}
+
+func TestConcurrentSetIdempotent(t *testing.T) {
+	c := NewConcurrentSkipList[int, int]()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	applied := 0
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := 0; id < 100; id++ {
+				ok, err := c.SetIdempotent(uint64(id), id, w)
+				assert.NoError(t, err)
+				if ok {
+					mu.Lock()
+					applied++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, applied)
+	assert.Equal(t, 100, c.Size(Consistent))
+}
@@ -0,0 +1,115 @@
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// WithInsertionOrder maintains a second chain linking the nodes in the order of their insertion, so the list
+// can be iterated in arrival order (see IterateByInsertion) besides the key order, e.g. for audit logs.
+// Updating the value of an existing key keeps its place in the chain. The chain is maintained in O(1) per
+// insert and removal. Operations replacing the nodes (like snapshots copying shared nodes, Compact, or Load)
+// keep the sequence numbers of the nodes, and the chain is relinked by the next iteration in O(n*log(n)).
+func WithInsertionOrder() Option {
+	return func(c *config) {
+		c.insertionOrder = true
+	}
+}
+
+// IterateByInsertion returns an iterator over the keys and values in the order of their insertion (see
+// WithInsertionOrder). Loaded elements (see Load) count as inserted in key order. Without WithInsertionOrder
+// the elements are returned in key order. Soft deleted elements are skipped. The list must not be modified
+// during the iteration.
+func (s *SkipList[K, V]) IterateByInsertion() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if !s.insertionOrder {
+			for key, value := range s.All() {
+				if !yield(key, value) {
+					return
+				}
+			}
+			return
+		}
+		if !s.insChain {
+			s.linkInsertionChain()
+		}
+		for x := s.insFirst; x != nil; x = x.insNext {
+			if !x.deleted && !yield(x.key, s.ValueOf(x)) {
+				return
+			}
+		}
+	}
+}
+
+// appendInsertion assigns the next sequence number to the new node x and appends it to the chain.
+func (s *SkipList[K, V]) appendInsertion(x *Node[K, V]) {
+	s.nextSeq++
+	x.seq = s.nextSeq
+	if !s.insChain {
+		return
+	}
+	x.insPrev = s.insLast
+	if s.insLast != nil {
+		s.insLast.insNext = x
+	} else {
+		s.insFirst = x
+	}
+	s.insLast = x
+}
+
+// unlinkInsertion removes the node x from the chain.
+func (s *SkipList[K, V]) unlinkInsertion(x *Node[K, V]) {
+	if !s.insChain {
+		return
+	}
+	if x.insPrev != nil {
+		x.insPrev.insNext = x.insNext
+	} else {
+		s.insFirst = x.insNext
+	}
+	if x.insNext != nil {
+		x.insNext.insPrev = x.insPrev
+	} else {
+		s.insLast = x.insPrev
+	}
+	x.insPrev, x.insNext = nil, nil
+}
+
+// linkInsertionChain links all nodes in the order of their sequence numbers.
+func (s *SkipList[K, V]) linkInsertionChain() {
+	nodes := make([]*Node[K, V], 0, s.count)
+	for x := s.First(); x != nil; x = x.Next() {
+		nodes = append(nodes, x)
+	}
+	slices.SortStableFunc(nodes, func(a, b *Node[K, V]) int { return cmp.Compare(a.seq, b.seq) })
+	s.insFirst, s.insLast = nil, nil
+	for _, x := range nodes {
+		x.insPrev, x.insNext = s.insLast, nil
+		if s.insLast != nil {
+			s.insLast.insNext = x
+		} else {
+			s.insFirst = x
+		}
+		s.insLast = x
+	}
+	s.insChain = true
+}
+
+// assignSeqs assigns new sequence numbers to all nodes in key order after the content was replaced.
+func (s *SkipList[K, V]) assignSeqs() {
+	for x := s.First(); x != nil; x = x.Next() {
+		s.nextSeq++
+		x.seq = s.nextSeq
+	}
+	s.insChain = false
+}
+
+// setSeq restores the sequence number of a node relinked by an undo or a rebuild. The chain is relinked by the
+// next iteration since the node was appended at its end.
+func (s *SkipList[K, V]) setSeq(x *Node[K, V], seq uint64) {
+	if x.seq != seq {
+		x.seq = seq
+		s.insChain = false
+	}
+}
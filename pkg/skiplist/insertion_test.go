@@ -0,0 +1,67 @@
+package skiplist
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertionKeys(s *SkipList[int, int]) []int {
+	var keys []int
+	for key := range s.IterateByInsertion() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestInsertionOrder(t *testing.T) {
+	s := NewSkipList[int, int](WithInsertionOrder())
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		s.Set(k, k*10)
+	}
+	assert.Equal(t, []int{5, 1, 9, 3, 7}, insertionKeys(s))
+	assert.Equal(t, map[int]int{1: 10, 3: 30, 5: 50, 7: 70, 9: 90}, maps.Collect(s.IterateByInsertion()))
+
+	s.Set(1, 11) // an update keeps the place
+	s.Remove(9)
+	s.Remove(5)
+	s.Set(4, 40)
+	s.MarkDeleted(3)
+	assert.Equal(t, []int{1, 7, 4}, insertionKeys(s))
+
+	// the order survives the replacement of the nodes
+	snap := s.Snapshot()
+	s.Set(2, 20)
+	assert.Equal(t, []int{1, 7, 4, 2}, insertionKeys(s))
+	assert.Equal(t, []int{1, 7, 4}, insertionKeys(snap))
+	c := s.Compact()
+	c.Set(0, 0)
+	assert.Equal(t, []int{1, 7, 4, 2, 0}, insertionKeys(c))
+	s.RemoveByPos(0)
+	s.Set(8, 80)
+	assert.Equal(t, []int{7, 4, 2, 8}, insertionKeys(s))
+	require.NoError(t, s.Validate())
+
+	// undo restores the former place
+	tx := &Tx{}
+	TxRemove(tx, s, 4)
+	assert.Equal(t, []int{7, 2, 8}, insertionKeys(s))
+	tx.Rollback()
+	assert.Equal(t, []int{7, 4, 2, 8}, insertionKeys(s))
+
+	dst := s.ExtractRange(4, 7)
+	dst.Set(5, 50)
+	assert.Equal(t, []int{7, 4, 5}, insertionKeys(dst))
+	assert.Equal(t, []int{2, 8}, insertionKeys(s))
+
+	s.Load([]Pair[int, int]{{3, 0}, {1, 0}})
+	s.Set(2, 0)
+	assert.Equal(t, []int{1, 3, 2}, insertionKeys(s))
+}
+
+func TestInsertionOrderDisabled(t *testing.T) {
+	s := Collect(pairSeq(3, 1, 2))
+	assert.Equal(t, []int{1, 2, 3}, insertionKeys(s))
+}
@@ -0,0 +1,65 @@
+package skiplist
+
+import (
+	"cmp"
+	"reflect"
+	"unique"
+	"unsafe"
+)
+
+// WithKeyInterning stores the string keys of inserted elements as canonical copies (see the package unique),
+// so equal keys inserted repeatedly or into many skip lists share their backing storage, e.g. for the keys of
+// per-minute lists repeating the same names. The list keeps a unique.Handle of every key it holds, which keeps
+// the canonical copy alive; removing the last element with a key releases its handle, and the garbage
+// collector reclaims the canonical copy once no list holds a handle of it anymore. Comparisons of two interned
+// equal keys take a fast path, as their bytes are at the same address. The option has no effect on other key
+// types.
+func WithKeyInterning() Option {
+	return func(c *config) {
+		c.internKeys = true
+	}
+}
+
+// newInternMap returns an empty map for the handles of the interned keys or nil if K is not a string type.
+func newInternMap[K cmp.Ordered](n int) map[K]unique.Handle[K] {
+	if reflect.TypeFor[K]().Kind() != reflect.String {
+		return nil
+	}
+	return make(map[K]unique.Handle[K], n)
+}
+
+// intern returns the canonical copy of key and keeps its handle. s.interned must not be nil.
+func (s *SkipList[K, V]) intern(key K) K {
+	if h, ok := s.interned[key]; ok {
+		return h.Value()
+	}
+	h := unique.Make(key)
+	s.interned[h.Value()] = h
+	return h.Value()
+}
+
+// releaseKey drops the handle of a removed key unless one of the nodes still has the key. As equal keys are
+// adjacent, the neighbors of the removed nodes are sufficient.
+func (s *SkipList[K, V]) releaseKey(key K, nodes ...*Node[K, V]) {
+	for _, y := range nodes {
+		if y != nil && y != s.head && y.key == key {
+			return
+		}
+	}
+	delete(s.interned, key)
+}
+
+// indexKeys interns the keys of all nodes after the content was replaced. s.interned must not be nil.
+func (s *SkipList[K, V]) indexKeys() {
+	s.interned = make(map[K]unique.Handle[K], s.count)
+	for x := s.First(); x != nil; x = x.Next() {
+		x.key = s.intern(x.key)
+	}
+}
+
+// sameString reports whether the string keys a and b are the same string, i.e. they have the same length and
+// the same address of their bytes. K must be a string type.
+func sameString[K cmp.Ordered](a, b K) bool {
+	x, y := *(*string)(unsafe.Pointer(&a)), *(*string)(unsafe.Pointer(&b))
+	return len(x) == len(y) && unsafe.StringData(x) == unsafe.StringData(y)
+}
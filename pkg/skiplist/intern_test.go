@@ -0,0 +1,85 @@
+package skiplist
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// data returns the address of the bytes of a string.
+func data[S ~string](s S) *byte {
+	return unsafe.StringData(string(s))
+}
+
+func TestKeyInterning(t *testing.T) {
+	type name string
+	a := NewSkipList[name, int](WithKeyInterning())
+	b := NewSkipList[name, int](WithKeyInterning())
+	key := func() name { return name(strings.Repeat("k", 3)) }
+	x, _, _ := a.Set(key(), 1)
+	y, _, _ := b.Set(key(), 2)
+	assert.Same(t, data(x.Key()), data(y.Key()))
+
+	b.Load([]Pair[name, int]{{key(), 3}})
+	assert.Same(t, data(x.Key()), data(b.First().Key()))
+	c := b.Compact()
+	z, _, _ := c.Set(key()+"2", 0)
+	assert.Same(t, data(z.Key()), data(c.intern(key()+"2")))
+
+	// without the option the keys keep their own storage
+	plain := NewSkipList[name, int]()
+	p, _, _ := plain.Set(key(), 1)
+	assert.NotSame(t, data(x.Key()), data(p.Key()))
+
+	ints := NewSkipList[int, int](WithKeyInterning())
+	assert.Nil(t, ints.interned)
+	ints.Set(1, 1)
+	require.NoError(t, ints.Validate())
+}
+
+func TestKeyInterningHandles(t *testing.T) {
+	s := NewSkipList[string, int](WithKeyInterning(), WithDuplicates())
+	for _, k := range []string{"a", "b", "b", "c"} {
+		s.Set(strings.Clone(k), 0)
+	}
+	assert.Len(t, s.interned, 3)
+
+	// the handle of a key is kept while a node has the key
+	s.Remove("b")
+	assert.Contains(t, s.interned, "b")
+	s.Remove("b")
+	assert.NotContains(t, s.interned, "b")
+	s.RemoveByPos(0)
+	assert.NotContains(t, s.interned, "a")
+
+	// derived lists keep their own handles
+	snap := s.Snapshot()
+	snap.Remove("c")
+	assert.Contains(t, s.interned, "c")
+	assert.Empty(t, snap.interned)
+	s.Set("d", 0)
+	e := s.ExtractRange("d", "d")
+	assert.Equal(t, []string{"c"}, slices.Sorted(maps.Keys(s.interned)))
+	assert.Equal(t, []string{"d"}, slices.Sorted(maps.Keys(e.interned)))
+	assert.Len(t, s.Compact().interned, 1)
+
+	s.Load([]Pair[string, int]{{"x", 0}, {"y", 0}})
+	assert.Equal(t, []string{"x", "y"}, slices.Sorted(maps.Keys(s.interned)))
+	s.Clear()
+	assert.Empty(t, s.interned)
+}
+
+func TestKeyInterningFastPath(t *testing.T) {
+	s := NewSkipList[string, int](WithKeyInterning())
+	x, _, _ := s.Set(strings.Repeat("k", 3), 0)
+	assert.True(t, sameString(x.Key(), s.intern("kkk")))
+	assert.False(t, sameString(x.Key(), strings.Repeat("k", 3)))
+	assert.False(t, s.less(x.Key(), s.intern("kkk")))
+	assert.Equal(t, 0, s.compare(x.Key(), s.intern("kkk")))
+	assert.True(t, s.less(x.Key(), "kkl"))
+}
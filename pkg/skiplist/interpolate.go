@@ -0,0 +1,33 @@
+package skiplist
+
+// Number is the constraint of numeric key types supporting interpolation.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Interpolate finds the elements bracketing `key` for lookups at unsampled positions of a series, e.g. of time
+// stamps: lo is the last element with a key <= key and hi the first element with a key >= key. frac is the
+// relative position of key between both keys in [0, 1], so a value can be interpolated linearly by
+// lo.Value + frac*(hi.Value-lo.Value). If key exists, lo and hi are its node and frac is 0. If key is outside
+// of the keys of the list, the missing bracket is nil and frac is 0.
+func Interpolate[K Number, V any](s *SkipList[K, V], key K) (lo, hi *Node[K, V], frac float64) {
+	s.lazyInit()
+	x := s.head
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.less(x.next[i].key, key) {
+			x = x.next[i]
+		}
+	}
+	hi = x.Next()
+	if hi != nil && hi.key == key {
+		return hi, hi, 0
+	}
+	if x != s.head {
+		lo = x
+	}
+	if lo == nil || hi == nil {
+		return lo, hi, 0
+	}
+	return lo, hi, (float64(key) - float64(lo.key)) / (float64(hi.key) - float64(lo.key))
+}
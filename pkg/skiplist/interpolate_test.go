@@ -0,0 +1,47 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolate(t *testing.T) {
+	s := NewSkipList[int64, float64]()
+	s.Set(10, 1)
+	s.Set(20, 3)
+	s.Set(40, 7)
+
+	lo, hi, frac := Interpolate(s, 15)
+	assert.Equal(t, int64(10), lo.Key())
+	assert.Equal(t, int64(20), hi.Key())
+	assert.Equal(t, 0.5, frac)
+	assert.Equal(t, 2.0, lo.Value+frac*(hi.Value-lo.Value))
+
+	lo, hi, frac = Interpolate(s, 35)
+	assert.Equal(t, int64(20), lo.Key())
+	assert.Equal(t, int64(40), hi.Key())
+	assert.Equal(t, 0.75, frac)
+
+	lo, hi, frac = Interpolate(s, 20)
+	assert.Same(t, lo, hi)
+	assert.Equal(t, int64(20), lo.Key())
+	assert.Zero(t, frac)
+
+	lo, hi, _ = Interpolate(s, 5)
+	assert.Nil(t, lo)
+	assert.Equal(t, int64(10), hi.Key())
+	lo, hi, _ = Interpolate(s, 50)
+	assert.Equal(t, int64(40), lo.Key())
+	assert.Nil(t, hi)
+
+	// unsigned keys do not overflow
+	u := NewSkipList[uint8, int]()
+	u.Set(200, 0)
+	u.Set(250, 0)
+	_, _, frac = Interpolate(u, 210)
+	assert.InDelta(t, 0.2, frac, 1e-9)
+	flo, fhi, _ := Interpolate(NewSkipList[float32, int](), 1)
+	assert.Nil(t, flo)
+	assert.Nil(t, fhi)
+}
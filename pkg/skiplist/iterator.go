@@ -0,0 +1,176 @@
+package skiplist
+
+import "cmp"
+
+// Iterator walks over the nodes of a SkipList in ascending key order.
+//
+//	for it := s.Iterator(skiplist.Offset(20), skiplist.Limit(10)); it.Next(); {
+//		fmt.Println(it.Node().Key())
+//	}
+//
+// The skip list must not be modified while it is iterated unless the iterator is pinned (see Pinned).
+type Iterator[K cmp.Ordered, V any] struct {
+	node       *Node[K, V] // current node, nil before the first call of Next()
+	start      *Node[K, V] // first node returned by Next()
+	pos        int         // position of the current node
+	to         K           // inclusive upper key bound if bounded is true
+	bounded    bool
+	descending bool            // the keys are descending
+	end        int             // exclusive upper position bound, negative if unbounded
+	remaining  int             // number of nodes which may still be returned, negative for unlimited
+	deleted    bool            // return soft deleted nodes
+	pinned     *SkipList[K, V] // snapshot iterated by a pinned iterator until it is closed
+	guarded    *SkipList[K, V] // list guarding the iterator until it is closed (see WithIterationGuard)
+}
+
+type iteratorConfig struct {
+	offset  int
+	limit   int
+	deleted bool
+	pinned  bool
+}
+
+// IteratorOption configures an Iterator.
+type IteratorOption func(*iteratorConfig)
+
+// Offset skips the first k elements of the iterated range. The skip is done by a positional
+// jump in O(log(n)) and not by walking over the skipped elements, so soft deleted elements are counted.
+func Offset(k int) IteratorOption {
+	return func(c *iteratorConfig) {
+		if k > 0 {
+			c.offset = k
+		}
+	}
+}
+
+// Limit restricts the iteration to at most n elements.
+func Limit(n int) IteratorOption {
+	return func(c *iteratorConfig) {
+		if n < 0 {
+			n = 0
+		}
+		c.limit = n
+	}
+}
+
+// IncludeDeleted makes the iterator return soft deleted elements (see SkipList.MarkDeleted), which are
+// skipped by default.
+func IncludeDeleted() IteratorOption {
+	return func(c *iteratorConfig) {
+		c.deleted = true
+	}
+}
+
+// Pinned makes the iterator work on a snapshot of the skip list taken when the iterator is created (see
+// SkipList.Snapshot), so the list may be modified while it is iterated and the iterator still sees the
+// elements of its start. The snapshot is released by Iterator.Close() or when the iterator is exhausted.
+// Until then the first modification after a pinned iterator was created copies all nodes in O(n).
+func Pinned() IteratorOption {
+	return func(c *iteratorConfig) {
+		c.pinned = true
+	}
+}
+
+// Iterator returns an iterator over all elements of the skip list.
+func (s *SkipList[K, V]) Iterator(options ...IteratorOption) *Iterator[K, V] {
+	cfg, s := s.iteratorConfig(options)
+	return s.newIterator(0, cfg)
+}
+
+// IteratorRange returns an iterator over all elements with from <= key <= to.
+func (s *SkipList[K, V]) IteratorRange(from, to K, options ...IteratorOption) *Iterator[K, V] {
+	cfg, s := s.iteratorConfig(options)
+	_, pos := s.lowerBound(from)
+	it := s.newIterator(pos, cfg)
+	it.to = to
+	it.bounded = true
+	it.descending = s.descending
+	return it
+}
+
+// iteratorConfig applies the options and returns the list to iterate, which is a snapshot for pinned iterators.
+func (s *SkipList[K, V]) iteratorConfig(options []IteratorOption) (iteratorConfig, *SkipList[K, V]) {
+	s.lazyInit()
+	cfg := iteratorConfig{limit: -1}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	if cfg.pinned {
+		s = s.Snapshot()
+	}
+	return cfg, s
+}
+
+func (s *SkipList[K, V]) newIterator(pos int, cfg iteratorConfig) *Iterator[K, V] {
+	pos += cfg.offset
+	it := &Iterator[K, V]{
+		start:     s.GetByPos(pos),
+		pos:       pos - 1,
+		remaining: cfg.limit,
+		deleted:   cfg.deleted,
+		end:       -1,
+	}
+	if cfg.pinned {
+		it.pinned = s
+	} else if s.iterationGuard {
+		s.guardIterator(it)
+	}
+	return it
+}
+
+// Next advances the iterator to the next element. It returns false if there are no more elements.
+func (it *Iterator[K, V]) Next() bool {
+	if it.remaining == 0 {
+		it.node = nil
+		it.Close()
+		return false
+	}
+	if it.start != nil {
+		it.node = it.start
+		it.start = nil
+	} else if it.node != nil {
+		it.node = it.node.Next()
+	}
+	for !it.deleted && it.node != nil && it.node.deleted {
+		it.node = it.node.Next()
+		it.pos++
+	}
+	if it.node == nil || (it.bounded && keyLess(it.descending, it.to, it.node.key)) || (it.end >= 0 && it.pos+1 >= it.end) {
+		it.node = nil
+		it.remaining = 0
+		it.Close()
+		return false
+	}
+	if it.remaining > 0 {
+		it.remaining--
+	}
+	it.pos++
+	return true
+}
+
+// Node returns the current node of the iterator or nil if the iterator is exhausted.
+func (it *Iterator[K, V]) Node() *Node[K, V] {
+	return it.node
+}
+
+// Pos returns the position 0...n-1 of the current node within the skip list. The position is tracked
+// incrementally and costs no additional search. Returns InvalidPos if the iterator is not positioned on a node.
+func (it *Iterator[K, V]) Pos() int {
+	if it.node == nil {
+		return InvalidPos
+	}
+	return it.pos
+}
+
+// Close releases the snapshot of a pinned iterator (see Pinned) and ends the guard of the iterator (see
+// WithIterationGuard). It may be called multiple times.
+func (it *Iterator[K, V]) Close() {
+	if it.guarded != nil {
+		delete(it.guarded.iterators, it)
+		it.guarded = nil
+	}
+	if it.pinned != nil {
+		it.pinned.releaseNodes()
+		it.pinned = nil
+	}
+}
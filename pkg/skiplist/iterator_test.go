@@ -0,0 +1,96 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectKeys(it *Iterator[int, int]) []int {
+	keys := []int{}
+	for it.Next() {
+		keys = append(keys, it.Node().Key())
+	}
+	return keys
+}
+
+func TestIterator(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for _, k := range makeRandomData(100) {
+		s.Set(k, k)
+	}
+
+	keys := collectKeys(s.Iterator())
+	assert.Len(t, keys, 100)
+	for i, k := range keys {
+		assert.Equal(t, i, k)
+	}
+
+	assert.Equal(t, []int{20, 21, 22}, collectKeys(s.Iterator(Offset(20), Limit(3))))
+	assert.Equal(t, []int{98, 99}, collectKeys(s.Iterator(Offset(98), Limit(10))))
+	assert.Empty(t, collectKeys(s.Iterator(Offset(100))))
+	assert.Empty(t, collectKeys(s.Iterator(Limit(0))))
+}
+
+func TestIteratorRange(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 100; k += 2 {
+		s.Set(k, k)
+	}
+
+	assert.Equal(t, []int{10, 12, 14, 16}, collectKeys(s.IteratorRange(9, 16)))
+	assert.Equal(t, []int{14, 16}, collectKeys(s.IteratorRange(9, 16, Offset(2))))
+	assert.Equal(t, []int{12, 14}, collectKeys(s.IteratorRange(9, 16, Offset(1), Limit(2))))
+	assert.Empty(t, collectKeys(s.IteratorRange(9, 16, Offset(4))))
+	assert.Empty(t, collectKeys(s.IteratorRange(200, 300)))
+	assert.Empty(t, collectKeys(s.IteratorRange(16, 9)))
+}
+
+func TestIteratorPos(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 100; k += 2 {
+		s.Set(k, k)
+	}
+
+	it := s.IteratorRange(9, 30, Offset(3))
+	assert.Equal(t, InvalidPos, it.Pos())
+	n := 0
+	for it.Next() {
+		_, pos := s.Get(it.Node().Key())
+		assert.Equal(t, pos, it.Pos())
+		n++
+	}
+	assert.Equal(t, 8, n)
+	assert.Equal(t, InvalidPos, it.Pos())
+}
+
+func TestIteratorPinned(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	it := s.Iterator(Pinned())
+	require.True(t, it.Next())
+	s.Remove(5)
+	s.Set(100, 100)
+	s.Set(0, -1)
+	keys := []int{it.Node().Key()}
+	assert.Equal(t, 0, it.Node().Value)
+	for it.Next() {
+		keys = append(keys, it.Node().Key())
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, keys)
+	assert.Equal(t, 10, s.Size())
+	it.Close()
+
+	it = s.IteratorRange(3, 6, Pinned())
+	s.Remove(4)
+	assert.Equal(t, []int{3, 4, 6}, collectKeys(it))
+
+	// closing before exhaustion releases the snapshot, the list owns its nodes again
+	it = s.Iterator(Pinned())
+	it.Close()
+	s.Set(50, 50)
+	assert.Nil(t, s.refs)
+}
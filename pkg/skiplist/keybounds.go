@@ -0,0 +1,47 @@
+package skiplist
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ErrKeyOutOfBounds is returned (wrapped by a KeyBoundsError) if a key outside of the bounds set by
+// WithKeyBounds is written.
+var ErrKeyOutOfBounds = errors.New("skiplist: key out of bounds")
+
+// KeyBoundsError reports a rejected key and the bounds of the skip list.
+type KeyBoundsError[K cmp.Ordered] struct {
+	Key      K
+	Min, Max K
+}
+
+func (e *KeyBoundsError[K]) Error() string {
+	return fmt.Sprintf("%v: %v is not within [%v, %v]", ErrKeyOutOfBounds, e.Key, e.Min, e.Max)
+}
+
+func (e *KeyBoundsError[K]) Unwrap() error {
+	return ErrKeyOutOfBounds
+}
+
+// WithKeyBounds restricts the keys to the domain min <= key <= max, e.g. of a shard responsible for a fixed key
+// range. Writes of other keys are rejected by the admission control (see WithAdmissionControl) with a
+// *KeyBoundsError, so TrySet returns the error while Set silently drops the element. The bounds are checked
+// before a function registered by WithAdmissionControl.
+func WithKeyBounds[K cmp.Ordered, V any](min, max K) Option {
+	if cmp.Less(max, min) {
+		log.Panic("Parameters min and max out of range (must be min <= max)")
+	}
+	return typedOption(func(s *SkipList[K, V]) {
+		s.keyBounds = &[2]K{min, max}
+	})
+}
+
+// checkKeyBounds returns a *KeyBoundsError if the key is outside the bounds set by WithKeyBounds.
+func (s *SkipList[K, V]) checkKeyBounds(key K) error {
+	if b := s.keyBounds; cmp.Less(key, b[0]) || cmp.Less(b[1], key) {
+		return &KeyBoundsError[K]{Key: key, Min: b[0], Max: b[1]}
+	}
+	return nil
+}
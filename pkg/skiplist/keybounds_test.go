@@ -0,0 +1,42 @@
+package skiplist
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyBounds(t *testing.T) {
+	errBanned := errors.New("banned")
+	s := NewSkipList[int, string](WithKeyBounds[int, string](10, 20),
+		WithAdmissionControl(func(key int, _ string, _ int) error {
+			if key == 13 {
+				return errBanned
+			}
+			return nil
+		}))
+
+	for _, k := range []int{10, 15, 20} {
+		_, _, created, err := s.TrySet(k, "x")
+		require.NoError(t, err)
+		assert.True(t, created)
+	}
+	_, _, _, err := s.TrySet(21, "x")
+	assert.ErrorIs(t, err, ErrKeyOutOfBounds)
+	var boundsErr *KeyBoundsError[int]
+	require.ErrorAs(t, err, &boundsErr)
+	assert.Equal(t, KeyBoundsError[int]{Key: 21, Min: 10, Max: 20}, *boundsErr)
+	assert.EqualError(t, err, "skiplist: key out of bounds: 21 is not within [10, 20]")
+
+	_, _, _, err = s.TrySet(13, "x")
+	assert.ErrorIs(t, err, errBanned)
+	x, _, _ := s.Set(9, "x")
+	assert.Nil(t, x)
+	_, _, _, err = s.FindPath(5).Set("x")
+	assert.ErrorIs(t, err, ErrKeyOutOfBounds)
+	assert.Equal(t, 3, s.Size())
+
+	assert.Panics(t, func() { WithKeyBounds[int, string](2, 1) })
+}
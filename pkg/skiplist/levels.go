@@ -0,0 +1,64 @@
+package skiplist
+
+// KeysAtLevel calls fn for the keys of all nodes having at least the level i+1 (the "express lane" i) in
+// ascending order until fn returns false. pos is the position of the node. The walk follows the chain of level
+// i only, so it visits about n*p^i nodes. Soft deleted nodes are included, as they are part of the structure.
+func (s *SkipList[K, V]) KeysAtLevel(i int, fn func(key K, pos int) bool) {
+	if i < 0 || i >= s.Level() {
+		return
+	}
+	pos := -1
+	for x := s.head; x.next[i] != nil; x = x.next[i] {
+		pos += x.dist[i]
+		if !fn(x.next[i].key, pos) {
+			return
+		}
+	}
+}
+
+// LevelCounts returns the number of nodes having at least the level i+1 for each level i of the list, i.e. the
+// lengths of the chains of all levels. The first count equals Size().
+func (s *SkipList[K, V]) LevelCounts() []int {
+	counts := make([]int, s.Level())
+	for i := range counts {
+		for x := s.head.next[i]; x != nil; x = x.next[i] {
+			counts[i]++
+		}
+	}
+	return counts
+}
+
+// splitterDensity is the minimum number of nodes per range on the level the splitters are taken from.
+const splitterDensity = 8
+
+// Splitters returns up to parts-1 keys dividing the list into about `parts` ranges of equal size, e.g. the
+// boundaries of shards: the range j contains the keys k with splitters[j-1] <= k < splitters[j]. The keys are
+// taken from the highest level having at least 8 nodes per range, so only a small part of the list is visited.
+// The sizes of the ranges deviate from n/parts by about the gaps between the nodes of that level. Returns nil if parts < 2
+// or the list is empty.
+func (s *SkipList[K, V]) Splitters(parts int) []K {
+	if parts < 2 || s.count == 0 {
+		return nil
+	}
+	counts := s.LevelCounts()
+	level := len(counts) - 1
+	for level > 0 && counts[level] < parts*splitterDensity {
+		level--
+	}
+	splitters := make([]K, 0, parts-1)
+	next := 1
+	s.KeysAtLevel(level, func(key K, pos int) bool {
+		if pos == 0 || pos < next*s.count/parts {
+			return true
+		}
+		if len(splitters) == 0 || splitters[len(splitters)-1] != key {
+			splitters = append(splitters, key)
+		}
+		// skip the targets already passed by this node
+		for next < parts && next*s.count/parts <= pos {
+			next++
+		}
+		return next < parts
+	})
+	return splitters
+}
@@ -0,0 +1,60 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeysAtLevel(t *testing.T) {
+	s := NewSkipList[int, int](WithSeed(3))
+	for i := 0; i < 1000; i++ {
+		s.Set(i, i)
+	}
+	counts := s.LevelCounts()
+	require.Len(t, counts, s.Level())
+	assert.Equal(t, 1000, counts[0])
+	for i := range counts {
+		n := 0
+		last := -1
+		s.KeysAtLevel(i, func(key int, pos int) bool {
+			x := s.GetByPos(pos)
+			assert.Equal(t, key, x.Key())
+			assert.Greater(t, x.Level(), i)
+			assert.Greater(t, key, last)
+			last = key
+			n++
+			return true
+		})
+		assert.Equal(t, counts[i], n, "level %d", i)
+		if i > 0 {
+			assert.LessOrEqual(t, counts[i], counts[i-1])
+		}
+	}
+
+	n := 0
+	s.KeysAtLevel(0, func(int, int) bool {
+		n++
+		return n < 5
+	})
+	assert.Equal(t, 5, n)
+	s.KeysAtLevel(s.Level(), func(int, int) bool {
+		t.Fatal("level out of range")
+		return false
+	})
+}
+
+func TestSplitters(t *testing.T) {
+	s := NewSkipList[int, int](WithSeed(5))
+	assert.Nil(t, s.Splitters(4))
+	for i := 0; i < 10000; i++ {
+		s.Set(i, i)
+	}
+	assert.Nil(t, s.Splitters(1))
+	splitters := s.Splitters(4)
+	require.Len(t, splitters, 3)
+	for j, key := range splitters {
+		assert.InDelta(t, (j+1)*2500, key, 300)
+	}
+}
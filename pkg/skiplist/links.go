@@ -0,0 +1,97 @@
+package skiplist
+
+// The functions of this file maintain the links and distances of the nodes behind a head node. They are shared by
+// SkipList and SkipListFunc, which only differ in how keys are compared: the searches take the comparison as a
+// predicate of the next node.
+
+// descend returns the rightmost node reachable from head by following the nodes x with before(x) on each level
+// and its position, which is -1 for the head. If update is not nil, update and updatePos receive the rightmost
+// node on each level and its position.
+func descend[K any, V any](head *Node[K, V], before func(x *Node[K, V]) bool, update []*Node[K, V],
+	updatePos []int) (*Node[K, V], int) {
+	x := head
+	pos := -1
+	for i := head.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && before(x.next[i]) {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+			updatePos[i] = pos
+		}
+	}
+	return x, pos
+}
+
+// descendByPos is like descend for the rightmost node with a position < k.
+func descendByPos[K any, V any](head *Node[K, V], k int, update []*Node[K, V], updatePos []int) (*Node[K, V], int) {
+	x := head
+	pos := -1
+	for i := head.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && pos+x.dist[i] < k {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+			updatePos[i] = pos
+		}
+	}
+	return x, pos
+}
+
+// growHead raises the head of a list with n nodes to `level` and extends update and updatePos (see linkNode) by
+// the head on the new levels.
+func growHead[K any, V any](head *Node[K, V], update []*Node[K, V], updatePos []int, level,
+	n int) ([]*Node[K, V], []int) {
+	oldLevel := head.Level()
+	update = update[:level]
+	updatePos = updatePos[:level]
+	head.extendLevel(level)
+	for i := oldLevel; i < level; i++ {
+		update[i] = head
+		updatePos[i] = -1
+		head.dist[i] = n + 1
+	}
+	return update, updatePos
+}
+
+// linkNode links the node x behind the node at position `pos`. update and updatePos hold the rightmost nodes
+// on each level with a position <= pos and their positions.
+func linkNode[K any, V any](head *Node[K, V], update []*Node[K, V], updatePos []int, pos int, x *Node[K, V]) {
+	for i := 0; i < head.Level(); i++ {
+		if i >= x.Level() {
+			update[i].dist[i]++
+		} else {
+			x.next[i] = update[i].next[i]
+			update[i].next[i] = x
+			delta := pos - updatePos[i]
+			x.dist[i] = update[i].dist[i] - delta
+			update[i].dist[i] = delta + 1
+		}
+	}
+}
+
+// unlinkNode removes the node x. update holds the rightmost nodes before x on each level.
+func unlinkNode[K any, V any](head *Node[K, V], update []*Node[K, V], x *Node[K, V]) {
+	for i := 0; i < head.Level(); i++ {
+		if update[i].next[i] == x {
+			update[i].next[i] = x.next[i]
+			update[i].dist[i] += x.dist[i] - 1
+		} else {
+			update[i].dist[i]--
+		}
+	}
+}
+
+// shrinkHead lowers the head to the highest level still in use. Returns true if the level was lowered.
+func shrinkHead[K any, V any](head *Node[K, V]) bool {
+	oldLevel := head.Level()
+	level := oldLevel
+	for level > 0 && head.next[level-1] == nil {
+		level--
+	}
+	head.shrinkLevel(level)
+	return level < oldLevel
+}
@@ -0,0 +1,137 @@
+package skiplist
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSorted(t *testing.T) {
+	s := NewSkipList[int, string]()
+	s.Set(100, "old")
+	require.NoError(t, s.LoadSorted([]Pair[int, string]{{1, "a"}, {2, "b"}, {5, "c"}}))
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 3, s.Size())
+	x, _ := s.Get(100)
+	assert.Nil(t, x)
+
+	err := s.LoadSorted([]Pair[int, string]{{1, "a"}, {3, "b"}, {3, "c"}})
+	var orderErr *OrderError
+	require.ErrorAs(t, err, &orderErr)
+	assert.Equal(t, 2, orderErr.Index)
+	assert.ErrorIs(t, err, ErrUnsorted)
+	assert.Equal(t, 3, s.Size())
+
+	d := NewSkipList[int, string](WithDuplicates())
+	require.NoError(t, d.LoadSorted([]Pair[int, string]{{1, "a"}, {3, "b"}, {3, "c"}}))
+	require.NoError(t, d.Validate())
+	assert.ErrorIs(t, d.LoadSorted([]Pair[int, string]{{3, "a"}, {1, "b"}}), ErrUnsorted)
+}
+
+func TestNewFromSorted(t *testing.T) {
+	pairs := make([]Pair[int, int], 100000)
+	for i := range pairs {
+		pairs[i] = Pair[int, int]{Key: 2 * i, Value: i}
+	}
+	s, err := NewFromSorted(pairs)
+	require.NoError(t, err)
+	require.NoError(t, s.Validate())
+	assert.Equal(t, len(pairs), s.Size())
+	x, pos := s.Get(2000)
+	assert.Equal(t, 1000, x.Value)
+	assert.Equal(t, 1000, pos)
+
+	// the levels depend on the positions only
+	other, err := NewFromSorted(pairs, WithSeed(7))
+	require.NoError(t, err)
+	for x, y := s.First(), other.First(); x != nil; x, y = x.Next(), y.Next() {
+		require.Equal(t, x.Level(), y.Level())
+	}
+
+	_, err = NewFromSorted([]Pair[int, int]{{2, 0}, {1, 0}})
+	assert.ErrorIs(t, err, ErrUnsorted)
+	d, err := NewFromSorted([]Pair[int, int]{{2, 0}, {1, 0}}, WithDescending())
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.First().Key())
+}
+
+func TestLoad(t *testing.T) {
+	input := []Pair[int, string]{{5, "a"}, {1, "b"}, {5, "c"}, {3, "d"}}
+	s := NewSkipList[int, string]()
+	s.Load(input)
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []string{"b", "d", "c"}, valuesOf(s))
+	assert.Equal(t, 5, input[0].Key)
+
+	d := NewSkipList[int, string](WithDuplicates())
+	d.Load(input)
+	require.NoError(t, d.Validate())
+	assert.Equal(t, []string{"b", "d", "a", "c"}, valuesOf(d))
+}
+
+func TestLoadZeroValue(t *testing.T) {
+	var s SkipList[int, string]
+	s.Load([]Pair[int, string]{{2, "a"}, {1, "b"}})
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []string{"b", "a"}, valuesOf(&s))
+
+	var sorted SkipList[int, string]
+	require.NoError(t, sorted.LoadSorted([]Pair[int, string]{{1, "b"}, {2, "a"}}))
+	require.NoError(t, sorted.Validate())
+	assert.Equal(t, 2, sorted.Size())
+}
+
+func TestLoadKeyBounds(t *testing.T) {
+	input := []Pair[int, string]{{5, "a"}, {1, "b"}, {3, "c"}, {9, "d"}}
+	s := NewSkipList[int, string](WithKeyBounds[int, string](2, 6))
+	s.Load(input)
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []string{"c", "a"}, valuesOf(s))
+	assert.Equal(t, 1, input[1].Key)
+
+	err := s.LoadSorted([]Pair[int, string]{{3, "x"}, {7, "y"}})
+	assert.ErrorIs(t, err, ErrKeyOutOfBounds)
+	assert.Equal(t, []string{"c", "a"}, valuesOf(s))
+
+	_, err = NewFromSorted([]Pair[int, string]{{1, "x"}}, WithKeyBounds[int, string](2, 6))
+	assert.ErrorIs(t, err, ErrKeyOutOfBounds)
+}
+
+func TestLoadAdmission(t *testing.T) {
+	banned := errors.New("banned")
+	var sizes []int
+	admit := WithAdmissionControl(func(key int, _ []int, size int) error {
+		sizes = append(sizes, size)
+		if key == 2 {
+			return banned
+		}
+		return nil
+	})
+	copier := WithValueCopier[int, []int](func(v []int) []int { return slices.Clone(v) })
+	s := NewSkipList[int, []int](admit, copier)
+	value := []int{1}
+	s.Load([]Pair[int, []int]{{3, value}, {2, value}, {1, value}})
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{1, 3}, slices.Collect(s.Keys()))
+	assert.Equal(t, []int{0, 1, 1}, sizes)
+	value[0] = 2
+	assert.Equal(t, [][]int{{1}, {1}}, valuesOf(s))
+
+	err := s.LoadSorted([]Pair[int, []int]{{1, value}, {2, value}})
+	assert.ErrorIs(t, err, banned)
+	assert.Equal(t, []int{1, 3}, slices.Collect(s.Keys()))
+	_, err = NewFromSorted([]Pair[int, []int]{{2, value}}, admit)
+	assert.ErrorIs(t, err, banned)
+}
+
+func valuesOf[K cmp.Ordered, V any](s *SkipList[K, V]) []V {
+	var v []V
+	for x := s.First(); x != nil; x = x.Next() {
+		v = append(v, x.Value)
+	}
+	return v
+}
@@ -0,0 +1,88 @@
+package skiplist
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLoadPanicked is returned to the callers of GetOrLoad waiting for a load which panicked.
+var ErrLoadPanicked = errors.New("skiplist: load panicked")
+
+// loadCall is a running load of a missing key shared by all callers of GetOrLoad waiting for it.
+type loadCall[V any] struct {
+	done     chan struct{} // closed when the load finished
+	value    V
+	err      error
+	canceled bool // the load failed after the context of its caller was done
+}
+
+// GetOrLoad returns the value of `key`. If the key is missing, it is loaded by `load` (e.g. from a database) and
+// added to the list, unless the key was set meanwhile. Concurrent misses of the same key are coalesced: only
+// the first caller runs load with its context, the others wait for its result (or until their own ctx is done)
+// and receive the same value or error. If the load fails after the context of the first caller is done, the
+// waiters whose context is not done load again. Errors are not cached, so the next miss loads again. A loaded
+// value rejected by the admission control (see WithAdmissionControl) is not added, and the error is returned.
+// load is called without holding the lock of the list.
+func (c *ConcurrentSkipList[K, V]) GetOrLoad(ctx context.Context, key K,
+	load func(ctx context.Context, key K) (V, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+	c.loadMu.Lock()
+	if call, ok := c.loads[key]; ok {
+		c.loadMu.Unlock()
+		select {
+		case <-call.done:
+			if call.canceled && ctx.Err() == nil {
+				return c.GetOrLoad(ctx, key, load)
+			}
+			return call.value, call.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+	call := &loadCall[V]{done: make(chan struct{})}
+	if c.loads == nil {
+		c.loads = map[K]*loadCall[V]{}
+	}
+	c.loads[key] = call
+	c.loadMu.Unlock()
+
+	finished := false
+	defer func() {
+		if !finished {
+			// load panicked: release the waiters before the panic continues
+			call.err = ErrLoadPanicked
+		}
+		c.loadMu.Lock()
+		delete(c.loads, key)
+		c.loadMu.Unlock()
+		close(call.done)
+	}()
+	call.value, call.err = load(ctx, key)
+	finished = true
+	if call.err == nil {
+		call.value, call.err = c.setIfAbsent(key, call.value)
+	} else {
+		call.canceled = ctx.Err() != nil
+	}
+	return call.value, call.err
+}
+
+// setIfAbsent adds the key with `value` if it is missing and returns the value of the key afterwards or the
+// error of the admission control.
+func (c *ConcurrentSkipList[K, V]) setIfAbsent(key K, value V) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if current, ok := c.list.GetCopy(key); ok {
+		return current, nil
+	}
+	_, _, created, err := c.list.TrySet(key, value)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	c.modified(created)
+	return value, nil
+}
@@ -0,0 +1,129 @@
+package skiplist
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrLoad(t *testing.T) {
+	c := NewConcurrentSkipList[int, string]()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context, key int) (string, error) {
+		calls.Add(1)
+		<-release
+		return "loaded", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad(context.Background(), 1, load)
+			assert.NoError(t, err)
+			results[i] = v
+		}()
+	}
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+	for _, v := range results {
+		assert.Equal(t, "loaded", v)
+	}
+	assert.Equal(t, 1, c.Size(Consistent))
+
+	v, err := c.GetOrLoad(context.Background(), 1, load)
+	require.NoError(t, err)
+	assert.Equal(t, "loaded", v)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestGetOrLoadErrors(t *testing.T) {
+	c := NewConcurrentSkipList[int, string]()
+	errDB := errors.New("db down")
+	_, err := c.GetOrLoad(context.Background(), 1, func(context.Context, int) (string, error) {
+		return "", errDB
+	})
+	assert.ErrorIs(t, err, errDB)
+	assert.Equal(t, 0, c.Size(Consistent))
+
+	// the key set during the load wins
+	v, err := c.GetOrLoad(context.Background(), 2, func(context.Context, int) (string, error) {
+		c.Set(2, "fresh")
+		return "stale", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", v)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		defer func() { _ = recover() }()
+		_, _ = c.GetOrLoad(context.Background(), 3, func(context.Context, int) (string, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.GetOrLoad(ctx, 3, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	done := make(chan error)
+	go func() {
+		_, err := c.GetOrLoad(context.Background(), 3, nil)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	assert.ErrorIs(t, <-done, ErrLoadPanicked)
+
+	errFull := errors.New("full")
+	a := NewConcurrentSkipList[int, string](WithAdmissionControl(func(int, string, int) error { return errFull }))
+	_, err = a.GetOrLoad(context.Background(), 1, func(context.Context, int) (string, error) { return "v", nil })
+	assert.ErrorIs(t, err, errFull)
+	assert.Equal(t, 0, a.Size(Consistent))
+}
+
+func TestGetOrLoadLeaderCanceled(t *testing.T) {
+	c := NewConcurrentSkipList[int, string]()
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	leader := make(chan error)
+	go func() {
+		_, err := c.GetOrLoad(ctx, 1, func(ctx context.Context, _ int) (string, error) {
+			close(started)
+			<-ctx.Done()
+			return "", ctx.Err()
+		})
+		leader <- err
+	}()
+	<-started
+	waiter := make(chan string)
+	go func() {
+		v, err := c.GetOrLoad(context.Background(), 1, func(context.Context, int) (string, error) {
+			return "loaded", nil
+		})
+		assert.NoError(t, err)
+		waiter <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-leader, context.Canceled)
+	// the waiter with a live context loads itself
+	assert.Equal(t, "loaded", <-waiter)
+	assert.Equal(t, 1, c.Size(Consistent))
+}
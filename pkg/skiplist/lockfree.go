@@ -0,0 +1,255 @@
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// LockFreeSkipList is a skip list for many concurrent writers without a global lock, following the lock-free
+// skip list of Herlihy and Shavit (based on Fraser): nodes are linked by atomically replaced links, and a
+// removal first marks the links of the node on all levels before it is unlinked by the next traversal. All
+// methods may be called concurrently; readers never wait.
+//
+// Unlike SkipList, it has no positions and no per-list policies: only the options WithProbability,
+// WithMaxLevel, and level functions safe for concurrent use apply (WithSeed and WithCryptoSeed are not).
+type LockFreeSkipList[K cmp.Ordered, V any] struct {
+	config
+	head  *lfNode[K, V]
+	count atomic.Int64
+	level atomic.Int32 // highest level of all nodes ever inserted, searches start there
+	paths sync.Pool    // of *lfPath
+}
+
+// lfNode is a node of a LockFreeSkipList.
+type lfNode[K cmp.Ordered, V any] struct {
+	key   K
+	value atomic.Pointer[V] // nil once the node was removed (see Remove)
+	next  []atomic.Pointer[lfLink[K, V]]
+}
+
+// lfPath holds the rightmost nodes before a key and their successors on each level (see find). The paths are
+// reused, so Set and Remove don't allocate them.
+type lfPath[K cmp.Ordered, V any] struct {
+	preds, succs []*lfNode[K, V]
+}
+
+// getPath returns an unused path, which must be returned by putPath.
+func (s *LockFreeSkipList[K, V]) getPath() *lfPath[K, V] {
+	return s.paths.Get().(*lfPath[K, V])
+}
+
+// putPath clears the path p, so it does not keep removed nodes alive, and returns it for reuse.
+func (s *LockFreeSkipList[K, V]) putPath(p *lfPath[K, V]) {
+	top := int(s.level.Load())
+	clear(p.preds[:top])
+	clear(p.succs[:top])
+	s.paths.Put(p)
+}
+
+// lfLink is an immutable link to the next node on one level. A marked link belongs to a removed node.
+type lfLink[K cmp.Ordered, V any] struct {
+	node   *lfNode[K, V] // nil at the end of the level
+	marked bool
+}
+
+// NewLockFreeSkipList creates a new empty LockFreeSkipList object.
+func NewLockFreeSkipList[K cmp.Ordered, V any](options ...Option) *LockFreeSkipList[K, V] {
+	s := &LockFreeSkipList[K, V]{
+		config: config{
+			p:         DefaultProbability,
+			maxLevel:  DefaultMaxLevel,
+			levelFunc: defaultLevelFunc,
+		},
+	}
+	for _, opt := range options {
+		opt(&s.config)
+	}
+	if len(s.typed) > 0 {
+		log.Panicf("Typed options are not applicable to %T", s)
+	}
+	s.head = newLFNode[K, V](*new(K), s.maxLevel)
+	s.paths.New = func() any {
+		return &lfPath[K, V]{preds: make([]*lfNode[K, V], s.maxLevel), succs: make([]*lfNode[K, V], s.maxLevel)}
+	}
+	return s
+}
+
+func newLFNode[K cmp.Ordered, V any](key K, level int) *lfNode[K, V] {
+	x := &lfNode[K, V]{key: key, next: make([]atomic.Pointer[lfLink[K, V]], level)}
+	for i := range x.next {
+		x.next[i].Store(&lfLink[K, V]{})
+	}
+	return x
+}
+
+// Size returns the number of elements. With concurrent modifications it may be outdated when it is returned.
+func (s *LockFreeSkipList[K, V]) Size() int {
+	return int(s.count.Load())
+}
+
+// Get returns the value of `key` and true or the zero value and false if the key was not found. It does not
+// modify the list and never waits.
+func (s *LockFreeSkipList[K, V]) Get(key K) (V, bool) {
+	x := s.head
+	var curr *lfNode[K, V]
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		curr = x.next[i].Load().node
+		for curr != nil {
+			link := curr.next[i].Load()
+			if link.marked {
+				// skip removed nodes
+				curr = link.node
+				continue
+			}
+			if !cmp.Less(curr.key, key) {
+				break
+			}
+			x = curr
+			curr = link.node
+		}
+	}
+	if curr != nil && curr.key == key {
+		if v := curr.value.Load(); v != nil && !curr.next[0].Load().marked {
+			return *v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Set sets the value of `key`. Returns true if the key was added and false if its value was replaced.
+func (s *LockFreeSkipList[K, V]) Set(key K, value V) bool {
+	p := s.getPath()
+	defer s.putPath(p)
+	preds, succs := p.preds, p.succs
+	for {
+		// the level is drawn before the search, so the search fills the path on all levels of the new node
+		level := s.levelFunc(s.p, s.maxLevel)
+		for top := s.level.Load(); int(top) < level && !s.level.CompareAndSwap(top, int32(level)); {
+			top = s.level.Load()
+		}
+		if s.find(key, preds, succs) {
+			// a concurrent Remove takes the value of the node after marking it: if it was taken before this
+			// value was stored, the value would be lost with the node, so the key is inserted again
+			if succs[0].value.Swap(&value) != nil {
+				return false
+			}
+			continue
+		}
+		x := newLFNode[K, V](key, level)
+		x.value.Store(&value)
+		for i := 0; i < level; i++ {
+			x.next[i].Store(&lfLink[K, V]{node: succs[i]})
+		}
+		// the node is inserted once it is linked on level 0
+		if !casLink(&preds[0].next[0], succs[0], x) {
+			continue
+		}
+		s.count.Add(1)
+		for i := 1; i < level; i++ {
+			for !casLink(&preds[i].next[i], succs[i], x) {
+				s.find(key, preds, succs)
+				link := x.next[i].Load()
+				if link.marked {
+					// removed meanwhile: stop linking the upper levels
+					return true
+				}
+				if link.node != succs[i] && !x.next[i].CompareAndSwap(link, &lfLink[K, V]{node: succs[i]}) {
+					return true
+				}
+			}
+		}
+		return true
+	}
+}
+
+// Remove removes `key` and returns its value and true or the zero value and false if the key was not found.
+func (s *LockFreeSkipList[K, V]) Remove(key K) (V, bool) {
+	p := s.getPath()
+	defer s.putPath(p)
+	preds, succs := p.preds, p.succs
+	var zero V
+	if !s.find(key, preds, succs) {
+		return zero, false
+	}
+	x := succs[0]
+	for i := len(x.next) - 1; i >= 1; i-- {
+		for link := x.next[i].Load(); !link.marked; link = x.next[i].Load() {
+			x.next[i].CompareAndSwap(link, &lfLink[K, V]{node: link.node, marked: true})
+		}
+	}
+	for {
+		link := x.next[0].Load()
+		if link.marked {
+			// removed by another goroutine
+			return zero, false
+		}
+		if x.next[0].CompareAndSwap(link, &lfLink[K, V]{node: link.node, marked: true}) {
+			s.count.Add(-1)
+			// take the value, so a concurrent Set storing its value afterwards notices the removal
+			v := x.value.Swap(nil)
+			// unlink the node physically
+			s.find(key, preds, succs)
+			return *v, true
+		}
+	}
+}
+
+// All returns an iterator over the elements in ascending order. Concurrent modifications may or may not be
+// observed.
+func (s *LockFreeSkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.head.next[0].Load().node; x != nil; {
+			link := x.next[0].Load()
+			if v := x.value.Load(); v != nil && !link.marked && !yield(x.key, *v) {
+				return
+			}
+			x = link.node
+		}
+	}
+}
+
+// find searches `key` and stores the rightmost node before the key and its successor on each level below the
+// top level in preds and succs. Marked nodes passed on the way are unlinked. Returns true if an unmarked node
+// with the key was found.
+func (s *LockFreeSkipList[K, V]) find(key K, preds, succs []*lfNode[K, V]) bool {
+retry:
+	for {
+		pred := s.head
+		var curr *lfNode[K, V]
+		for i := int(s.level.Load()) - 1; i >= 0; i-- {
+			curr = pred.next[i].Load().node
+			for curr != nil {
+				link := curr.next[i].Load()
+				if link.marked {
+					if !casLink(&pred.next[i], curr, link.node) {
+						continue retry
+					}
+					curr = link.node
+					continue
+				}
+				if !cmp.Less(curr.key, key) {
+					break
+				}
+				pred = curr
+				curr = link.node
+			}
+			preds[i] = pred
+			succs[i] = curr
+		}
+		return curr != nil && curr.key == key
+	}
+}
+
+// casLink replaces the unmarked link to `old` by a link to `new`. Returns false if the link changed or was
+// marked meanwhile.
+func casLink[K cmp.Ordered, V any](p *atomic.Pointer[lfLink[K, V]], old, new *lfNode[K, V]) bool {
+	link := p.Load()
+	if link.marked || link.node != old {
+		return false
+	}
+	return p.CompareAndSwap(link, &lfLink[K, V]{node: new})
+}
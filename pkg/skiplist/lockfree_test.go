@@ -0,0 +1,115 @@
+package skiplist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFreeSkipList(t *testing.T) {
+	s := NewLockFreeSkipList[int, string]()
+	assert.True(t, s.Set(2, "b"))
+	assert.True(t, s.Set(1, "a"))
+	assert.False(t, s.Set(2, "B"))
+	v, ok := s.Get(2)
+	require.True(t, ok)
+	assert.Equal(t, "B", v)
+	_, ok = s.Get(3)
+	assert.False(t, ok)
+	v, ok = s.Remove(1)
+	require.True(t, ok)
+	assert.Equal(t, "a", v)
+	_, ok = s.Remove(1)
+	assert.False(t, ok)
+	assert.Equal(t, 1, s.Size())
+	assert.Panics(t, func() { NewLockFreeSkipList[int, int](WithValueCopier[int, int](nil)) })
+}
+
+func TestLockFreeSkipListConcurrent(t *testing.T) {
+	s := NewLockFreeSkipList[int, int](WithMaxLevel(8))
+	const writers = 8
+	const n = 2000
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for k := w; k < n; k += writers {
+				s.Set(k, k)
+				s.Set(k+n, k)
+			}
+			// every writer removes its upper keys and competes with the others for a few shared ones
+			for k := w; k < n; k += writers {
+				s.Remove(k + n)
+				s.Remove((k+1)%writers + n)
+			}
+		}(w)
+	}
+	for r := 0; r < 2; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				var keys []int
+				for k := range s.All() {
+					keys = append(keys, k)
+				}
+				assert.True(t, slices.IsSorted(keys))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, n, s.Size())
+	var keys []int
+	for k, v := range s.All() {
+		assert.Equal(t, k, v)
+		keys = append(keys, k)
+	}
+	require.Len(t, keys, n)
+	for k := 0; k < n; k++ {
+		assert.Equal(t, k, keys[k])
+		_, ok := s.Get(k + n)
+		assert.False(t, ok)
+	}
+}
+
+func TestLockFreeSkipListLevel(t *testing.T) {
+	s := NewLockFreeSkipList[int, int](WithLevelFunc(func(float64, int) int { return 3 }))
+	assert.Equal(t, int32(0), s.level.Load())
+	s.Set(1, 1)
+	s.Set(2, 2)
+	assert.Equal(t, int32(3), s.level.Load())
+	v, ok := s.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	p := s.getPath()
+	s.find(2, p.preds, p.succs)
+	assert.Equal(t, 2, p.succs[0].key)
+	s.putPath(p)
+	assert.Nil(t, p.succs[0])
+}
+
+func TestLockFreeSkipListSetRacingRemove(t *testing.T) {
+	s := NewLockFreeSkipList[int, int]()
+	for i := 0; i < 1000; i++ {
+		s.Set(0, 0)
+		removed := make(chan int)
+		go func() {
+			v, _ := s.Remove(0)
+			removed <- v
+		}()
+		s.Set(0, 1)
+		// the value set is either visible or was returned by Remove, but never lost
+		if v, ok := s.Get(0); ok {
+			assert.Equal(t, 1, v)
+			<-removed
+		} else {
+			assert.Equal(t, 1, <-removed)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package skiplist
+
+import (
+	"cmp"
+	"context"
+	"log"
+	"time"
+)
+
+// maintenanceChunk is the number of elements processed between two checks of the deadline of a maintenance tick.
+const maintenanceChunk = 64
+
+// MaintenanceTask performs a part of additional deferred work on the list until about the deadline and returns
+// true if work remains (see ConcurrentSkipList.Maintenance).
+type MaintenanceTask[K cmp.Ordered, V any] func(s *SkipList[K, V], deadline time.Time) bool
+
+// Maintenance runs the deferred work of the list in ticks every `interval` until ctx is done, and returns
+// ctx.Err(). Each tick holds the write lock for at most about budgetPerTick, so the work shares one predictable
+// schedule instead of delaying single operations. A tick performs in this order while its budget lasts:
+//   - the copy of the remaining nodes of a background rebuild (see RebuildInBackground) and its cutover, which
+//     replays the modifications journaled meanwhile,
+//   - the removal of expired elements (see WithRetention) in batches,
+//   - the purging of soft deleted elements (see MarkDeleted), resumed behind the last purged key by the next
+//     tick,
+//   - the given tasks in their order.
+//
+// If work remains at the end of a tick, the next tick starts after a pause of budgetPerTick instead of interval,
+// so other goroutines get the lock at least half of the time.
+func (c *ConcurrentSkipList[K, V]) Maintenance(ctx context.Context, interval, budgetPerTick time.Duration,
+	tasks ...MaintenanceTask[K, V]) error {
+	if interval <= 0 || budgetPerTick <= 0 {
+		log.Panic("Parameters interval and budgetPerTick out of range (must be > 0)")
+	}
+	m := maintenance[K, V]{tasks: tasks}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		c.mu.Lock()
+		more := m.tick(c.list, time.Now().Add(budgetPerTick))
+		c.size.Store(int64(c.list.Size()))
+		c.mu.Unlock()
+		if more {
+			timer.Reset(budgetPerTick)
+		} else {
+			timer.Reset(interval)
+		}
+	}
+}
+
+// maintenance holds the state of the maintenance runner between its ticks.
+type maintenance[K cmp.Ordered, V any] struct {
+	tasks     []MaintenanceTask[K, V]
+	purgeFrom K    // key to resume purging at
+	purging   bool // purgeFrom is valid
+}
+
+// tick performs maintenance work on s until about the deadline and returns true if work remains.
+func (m *maintenance[K, V]) tick(s *SkipList[K, V], deadline time.Time) bool {
+	s.lazyInit()
+	if s.rebuild != nil && m.rebuild(s, deadline) {
+		return true
+	}
+	if s.retention != nil && m.prune(s, deadline) {
+		return true
+	}
+	if s.deleted > 0 && m.purge(s, deadline) {
+		return true
+	}
+	more := false
+	for _, task := range m.tasks {
+		if time.Now().After(deadline) {
+			return true
+		}
+		if task(s, deadline) {
+			more = true
+		}
+	}
+	return more
+}
+
+// rebuild copies the nodes of the running rebuild of s until the deadline. Returns true if nodes remain.
+func (m *maintenance[K, V]) rebuild(s *SkipList[K, V], deadline time.Time) bool {
+	for r := s.rebuild; s.rebuild == r; {
+		r.step()
+		if s.rebuild == r && time.Now().After(deadline) {
+			return true
+		}
+	}
+	return false
+}
+
+// prune removes expired elements in batches until the deadline. Returns true if expired elements may remain.
+func (m *maintenance[K, V]) prune(s *SkipList[K, V], deadline time.Time) bool {
+	batch := s.pruneBatch
+	defer func() { s.pruneBatch = batch }()
+	s.pruneBatch = maintenanceChunk
+	for s.prune() == maintenanceChunk {
+		if time.Now().After(deadline) {
+			return true
+		}
+	}
+	return false
+}
+
+// purge removes soft deleted elements starting behind the last purged key until the deadline. Returns true if
+// elements remain to be checked.
+func (m *maintenance[K, V]) purge(s *SkipList[K, V], deadline time.Time) bool {
+	x, pos := s.First(), 0
+	if m.purging {
+		x, pos = s.lowerBound(m.purgeFrom)
+	}
+	for n := 1; x != nil; n++ {
+		if x.deleted {
+			s.RemoveByPos(pos)
+			// the removal may have copied shared nodes or completed a rebuild
+			x = s.GetByPos(pos)
+		} else {
+			x = x.Next()
+			pos++
+		}
+		if x != nil && n%maintenanceChunk == 0 && time.Now().After(deadline) {
+			m.purgeFrom, m.purging = x.key, true
+			return true
+		}
+	}
+	m.purging = false
+	return false
+}
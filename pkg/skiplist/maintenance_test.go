@@ -0,0 +1,73 @@
+package skiplist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceTick(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for i := 0; i < 1000; i++ {
+		s.Set(i, i)
+		if i%2 == 0 {
+			s.MarkDeleted(i)
+		}
+	}
+	m := maintenance[int, int]{}
+	// an expired deadline still allows one chunk of work
+	assert.True(t, m.tick(s, time.Now().Add(-time.Second)))
+	assert.Equal(t, 500-maintenanceChunk/2, s.deleted)
+	assert.True(t, m.purging)
+	assert.False(t, m.tick(s, time.Now().Add(time.Minute)))
+	assert.Equal(t, 500, s.Size())
+	assert.Equal(t, 0, s.deleted)
+	require.NoError(t, s.Validate())
+
+	calls := 0
+	m.tasks = []MaintenanceTask[int, int]{func(s *SkipList[int, int], deadline time.Time) bool {
+		calls++
+		return calls < 2
+	}}
+	assert.True(t, m.tick(s, time.Now().Add(time.Minute)))
+	assert.False(t, m.tick(s, time.Now().Add(time.Minute)))
+}
+
+func TestMaintenanceRebuild(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for i := 0; i < 1000; i++ {
+		s.Set(i, i)
+	}
+	r := s.RebuildInBackground(context.Background())
+	m := maintenance[int, int]{}
+	assert.True(t, m.tick(s, time.Now().Add(-time.Second)))
+	assert.Equal(t, rebuildStep-1, r.cursor)
+	assert.False(t, m.tick(s, time.Now().Add(time.Minute)))
+	assert.Nil(t, s.rebuild)
+	require.NoError(t, r.Wait())
+	require.NoError(t, s.Validate())
+}
+
+func TestMaintenanceRetention(t *testing.T) {
+	now := time.Unix(1000, 0)
+	clock := func() time.Time { return now }
+	keyTime := func(key int) time.Time { return time.Unix(int64(key), 0) }
+	c := NewConcurrentSkipList[int, int](WithRetention[int, int](time.Hour, clock, keyTime))
+	for i := 0; i < 300; i++ {
+		c.Set(i+1000, i)
+	}
+	now = now.Add(time.Hour + 200*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- c.Maintenance(ctx, time.Millisecond, time.Millisecond)
+	}()
+	assert.Eventually(t, func() bool { return c.Size(Consistent) == 100 }, time.Second, time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Panics(t, func() { _ = c.Maintenance(ctx, 0, time.Millisecond) })
+}
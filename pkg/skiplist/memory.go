@@ -0,0 +1,233 @@
+package skiplist
+
+import (
+	"cmp"
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// pressureRatio is the fraction of the memory budget at which the pressure callback is invoked.
+const pressureRatio = 0.9
+
+// cgroupLimitFiles are the files containing the memory limit of cgroup v2 and v1.
+var cgroupLimitFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// CgroupMemoryLimit returns the memory limit in bytes of the cgroup the process is running in (e.g. a
+// container). The bool value is false if there is no limit or it cannot be read. The limit can be used
+// to derive a budget for WithMemoryBudget.
+func CgroupMemoryLimit() (int, bool) {
+	for _, file := range cgroupLimitFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		// cgroup v1 reports a huge number instead of "max" for unlimited memory
+		if err != nil || limit <= 0 || limit >= 1<<62 {
+			return 0, false
+		}
+		return int(limit), true
+	}
+	return 0, false
+}
+
+// WithMemoryBudget limits the memory of the skip list to about `bytes` as estimated by
+// SkipList.EstimatedMemory(). When an insert brings the estimate above 90% of the budget, onPressure is called
+// and may remove elements, e.g. EvictSmallest or EvictLargest. Modifications within onPressure do not
+// invoke it again. Node references returned by the insert may have been evicted by onPressure.
+// The type parameters are inferred from `onPressure`.
+func WithMemoryBudget[K cmp.Ordered, V any](bytes int, onPressure func(s *SkipList[K, V])) Option {
+	if bytes <= 0 {
+		log.Panic("Parameter bytes out of range (must be > 0)")
+	}
+	return typedOption(func(s *SkipList[K, V]) {
+		s.memBudget = bytes
+		s.onPressure = onPressure
+	})
+}
+
+// EstimatedMemory estimates the memory used by the skip list in bytes from the number of elements, the sizes
+// of the key and value types, and the expected number of levels per node 1/(1-p). Memory referenced by keys
+// and values (e.g. string contents) is not included.
+func (s *SkipList[K, V]) EstimatedMemory() int {
+	s.lazyInit()
+	var node Node[K, V]
+	perLevel := float64(unsafe.Sizeof(node.next[0]) + unsafe.Sizeof(node.dist[0]))
+	perNode := float64(unsafe.Sizeof(node)) + perLevel/(1-s.p)
+	head := float64(unsafe.Sizeof(*s)+unsafe.Sizeof(node)) + perLevel*float64(cap(s.head.next))
+	return int(head + perNode*float64(s.count))
+}
+
+// WithSizer registers a function returning the bytes referenced by a key and a value beyond their type sizes,
+// e.g. the length of string contents. The sizes are included by SkipList.MemoryUsage().
+// The type parameters are inferred from `sizer`.
+func WithSizer[K cmp.Ordered, V any](sizer func(key K, value V) int) Option {
+	return typedOption(func(s *SkipList[K, V]) {
+		s.sizer = sizer
+	})
+}
+
+// MemoryUsage computes the memory used by the skip list in bytes by walking all nodes and summing up the node
+// structs and the allocated capacities of their level slices, plus the sizes reported by the sizer (see
+// WithSizer). Unlike EstimatedMemory it reflects the actual level distribution, but it costs O(n). Rounding
+// by the allocator to size classes is not included.
+func (s *SkipList[K, V]) MemoryUsage() int {
+	s.lazyInit()
+	bytes := int(unsafe.Sizeof(*s)) + nodeMemory(s.head)
+	for x := s.First(); x != nil; x = x.Next() {
+		bytes += s.elementMemory(x)
+	}
+	return bytes
+}
+
+// nodeMemory returns the size of the node struct x and the allocated capacities of its level slices.
+func nodeMemory[K cmp.Ordered, V any](x *Node[K, V]) int {
+	return int(unsafe.Sizeof(*x)) + cap(x.next)*int(unsafe.Sizeof(x.next[0])) + cap(x.dist)*int(unsafe.Sizeof(x.dist[0]))
+}
+
+// elementMemory returns the memory of the element x counted by MemoryUsage.
+func (s *SkipList[K, V]) elementMemory(x *Node[K, V]) int {
+	bytes := nodeMemory(x)
+	if s.sizer != nil {
+		bytes += s.sizer(x.key, x.Value)
+	}
+	return bytes
+}
+
+// MemoryBudget returns the budget configured by WithMemoryBudget or 0 if there is none.
+func (s *SkipList[K, V]) MemoryBudget() int {
+	return s.memBudget
+}
+
+func (s *SkipList[K, V]) checkMemory() {
+	if s.memBudget == 0 || s.inPressure || s.onPressure == nil {
+		return
+	}
+	if float64(s.EstimatedMemory()) < pressureRatio*float64(s.memBudget) {
+		return
+	}
+	s.inPressure = true
+	defer func() { s.inPressure = false }()
+	s.onPressure(s)
+}
+
+// EvictSmallest is a pressure callback for WithMemoryBudget removing the smallest keys until the estimated
+// memory is below 90% of the budget. With WithDescending the smallest keys are removed from the end of the
+// list. An EventTrim with the number of evicted elements is emitted.
+func EvictSmallest[K cmp.Ordered, V any](s *SkipList[K, V]) {
+	s.evictWhileOverBudget(func() { s.removeSmallest() })
+}
+
+// EvictLargest is like EvictSmallest but removes the largest keys.
+func EvictLargest[K cmp.Ordered, V any](s *SkipList[K, V]) {
+	s.evictWhileOverBudget(func() { s.removeLargest() })
+}
+
+// removeSmallest removes the node with the smallest key, which is the last one with WithDescending.
+func (s *SkipList[K, V]) removeSmallest() *Node[K, V] {
+	if s.descending {
+		return s.RemoveByPos(s.count - 1)
+	}
+	return s.RemoveByPos(0)
+}
+
+// removeLargest removes the node with the largest key, which is the first one with WithDescending.
+func (s *SkipList[K, V]) removeLargest() *Node[K, V] {
+	if s.descending {
+		return s.RemoveByPos(0)
+	}
+	return s.RemoveByPos(s.count - 1)
+}
+
+func (s *SkipList[K, V]) evictWhileOverBudget(evict func()) {
+	end := s.trace(context.Background(), "Trim", s.count)
+	n := 0
+	for s.count > 0 && float64(s.EstimatedMemory()) >= pressureRatio*float64(s.memBudget) {
+		evict()
+		n++
+	}
+	if n > 0 {
+		s.emit(Event{Type: EventTrim, Level: s.Level(), Count: n})
+	}
+	end(n)
+}
+
+// Reserve prepares the skip list for n more inserts, e.g. before a load phase of known size, so that they do
+// not allocate beyond the growth of their keys and values:
+//   - the nodes and their links are preallocated in one block; an allocator with a method Reserve(n int) (like
+//     ArenaAllocator) is asked to do so, other allocators (see WithAllocator) allocate every node themselves,
+//   - the update paths searched by inserts are allocated once and reused,
+//   - the index of the stable IDs (see WithStableIDs) is sized for them.
+//
+// Reserved nodes beyond the average of 2 links per node allocate their links. As the nodes of a block are
+// released together, the block is kept alive until all nodes allocated from it are dropped. Init ends the
+// reservation; snapshots (see Snapshot) do not share it.
+func (s *SkipList[K, V]) Reserve(n int) {
+	if n <= 0 {
+		return
+	}
+	s.lazyInit()
+	if s.paths == nil {
+		s.paths = &searchPaths[K, V]{
+			update:    make([]*Node[K, V], 0, s.maxLevel),
+			updatePos: make([]int, 0, s.maxLevel),
+		}
+	}
+	if s.stableIDs {
+		ids := make(map[uint64]*Node[K, V], len(s.ids)+n)
+		for id, x := range s.ids {
+			ids[id] = x
+		}
+		s.ids = ids
+	}
+	if s.allocator == nil {
+		if s.reserved == nil {
+			s.reserved = NewArenaAllocator[K, V](1)
+		}
+		s.reserved.Reserve(n)
+	} else if r, ok := s.allocator.(interface{ Reserve(n int) }); ok {
+		r.Reserve(n)
+	}
+}
+
+// reservedNode creates a node from the nodes reserved for the heap allocator and ends the reservation with
+// the last one.
+func (s *SkipList[K, V]) reservedNode(key K, value V, level int, capacity int) *Node[K, V] {
+	x := allocNode[K, V](s.reserved, key, value, level, capacity)
+	if len(s.reserved.nodes) == 0 {
+		s.reserved = nil
+	}
+	return x
+}
+
+// searchPaths holds the rightmost nodes before an inserted key on each level and their positions.
+type searchPaths[K cmp.Ordered, V any] struct {
+	update    []*Node[K, V]
+	updatePos []int
+	taken     bool // in use by an insert
+}
+
+// takePaths returns update paths of the current level and true if they are the ones reserved by Reserve,
+// which must be returned by putPaths. While an insert uses them (e.g. when its event handler inserts), new
+// paths are allocated.
+func (s *SkipList[K, V]) takePaths() ([]*Node[K, V], []int, bool) {
+	if p := s.paths; p != nil && !p.taken {
+		p.taken = true
+		return p.update[:s.Level()], p.updatePos[:s.Level()], true
+	}
+	return make([]*Node[K, V], s.Level(), s.maxLevel), make([]int, s.Level(), s.maxLevel), false
+}
+
+// putPaths returns the reserved update paths. The references to the nodes are cleared, so removed nodes are
+// not kept alive.
+func (s *SkipList[K, V]) putPaths() {
+	clear(s.paths.update[:cap(s.paths.update)])
+	s.paths.taken = false
+}
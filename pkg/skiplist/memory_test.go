@@ -0,0 +1,144 @@
+package skiplist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimatedMemory(t *testing.T) {
+	s := NewSkipList[int, int]()
+	empty := s.EstimatedMemory()
+	assert.Greater(t, empty, 0)
+	for k := 0; k < 100; k++ {
+		s.Set(k, k)
+	}
+	// 8 bytes key, 8 bytes value, 2 slice headers, the ID, the modification time, the padded deleted flag, the insertion sequence number and links, the back link, and 2 levels in the average with 16 bytes each
+	assert.InDelta(t, empty+100*(16+48+8+8+8+24+8+32), s.EstimatedMemory(), 1)
+}
+
+func TestMemoryBudget(t *testing.T) {
+	var trimmed []int
+	budget := NewSkipList[int, int]().EstimatedMemory() + 1000*152
+	s := NewSkipList[int, int](
+		WithMemoryBudget(budget, EvictSmallest[int, int]),
+		WithEventHandler(func(e Event) {
+			if e.Type == EventTrim {
+				trimmed = append(trimmed, e.Count)
+			}
+		}),
+	)
+	for k := 0; k < 2000; k++ {
+		s.Set(k, k)
+		assert.Less(t, s.EstimatedMemory(), s.MemoryBudget())
+	}
+	assert.Less(t, s.Size(), 1000)
+	assert.Greater(t, s.Size(), 800)
+	assert.Equal(t, 1999, s.GetByPos(s.Size()-1).Key())
+	assert.NotEmpty(t, trimmed)
+
+	calls := 0
+	s = NewSkipList[int, int](WithMemoryBudget(budget, func(s *SkipList[int, int]) {
+		calls++
+		EvictLargest(s)
+		s.Set(-1, -1) // does not invoke the callback recursively
+	}))
+	for k := 0; k < 2000; k++ {
+		s.Set(k, k)
+	}
+	assert.Greater(t, calls, 0)
+	x, _ := s.Get(-1)
+	assert.NotNil(t, x)
+	assert.Equal(t, 0, s.GetByPos(1).Key())
+}
+
+func TestCgroupMemoryLimit(t *testing.T) {
+	defer func(files []string) { cgroupLimitFiles = files }(cgroupLimitFiles)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "memory.max")
+	cgroupLimitFiles = []string{filepath.Join(dir, "missing"), file}
+
+	_, ok := CgroupMemoryLimit()
+	assert.False(t, ok)
+
+	assert.NoError(t, os.WriteFile(file, []byte("max\n"), 0o600))
+	_, ok = CgroupMemoryLimit()
+	assert.False(t, ok)
+
+	assert.NoError(t, os.WriteFile(file, []byte("536870912\n"), 0o600))
+	limit, ok := CgroupMemoryLimit()
+	assert.True(t, ok)
+	assert.Equal(t, 512<<20, limit)
+}
+
+func TestMemoryUsage(t *testing.T) {
+	data := []testData{{1, 1, 0}, {2, 3, 1}, {3, 1, 2}}
+	s := NewSkipList[int, string](WithLevelFunc(createPlayBackLevelFunc(data)), WithMaxLevel(4),
+		WithSizer(func(_ int, v string) int { return len(v) }))
+	empty := s.MemoryUsage()
+	// the head has a capacity of the maximum level
+	assert.Equal(t, int(unsafe.Sizeof(*s))+128+4*16, empty)
+	s.Set(1, "a")
+	s.Set(2, "bb")
+	s.Set(3, "ccc")
+	// node structs (128 bytes), 5 levels with 16 bytes each, and 6 bytes of strings
+	assert.Equal(t, empty+3*128+5*16+6, s.MemoryUsage())
+}
+
+func TestReserve(t *testing.T) {
+	a := NewArenaAllocator[int, int](1)
+	s := NewSkipList[int, int](WithAllocator[int, int](a), WithStableIDs(), WithMaxLevel(2))
+	s.Set(-1, 0)
+	s.Reserve(1000)
+	assert.Len(t, a.nodes, 1000)
+	k := 0
+	// the nodes and links come from the reserved blocks, the search paths are reused
+	allocs := testing.AllocsPerRun(500, func() {
+		s.Set(k, k)
+		k++
+	})
+	assert.Zero(t, allocs)
+	assert.Equal(t, 502, s.Size())
+	assert.Equal(t, 0, s.GetByID(2).Key())
+	s.Reserve(0)
+	require.NoError(t, s.Validate())
+}
+
+func TestReserveHeap(t *testing.T) {
+	s := NewSkipList[int, int](WithMaxLevel(2))
+	s.Reserve(1000)
+	k := 0
+	allocs := testing.AllocsPerRun(500, func() {
+		s.Set(k, k)
+		k++
+	})
+	assert.Zero(t, allocs)
+	require.NoError(t, s.Validate())
+
+	// the reservation is not shared with snapshots and ends with Init
+	snap := s.Snapshot()
+	n := snap.Size()
+	assert.Nil(t, snap.paths)
+	assert.Nil(t, snap.reserved)
+	s.Set(-1, 0)
+	assert.Equal(t, n, snap.Size())
+	s.Init(WithMaxLevel(8))
+	assert.Nil(t, s.paths)
+	assert.Nil(t, s.reserved)
+	s.Reserve(10)
+	for k = 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	require.NoError(t, s.Validate())
+
+	// inserts beyond the reservation allocate their nodes again
+	assert.Nil(t, s.reserved)
+	assert.Positive(t, testing.AllocsPerRun(10, func() {
+		s.Set(k, k)
+		k++
+	}))
+}
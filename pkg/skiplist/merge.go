@@ -0,0 +1,110 @@
+package skiplist
+
+import (
+	"errors"
+	"iter"
+)
+
+// mergeProgressInterval is the number of merged pairs between two calls of the progress callback of MergeSorted.
+const mergeProgressInterval = 1024
+
+// MergeSorted merges a stream of pairs sorted by key into the existing elements in one forward pass: every
+// search continues from the search path of the previous key (finger search) instead of starting at the head,
+// so each level of the list is traversed at most once. Existing keys get the new value like Set (without duplicates), new
+// keys are inserted. onProgress (if not nil) is called with the number of merged pairs every 1024 pairs and
+// once at the end. The keys must be strictly ascending (ascending if duplicates are allowed, with the values of
+// equal keys ordered by the tie-break of WithTieBreak, if any); at the first
+// offending pair an *OrderError with its index in the stream is returned. Every pair is checked by the
+// admission control like by TrySet (see WithAdmissionControl), a rejected pair stops the merge, too. The pairs merged before remain merged. seq must not modify the
+// skip list.
+func (s *SkipList[K, V]) MergeSorted(seq iter.Seq2[K, V], onProgress func(done int)) error {
+	s.lazyInit()
+	s.pollRebuild()
+	s.ensureOwned()
+	update := make([]*Node[K, V], s.Level(), s.maxLevel)
+	updatePos := make([]int, s.Level(), s.maxLevel)
+	resetFinger := func() {
+		update = update[:s.Level()]
+		updatePos = updatePos[:s.Level()]
+		for i := range update {
+			update[i] = s.head
+			updatePos[i] = -1
+		}
+	}
+	resetFinger()
+
+	done := 0
+	var last *Node[K, V]
+	var created bool
+	var err error
+	for key, value := range seq {
+		if last, created, err = s.mergeOne(update, updatePos, last, key, value); err != nil {
+			if err == errOutOfOrder {
+				err = &OrderError{Index: done}
+			}
+			break
+		}
+		if len(update) < s.Level() {
+			// insert grew the head within the capacity of update
+			update = update[:s.Level()]
+			updatePos = updatePos[:s.Level()]
+		}
+		if created {
+			v := s.version
+			s.inserted()
+			if s.version != v {
+				// pruning or a memory pressure handler modified the list
+				resetFinger()
+			}
+		}
+		done++
+		if onProgress != nil && done%mergeProgressInterval == 0 {
+			onProgress(done)
+		}
+	}
+	if onProgress != nil && done%mergeProgressInterval != 0 {
+		onProgress(done)
+	}
+	return err
+}
+
+// errOutOfOrder is returned by mergeOne if the key (and value) must not follow the last merged pair.
+var errOutOfOrder = errors.New("out of order")
+
+// mergeOne sets the value of `key` starting the search from the nodes in update, which are advanced to the
+// rightmost nodes before the key. last is the node set by the previous call or nil. Returns the node set and
+// whether it was created.
+func (s *SkipList[K, V]) mergeOne(update []*Node[K, V], updatePos []int, last *Node[K, V], key K,
+	value V) (*Node[K, V], bool, error) {
+	if err := s.admission(key, value); err != nil {
+		return nil, false, err
+	}
+	if last != nil && !s.precedes(last, key, value) {
+		return nil, false, errOutOfOrder
+	}
+	x := s.head
+	pos := -1
+	for i := len(update) - 1; i >= 0; i-- {
+		if updatePos[i] > pos {
+			x, pos = update[i], updatePos[i]
+		}
+		for x.next[i] != nil && s.precedes(x.next[i], key, value) {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		update[i] = x
+		updatePos[i] = pos
+	}
+	s.touched(key)
+	value = s.storeValue(value)
+	if !s.duplicates && len(x.next) > 0 && x.next[0] != nil && x.next[0].key == key {
+		x = x.next[0]
+		x.Value = value
+		s.markDeleted(x, false)
+		if s.modClock != nil {
+			s.stamp(x)
+		}
+		return x, false, nil
+	}
+	return s.insert(update, updatePos, pos, key, value), true, nil
+}
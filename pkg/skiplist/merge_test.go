@@ -0,0 +1,111 @@
+package skiplist
+
+import (
+	"errors"
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pairSeq returns a sequence of the keys with the values key*10.
+func pairSeq(keys ...int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for _, k := range keys {
+			if !yield(k, k*10) {
+				return
+			}
+		}
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	s := NewSkipList[int, int](WithSeed(1))
+	for i := 0; i < 3000; i += 2 {
+		s.Set(i, i)
+	}
+	var keys []int
+	for i := 0; i < 5000; i += 3 {
+		keys = append(keys, i)
+	}
+	var progress []int
+	require.NoError(t, s.MergeSorted(pairSeq(keys...), func(done int) { progress = append(progress, done) }))
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{1024, len(keys)}, progress)
+
+	want := map[int]int{}
+	for i := 0; i < 3000; i += 2 {
+		want[i] = i
+	}
+	for _, k := range keys {
+		want[k] = k * 10
+	}
+	assert.Equal(t, len(want), s.Size())
+	assert.Equal(t, slices.Sorted(maps.Keys(want)), collectKeys(s.Iterator()))
+	for k, v := range want {
+		x, _ := s.Get(k)
+		require.NotNil(t, x)
+		assert.Equal(t, v, x.Value)
+	}
+}
+
+func TestMergeSortedEmpty(t *testing.T) {
+	var s SkipList[int, int]
+	calls := 0
+	require.NoError(t, s.MergeSorted(pairSeq(), func(int) { calls++ }))
+	assert.Equal(t, 0, calls)
+	require.NoError(t, s.MergeSorted(pairSeq(1, 2, 3), nil))
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{1, 2, 3}, collectKeys(s.Iterator()))
+}
+
+func TestMergeSortedErrors(t *testing.T) {
+	s := NewSkipList[int, int]()
+	err := s.MergeSorted(pairSeq(1, 5, 5, 7), nil)
+	var orderErr *OrderError
+	require.ErrorAs(t, err, &orderErr)
+	assert.Equal(t, 2, orderErr.Index)
+	assert.Equal(t, []int{1, 5}, collectKeys(s.Iterator()))
+
+	d := NewSkipList[int, int](WithDuplicates())
+	require.NoError(t, d.MergeSorted(pairSeq(1, 5, 5, 7), nil))
+	require.NoError(t, d.MergeSorted(pairSeq(5), nil))
+	require.NoError(t, d.Validate())
+	assert.Equal(t, []int{1, 5, 5, 5, 7}, collectKeys(d.Iterator()))
+
+	errFull := errors.New("full")
+	a := NewSkipList[int, int](WithAdmissionControl(func(key int, value int, size int) error {
+		if size >= 2 {
+			return errFull
+		}
+		return nil
+	}))
+	assert.ErrorIs(t, a.MergeSorted(pairSeq(1, 2, 3), nil), errFull)
+	assert.Equal(t, 2, a.Size())
+
+	// overwrites are checked, too
+	banned := errors.New("banned")
+	b := NewSkipList[int, int](WithAdmissionControl(func(key int, value int, size int) error {
+		if value < 0 {
+			return banned
+		}
+		return nil
+	}))
+	b.Set(1, 1)
+	assert.ErrorIs(t, b.MergeSorted(func(yield func(int, int) bool) { yield(1, -1) }, nil), banned)
+	x, _ := b.Get(1)
+	assert.Equal(t, 1, x.Value)
+}
+
+func TestMergeSortedInserted(t *testing.T) {
+	calls := 0
+	s := NewSkipList[int, int](WithMemoryBudget(1, func(*SkipList[int, int]) { calls++ }))
+	require.NoError(t, s.MergeSorted(pairSeq(1, 2, 3), nil))
+	assert.Equal(t, 3, calls)
+	// overwrites do not apply the policies of inserts
+	require.NoError(t, s.MergeSorted(pairSeq(1, 2, 4), nil))
+	assert.Equal(t, 4, calls)
+}
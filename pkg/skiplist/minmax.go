@@ -0,0 +1,41 @@
+package skiplist
+
+// Min returns the node with the smallest key (the largest with WithDescending) or nil if the list is empty.
+// It is the first node in O(1).
+func (s *SkipList[K, V]) Min() *Node[K, V] {
+	return s.First()
+}
+
+// Max returns the node with the largest key (the smallest with WithDescending) or nil if the list is empty.
+// The last node is reached by following the last link of every level in O(log(n)). With duplicates the last
+// of the equal keys is returned.
+func (s *SkipList[K, V]) Max() *Node[K, V] {
+	return s.Last()
+}
+
+// Last returns the last node of the list in O(log(n)) or nil if the list is empty. With WithBackPointers the
+// list can be iterated backwards by the Node.Prev() function.
+func (s *SkipList[K, V]) Last() *Node[K, V] {
+	s.lazyInit()
+	x := s.head
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	if x == s.head {
+		return nil
+	}
+	return x
+}
+
+// PopMin removes the node with the smallest key and returns it or nil if the list is empty. Together with
+// PopMax the list serves as a double-ended priority queue.
+func (s *SkipList[K, V]) PopMin() *Node[K, V] {
+	return s.RemoveByPos(0)
+}
+
+// PopMax removes the node with the largest key and returns it or nil if the list is empty.
+func (s *SkipList[K, V]) PopMax() *Node[K, V] {
+	return s.RemoveByPos(s.count - 1)
+}
@@ -0,0 +1,46 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinMax(t *testing.T) {
+	s := NewSkipList[int, int]()
+	assert.Nil(t, s.Min())
+	assert.Nil(t, s.Max())
+	assert.Nil(t, s.PopMin())
+	assert.Nil(t, s.PopMax())
+
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		s.Set(k, k*10)
+	}
+	assert.Equal(t, 1, s.Min().Key())
+	assert.Equal(t, 9, s.Max().Key())
+	assert.Equal(t, 9, s.PopMax().Key())
+	assert.Equal(t, 1, s.PopMin().Key())
+	assert.Equal(t, 7, s.Max().Key())
+	assert.Equal(t, 3, s.Size())
+	require.NoError(t, s.Validate())
+
+	d := NewSkipList[int, int](WithDescending())
+	d.Set(1, 0)
+	d.Set(2, 0)
+	assert.Equal(t, 2, d.Min().Key())
+	assert.Equal(t, 1, d.Max().Key())
+}
+
+func TestConcurrentPopLast(t *testing.T) {
+	c := NewConcurrentSkipList[int, string]()
+	_, _, ok := c.PopLast()
+	assert.False(t, ok)
+	c.Set(1, "a")
+	c.Set(2, "b")
+	key, value, ok := c.PopLast()
+	assert.True(t, ok)
+	assert.Equal(t, 2, key)
+	assert.Equal(t, "b", value)
+	assert.Equal(t, 1, c.Size(Fast))
+}
@@ -0,0 +1,50 @@
+package skiplist
+
+import "time"
+
+// WithModTimes records the time of the last modification of every element, read by Node.ModifiedAt(). Set,
+// MarkDeleted, and Undelete update the time; copies made by Compact, rebuilds, and snapshots keep it, whereas
+// Load stamps all elements. Changes through Node.Value or GetRef are not noticed, and removed elements are
+// gone, so exporters need a separate record of removals. A nil clock uses time.Now.
+func WithModTimes(clock func() time.Time) Option {
+	if clock == nil {
+		clock = time.Now
+	}
+	return func(c *config) {
+		c.modClock = clock
+	}
+}
+
+// ModifiedAt returns the time of the last modification of the node (see WithModTimes) or the zero time if
+// modification times are not recorded.
+func (n *Node[K, V]) ModifiedAt() time.Time {
+	if n.modified == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n.modified)
+}
+
+// ModifiedSince calls fn for all elements modified at or after t (see WithModTimes) in ascending key order
+// until fn returns false. Soft deleted elements are included, since their deletion is a modification. The
+// times are not indexed, so all elements are visited in O(n).
+func (s *SkipList[K, V]) ModifiedSince(t time.Time, fn func(x *Node[K, V]) bool) {
+	since := t.UnixNano()
+	for x := s.First(); x != nil; x = x.Next() {
+		if x.modified != 0 && x.modified >= since && !fn(x) {
+			return
+		}
+	}
+}
+
+// stamp sets the modification time of the node x to now.
+func (s *SkipList[K, V]) stamp(x *Node[K, V]) {
+	x.modified = s.modClock().UnixNano()
+}
+
+// stampAll sets the modification time of all nodes to now.
+func (s *SkipList[K, V]) stampAll() {
+	now := s.modClock().UnixNano()
+	for x := s.First(); x != nil; x = x.Next() {
+		x.modified = now
+	}
+}
@@ -0,0 +1,67 @@
+package skiplist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func modifiedKeys(s *SkipList[int, int], t time.Time) []int {
+	var keys []int
+	s.ModifiedSince(t, func(x *Node[int, int]) bool {
+		keys = append(keys, x.Key())
+		return true
+	})
+	return keys
+}
+
+func TestModTimes(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := NewSkipList[int, int](WithModTimes(func() time.Time { return now }))
+	for k := 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	x, _ := s.Get(3)
+	assert.Equal(t, now, x.ModifiedAt())
+
+	t1 := time.Unix(2000, 0)
+	now = t1
+	s.Set(3, 33)
+	s.Set(20, 20)
+	s.MarkDeleted(5)
+	s.MarkDeleted(5) // no change, no modification
+	assert.Equal(t, []int{3, 5, 20}, modifiedKeys(s, t1))
+	assert.Len(t, modifiedKeys(s, time.Time{}), 11)
+
+	// the times survive copies of the nodes
+	now = time.Unix(3000, 0)
+	snap := s.Snapshot()
+	s.Set(1, 1)
+	c := s.Compact()
+	assert.Equal(t, []int{1, 3, 5, 20}, modifiedKeys(c, t1))
+	assert.Equal(t, []int{3, 5, 20}, modifiedKeys(snap, t1))
+	r := s.RebuildInBackground(context.Background())
+	s.Set(7, 7)
+	require.NoError(t, r.Wait())
+	assert.Equal(t, []int{1, 3, 5, 7, 20}, modifiedKeys(s, t1))
+
+	var keys []int
+	s.ModifiedSince(t1, func(x *Node[int, int]) bool {
+		keys = append(keys, x.Key())
+		return len(keys) < 2
+	})
+	assert.Equal(t, []int{1, 3}, keys)
+
+	x, _, _ = NewSkipList[int, int]().Set(1, 1)
+	assert.True(t, x.ModifiedAt().IsZero())
+}
+
+func TestModTimesLoad(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := NewSkipList[int, int](WithModTimes(func() time.Time { return now }))
+	require.NoError(t, s.LoadSorted([]Pair[int, int]{{1, 1}, {2, 2}}))
+	assert.Equal(t, []int{1, 2}, modifiedKeys(s, now))
+}
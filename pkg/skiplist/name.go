@@ -0,0 +1,14 @@
+package skiplist
+
+// WithName assigns a name to the skip list. The name identifies the list in diagnostics, e.g. in the
+// profiler labels enabled by the build tag skiplist_pprof.
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// Name returns the name assigned by WithName.
+func (s *SkipList[K, V]) Name() string {
+	return s.name
+}
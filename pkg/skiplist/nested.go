@@ -0,0 +1,107 @@
+package skiplist
+
+import "cmp"
+
+// Nested is a two-level index consisting of an outer SkipList with key K1 whose values are inner SkipLists
+// with key K2 (e.g. per-user time ordered events). Inner lists are created on the first insert of an outer
+// key and removed as soon as they become empty.
+type Nested[K1 cmp.Ordered, K2 cmp.Ordered, V any] struct {
+	outer   *SkipList[K1, *SkipList[K2, V]]
+	options []Option
+}
+
+// NewNested creates a new empty Nested index. The options are applied to every inner skip list.
+func NewNested[K1 cmp.Ordered, K2 cmp.Ordered, V any](options ...Option) *Nested[K1, K2, V] {
+	return &Nested[K1, K2, V]{
+		outer:   NewSkipList[K1, *SkipList[K2, V]](),
+		options: options,
+	}
+}
+
+// Size returns the total number of elements within all inner skip lists in O(OuterSize()). It is summed up
+// from the inner lists, so it includes the removals done by the inner lists themselves (e.g. by WithRetention
+// or WithMemoryBudget).
+func (n *Nested[K1, K2, V]) Size() int {
+	size := 0
+	for o := n.outer.First(); o != nil; o = o.Next() {
+		size += o.Value.Size()
+	}
+	return size
+}
+
+// OuterSize returns the number of outer keys.
+func (n *Nested[K1, K2, V]) OuterSize() int {
+	return n.outer.Size()
+}
+
+// Inner returns the inner skip list of the outer key `k1` or nil if there is none.
+// The returned list must not be modified directly.
+func (n *Nested[K1, K2, V]) Inner(k1 K1) *SkipList[K2, V] {
+	x, _ := n.outer.Get(k1)
+	if x == nil {
+		return nil
+	}
+	return x.Value
+}
+
+// Set sets the value of the key pair (k1, k2). The inner skip list is created if necessary.
+// Returns the node within the inner list and true if a new node was created. If the element is rejected by
+// the inner list (see WithAdmissionControl and WithKeyBounds), nil and false are returned and no inner list
+// is created.
+func (n *Nested[K1, K2, V]) Set(k1 K1, k2 K2, value V) (*Node[K2, V], bool) {
+	inner := n.Inner(k1)
+	if inner != nil {
+		x, _, created := inner.Set(k2, value)
+		return x, created
+	}
+	inner = NewSkipList[K2, V](n.options...)
+	x, _, created := inner.Set(k2, value)
+	if x != nil {
+		n.outer.Set(k1, inner)
+	}
+	return x, created
+}
+
+// Get returns the node of the key pair (k1, k2) or nil if it was not found.
+func (n *Nested[K1, K2, V]) Get(k1 K1, k2 K2) *Node[K2, V] {
+	inner := n.Inner(k1)
+	if inner == nil {
+		return nil
+	}
+	x, _ := inner.Get(k2)
+	return x
+}
+
+// Remove removes the key pair (k1, k2). An inner skip list becoming empty is removed as well.
+// Returns the removed node or nil if it was not found.
+func (n *Nested[K1, K2, V]) Remove(k1 K1, k2 K2) *Node[K2, V] {
+	inner := n.Inner(k1)
+	if inner == nil {
+		return nil
+	}
+	x, _ := inner.Remove(k2)
+	if inner.Size() == 0 {
+		n.outer.Remove(k1)
+	}
+	return x
+}
+
+// RemoveOuter removes the outer key `k1` with all its inner elements. Returns the number of removed elements.
+func (n *Nested[K1, K2, V]) RemoveOuter(k1 K1) int {
+	x, _ := n.outer.Remove(k1)
+	if x == nil {
+		return 0
+	}
+	return x.Value.Size()
+}
+
+// ForEach calls fn for all elements ordered by (k1, k2) until fn returns false.
+func (n *Nested[K1, K2, V]) ForEach(fn func(k1 K1, k2 K2, value V) bool) {
+	for o := n.outer.First(); o != nil; o = o.Next() {
+		for x := o.Value.First(); x != nil; x = x.Next() {
+			if !fn(o.key, x.key, o.Value.ValueOf(x)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNested(t *testing.T) {
+	n := NewNested[string, int, string]()
+
+	_, created := n.Set("bob", 2, "b2")
+	assert.True(t, created)
+	n.Set("alice", 3, "a3")
+	n.Set("alice", 1, "a1")
+	_, created = n.Set("alice", 1, "A1")
+	assert.False(t, created)
+	assert.Equal(t, 3, n.Size())
+	assert.Equal(t, 2, n.OuterSize())
+
+	assert.Equal(t, "A1", n.Get("alice", 1).Value)
+	assert.Nil(t, n.Get("alice", 2))
+	assert.Nil(t, n.Get("carol", 1))
+
+	type entry struct {
+		k1 string
+		k2 int
+	}
+	var entries []entry
+	n.ForEach(func(k1 string, k2 int, _ string) bool {
+		entries = append(entries, entry{k1, k2})
+		return true
+	})
+	assert.Equal(t, []entry{{"alice", 1}, {"alice", 3}, {"bob", 2}}, entries)
+
+	assert.NotNil(t, n.Remove("bob", 2))
+	assert.Nil(t, n.Inner("bob"))
+	assert.Equal(t, 1, n.OuterSize())
+	assert.Nil(t, n.Remove("bob", 2))
+
+	assert.Equal(t, 2, n.RemoveOuter("alice"))
+	assert.Equal(t, 0, n.Size())
+	assert.Equal(t, 0, n.OuterSize())
+}
+
+func TestNestedRejectedAndEvicted(t *testing.T) {
+	n := NewNested[string, int, string](WithKeyBounds[int, string](0, 10))
+	x, created := n.Set("bob", 11, "b")
+	assert.Nil(t, x)
+	assert.False(t, created)
+	assert.Nil(t, n.Inner("bob"))
+	assert.Equal(t, 0, n.OuterSize())
+
+	// the inner lists evict elements themselves
+	evicting := NewNested[string, int, string](WithMemoryBudget(1, func(s *SkipList[int, string]) {
+		if s.Size() > 2 {
+			s.RemoveByPos(0)
+		}
+	}))
+	for k := 0; k < 5; k++ {
+		evicting.Set("a", k, "v")
+		evicting.Set("b", k, "v")
+	}
+	assert.Equal(t, 4, evicting.Size())
+}
@@ -1,19 +1,26 @@
 package skiplist
 
-import (
-	"cmp"
-	"fmt"
-)
+import "fmt"
 
 // Node holds an element within the SkipList with a unique key `key`.
-type Node[K cmp.Ordered, V any] struct {
+type Node[K any, V any] struct {
 	key   K
 	Value V // Value is the payload within an element node.
 	next  []*Node[K, V]
 	dist  []int
+	id    uint64 // stable ID (see WithStableIDs)
+	// modified is the time of the last modification in Unix nanoseconds (see WithModTimes)
+	modified int64
+	// deleted marks a soft deleted node (see SkipList.MarkDeleted)
+	deleted bool
+	// seq is the insertion sequence number, insPrev and insNext link the insertion order (see WithInsertionOrder)
+	seq              uint64
+	insPrev, insNext *Node[K, V]
+	// prev is the previous element node or nil for the first one (see WithBackPointers)
+	prev *Node[K, V]
 }
 
-func newNode[K cmp.Ordered, V any](key K, value V, level int, capacity int) *Node[K, V] {
+func newNode[K any, V any](key K, value V, level int, capacity int) *Node[K, V] {
 	if capacity < level {
 		capacity = level
 	}
@@ -29,6 +36,11 @@ func (n *Node[K, V]) Key() K {
 	return n.key
 }
 
+// Deleted returns true if the node is soft deleted (see SkipList.MarkDeleted).
+func (n *Node[K, V]) Deleted() bool {
+	return n.deleted
+}
+
 func (n *Node[K, V]) Level() int {
 	return len(n.next)
 }
@@ -41,6 +53,45 @@ func (n *Node[K, V]) Next() *Node[K, V] {
 	return nil
 }
 
+// Prev returns the preceding element within the skip list in O(1). If there is no such element or the list was
+// created without WithBackPointers, nil is returned.
+func (n *Node[K, V]) Prev() *Node[K, V] {
+	return n.prev
+}
+
+// WithBackPointers maintains a backward link in every node, so Node.Prev returns the preceding element in O(1)
+// and Backward iterates without position lookups. Every insert and removal updates the link of the following
+// node as well. Without the option Node.Prev returns nil.
+func WithBackPointers() Option {
+	return func(c *config) {
+		c.backPointers = true
+	}
+}
+
+// linkPrev sets the backward link of n to the node p before it on level 0, which is nil if p is the head.
+func (n *Node[K, V]) linkPrev(p, head *Node[K, V]) {
+	if p == head {
+		n.prev = nil
+	} else {
+		n.prev = p
+	}
+}
+
+// linkBack sets the backward links of the inserted node n and of the node following it. p is the node before n.
+func (n *Node[K, V]) linkBack(p, head *Node[K, V]) {
+	n.linkPrev(p, head)
+	if n.next[0] != nil {
+		n.next[0].prev = n
+	}
+}
+
+// unlinkBack bridges the backward link of the node following the removed node n.
+func (n *Node[K, V]) unlinkBack() {
+	if n.next[0] != nil {
+		n.next[0].prev = n.prev
+	}
+}
+
 func (n *Node[K, V]) extendLevel(newLevel int) {
 	oldLevel := n.Level()
 	if newLevel > oldLevel {
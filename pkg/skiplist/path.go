@@ -0,0 +1,116 @@
+package skiplist
+
+import (
+	"cmp"
+	"errors"
+)
+
+// ErrStalePath is returned when a Path is used after the skip list was modified otherwise.
+var ErrStalePath = errors.New("skiplist: path invalidated by a modification")
+
+// Path is the search path to a key found by SkipList.FindPath(). It allows a check-then-act sequence (e.g. a
+// conditional insert) with a single descent. A path is invalidated by every other structural modification
+// of the skip list and by a snapshot (see SkipList.Snapshot); using an invalidated path returns ErrStalePath.
+type Path[K cmp.Ordered, V any] struct {
+	list      *SkipList[K, V]
+	key       K
+	update    []*Node[K, V] // rightmost nodes with a key < key on each level
+	updatePos []int         // positions of the update nodes
+	pos       int           // position of update[0]
+	version   uint64        // version of the list when the path was found
+}
+
+// FindPath searches `key` and returns its search path. Since the path is meant to be used for a following
+// modification, pending maintenance (like copying nodes shared with a snapshot) is done before the search.
+func (s *SkipList[K, V]) FindPath(key K) *Path[K, V] {
+	s.lazyInit()
+	s.pollRebuild()
+	s.ensureOwned()
+	p := &Path[K, V]{
+		list:      s,
+		key:       key,
+		update:    make([]*Node[K, V], s.Level(), s.maxLevel),
+		updatePos: make([]int, s.Level(), s.maxLevel),
+		version:   s.version,
+	}
+	x := s.head
+	pos := -1
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.less(x.next[i].key, key) {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		p.update[i] = x
+		p.updatePos[i] = pos
+	}
+	p.pos = pos
+	return p
+}
+
+// stale reports whether the path was invalidated by a modification or by a snapshot sharing its nodes.
+func (p *Path[K, V]) stale() bool {
+	return p.version != p.list.version || p.list.refs != nil
+}
+
+// Node returns the (first) node with the searched key or nil if the key was not found or the path is stale.
+func (p *Path[K, V]) Node() *Node[K, V] {
+	if p.stale() || len(p.update) == 0 {
+		return nil
+	}
+	if x := p.update[0].next[0]; x != nil && x.key == p.key {
+		return x
+	}
+	return nil
+}
+
+// Pos returns the position of the searched key if it was found, otherwise the position where it would be
+// inserted.
+func (p *Path[K, V]) Pos() int {
+	return p.pos + 1
+}
+
+// Set sets the value of the searched key like SkipList.TrySet without searching it again: the admission
+// control is consulted for new and existing keys. The path is invalidated if a new node was created.
+func (p *Path[K, V]) Set(value V) (*Node[K, V], int, bool, error) {
+	s := p.list
+	if p.stale() {
+		return nil, InvalidPos, false, ErrStalePath
+	}
+	x := p.Node()
+	if x != nil && s.duplicates {
+		// with duplicates the new node belongs behind all equal keys, which the path does not cover
+		return s.TrySet(p.key, value)
+	}
+	if err := s.admission(p.key, value); err != nil {
+		return nil, InvalidPos, false, err
+	}
+	value = s.storeValue(value)
+	s.touched(p.key)
+	if x != nil {
+		x.Value = value
+		s.markDeleted(x, false)
+		if s.modClock != nil {
+			s.stamp(x)
+		}
+		return x, p.pos + 1, false, nil
+	}
+	x = s.insert(p.update, p.updatePos, p.pos, p.key, value)
+	s.inserted()
+	return x, p.pos + 1, true, nil
+}
+
+// Remove removes the (first) node with the searched key like SkipList.Remove without searching it again.
+// Returns nil and InvalidPos if the key was not found. The path is invalidated if a node was removed.
+func (p *Path[K, V]) Remove() (*Node[K, V], int, error) {
+	s := p.list
+	if p.stale() {
+		return nil, InvalidPos, ErrStalePath
+	}
+	x := p.Node()
+	if x == nil {
+		return nil, InvalidPos, nil
+	}
+	s.touched(p.key)
+	s.unlink(p.update, x)
+	return x, p.pos + 1, nil
+}
@@ -0,0 +1,120 @@
+package skiplist
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPath(t *testing.T) {
+	s := NewSkipList[int, string]()
+	for k := 0; k < 100; k += 2 {
+		s.Set(k, "v")
+	}
+
+	p := s.FindPath(11)
+	assert.Nil(t, p.Node())
+	assert.Equal(t, 6, p.Pos())
+	x, pos, created, err := p.Set("new")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, 6, pos)
+	assert.Equal(t, 11, x.Key())
+	require.NoError(t, s.Validate())
+
+	// the path is invalidated by the insert
+	_, _, _, err = p.Set("again")
+	assert.ErrorIs(t, err, ErrStalePath)
+	assert.Nil(t, p.Node())
+
+	p = s.FindPath(10)
+	require.NotNil(t, p.Node())
+	assert.Equal(t, 5, p.Pos())
+	x, pos, created, err = p.Set("updated")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, 5, pos)
+	assert.Equal(t, "updated", x.Value)
+
+	// overriding a value does not invalidate the path
+	x, pos, err = p.Remove()
+	require.NoError(t, err)
+	assert.Equal(t, 10, x.Key())
+	assert.Equal(t, 5, pos)
+	require.NoError(t, s.Validate())
+
+	p = s.FindPath(10)
+	s.Set(1000, "other")
+	_, _, err = p.Remove()
+	assert.ErrorIs(t, err, ErrStalePath)
+
+	x, pos, err = s.FindPath(13).Remove()
+	require.NoError(t, err)
+	assert.Nil(t, x)
+	assert.Equal(t, InvalidPos, pos)
+}
+
+func TestFindPathEmptyAndSnapshot(t *testing.T) {
+	s := NewSkipList[int, int]()
+	p := s.FindPath(1)
+	assert.Nil(t, p.Node())
+	_, _, created, err := p.Set(1)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	snap := s.Snapshot()
+	p = s.FindPath(2)
+	_, _, _, err = p.Set(2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, snap.Size())
+	assert.Equal(t, 2, s.Size())
+
+	// a path found before a snapshot must not write into the shared nodes
+	p = s.FindPath(3)
+	snap = s.Snapshot()
+	_, _, _, err = p.Set(3)
+	assert.ErrorIs(t, err, ErrStalePath)
+	_, _, _, err = s.FindPath(1).Set(10)
+	require.NoError(t, err)
+	require.NoError(t, s.Validate())
+	require.NoError(t, snap.Validate())
+	x, _ := snap.Get(1)
+	assert.Equal(t, 1, x.Value)
+}
+
+func TestPathSetAdmission(t *testing.T) {
+	banned := errors.New("banned")
+	var seen []string
+	s := NewSkipList[int, string](WithAdmissionControl(func(_ int, value string, _ int) error {
+		seen = append(seen, value)
+		if value == "bad" {
+			return banned
+		}
+		return nil
+	}), WithKeyBounds[int, string](0, 10), WithValueCodec[int](strings.ToUpper, strings.ToLower))
+	s.Set(1, "a")
+	_, _, _, err := s.FindPath(1).Set("bad")
+	assert.ErrorIs(t, err, banned)
+	_, _, _, err = s.FindPath(2).Set("bad")
+	assert.ErrorIs(t, err, banned)
+	_, _, _, err = s.FindPath(11).Set("b")
+	assert.ErrorIs(t, err, ErrKeyOutOfBounds)
+	_, _, _, err = s.FindPath(1).Set("c")
+	require.NoError(t, err)
+	v, _ := s.GetCopy(1)
+	assert.Equal(t, "c", v)
+	assert.Equal(t, []string{"a", "bad", "bad", "c"}, seen)
+}
+
+func TestSetOverridePosition(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	_, pos, created := s.Set(4, 0)
+	assert.False(t, created)
+	assert.Equal(t, 4, pos)
+}
@@ -0,0 +1,86 @@
+package skiplist
+
+import "errors"
+
+var (
+	// ErrNodeNotFound is returned if a node passed as argument is not contained in the skip list.
+	ErrNodeNotFound = errors.New("skiplist: node not found")
+	// ErrInvalidPlacement is returned if an explicitly placed key would violate the key order.
+	ErrInvalidPlacement = errors.New("skiplist: placement violates key order")
+)
+
+// InsertBefore inserts a new element directly before `node`, e.g. to control the order among equal keys if
+// duplicates are allowed (see WithDuplicates). The key must fit between the predecessor of node and node.
+// Returns the new node and its position 0...n-1.
+func (s *SkipList[K, V]) InsertBefore(node *Node[K, V], key K, value V) (*Node[K, V], int, error) {
+	pos := s.position(node)
+	if pos == InvalidPos {
+		return nil, InvalidPos, ErrNodeNotFound
+	}
+	return s.insertAt(pos, key, value)
+}
+
+// InsertAfter inserts a new element directly behind `node`. The key must fit between node and its successor.
+// Returns the new node and its position 0...n-1.
+func (s *SkipList[K, V]) InsertAfter(node *Node[K, V], key K, value V) (*Node[K, V], int, error) {
+	pos := s.position(node)
+	if pos == InvalidPos {
+		return nil, InvalidPos, ErrNodeNotFound
+	}
+	return s.insertAt(pos+1, key, value)
+}
+
+// position returns the position of `node` or InvalidPos if it is not contained in the skip list.
+// Nodes with keys equal to the key of node are passed linearly.
+func (s *SkipList[K, V]) position(node *Node[K, V]) int {
+	if node == nil {
+		return InvalidPos
+	}
+	x, pos := s.Get(node.key)
+	for ; x != nil && x.key == node.key; x = x.Next() {
+		if x == node {
+			return pos
+		}
+		pos++
+	}
+	return InvalidPos
+}
+
+// insertAt inserts a new element at position k in [0, Size()] if the key order is kept and it is
+// accepted by the admission control.
+func (s *SkipList[K, V]) insertAt(k int, key K, value V) (*Node[K, V], int, error) {
+	if err := s.admission(key, value); err != nil {
+		return nil, InvalidPos, err
+	}
+	value = s.storeValue(value)
+	x, err := s.linkAt(k, key, value)
+	if err != nil {
+		return nil, InvalidPos, err
+	}
+	s.inserted()
+	return x, k, nil
+}
+
+// linkAt links a new node at position k in [0, Size()] if the key order is kept.
+func (s *SkipList[K, V]) linkAt(k int, key K, value V) (*Node[K, V], error) {
+	s.lazyInit()
+	s.pollRebuild()
+	s.touched(key)
+	s.ensureOwned()
+	update := make([]*Node[K, V], s.Level(), s.maxLevel)
+	updatePos := make([]int, s.Level(), s.maxLevel)
+	x := s.head
+	pos := -1
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && pos+x.dist[i] < k {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		update[i] = x
+		updatePos[i] = pos
+	}
+	if (x != s.head && !s.ordered(x.key, key)) || (x.Next() != nil && !s.ordered(key, x.Next().key)) {
+		return nil, ErrInvalidPlacement
+	}
+	return s.insert(update, updatePos, pos, key, value), nil
+}
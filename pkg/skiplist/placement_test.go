@@ -0,0 +1,58 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertBeforeAfter(t *testing.T) {
+	s := NewSkipList[int, string](WithDuplicates())
+	s.Set(1, "a")
+	b, _, _ := s.Set(2, "b")
+	s.Set(2, "c")
+	s.Set(3, "d")
+
+	x, pos, err := s.InsertBefore(b, 2, "x")
+	require.NoError(t, err)
+	assert.Equal(t, 1, pos)
+	assert.Equal(t, "x", x.Value)
+
+	_, pos, err = s.InsertAfter(b, 2, "y")
+	require.NoError(t, err)
+	assert.Equal(t, 3, pos)
+	assert.Equal(t, []string{"a", "x", "b", "y", "c", "d"}, valuesOf(s))
+	require.NoError(t, s.Validate())
+
+	_, _, err = s.InsertAfter(b, 3, "z")
+	assert.ErrorIs(t, err, ErrInvalidPlacement)
+	_, _, err = s.InsertBefore(b, 1, "z")
+	assert.ErrorIs(t, err, ErrInvalidPlacement)
+
+	removed, _ := s.Remove(1)
+	_, _, err = s.InsertAfter(removed, 1, "z")
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+
+	last := s.GetByPos(s.Size() - 1)
+	_, pos, err = s.InsertAfter(last, 4, "e")
+	require.NoError(t, err)
+	assert.Equal(t, s.Size()-1, pos)
+	_, pos, err = s.InsertBefore(s.First(), 0, "f")
+	require.NoError(t, err)
+	assert.Equal(t, 0, pos)
+	assert.Equal(t, []string{"f", "x", "b", "y", "c", "d", "e"}, valuesOf(s))
+	require.NoError(t, s.Validate())
+}
+
+func TestInsertWithoutDuplicates(t *testing.T) {
+	s := NewSkipList[int, string]()
+	a, _, _ := s.Set(1, "a")
+	s.Set(5, "b")
+	_, _, err := s.InsertAfter(a, 1, "x")
+	assert.ErrorIs(t, err, ErrInvalidPlacement)
+	_, pos, err := s.InsertAfter(a, 3, "x")
+	require.NoError(t, err)
+	assert.Equal(t, 1, pos)
+	require.NoError(t, s.Validate())
+}
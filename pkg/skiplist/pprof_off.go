@@ -0,0 +1,15 @@
+//go:build !skiplist_pprof
+
+package skiplist
+
+// pprofLabels is true if the core operations set runtime/pprof labels (build tag skiplist_pprof).
+const pprofLabels = false
+
+// profLabels are the labels of a goroutine saved by setLabels.
+type profLabels struct{}
+
+func (s *SkipList[K, V]) setLabels(op string) profLabels {
+	return profLabels{}
+}
+
+func restoreLabels(saved profLabels) {}
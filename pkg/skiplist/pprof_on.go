@@ -0,0 +1,53 @@
+//go:build skiplist_pprof
+
+package skiplist
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+	"unsafe"
+)
+
+// pprofLabels is true if the core operations set runtime/pprof labels (build tag skiplist_pprof).
+const pprofLabels = true
+
+// runtime/pprof offers no way to read the labels of the current goroutine, which are needed to restore the
+// labels of the caller (e.g. set by pprof.Do) after an operation. Both functions are provided by the runtime.
+
+//go:linkname getProfLabel runtime/pprof.runtime_getProfLabel
+func getProfLabel() unsafe.Pointer
+
+//go:linkname setProfLabel runtime/pprof.runtime_setProfLabel
+func setProfLabel(labels unsafe.Pointer)
+
+// profLabels are the labels of a goroutine saved by setLabels.
+type profLabels = unsafe.Pointer
+
+// labelKey identifies the labels of an operation on a list.
+type labelKey struct {
+	name, op string
+}
+
+// labelContexts caches a context with the labels of every labelKey, so labeling an operation does not allocate.
+var labelContexts sync.Map
+
+// setLabels labels the current goroutine with the list name (see WithName) and the operation, so CPU
+// profiles attribute samples to lists and operations. The labels of the caller are replaced during the
+// operation; they are returned for restoreLabels.
+func (s *SkipList[K, V]) setLabels(op string) profLabels {
+	key := labelKey{s.name, op}
+	ctx, ok := labelContexts.Load(key)
+	if !ok {
+		ctx, _ = labelContexts.LoadOrStore(key, pprof.WithLabels(context.Background(),
+			pprof.Labels("skiplist", s.name, "op", op)))
+	}
+	saved := getProfLabel()
+	pprof.SetGoroutineLabels(ctx.(context.Context))
+	return saved
+}
+
+// restoreLabels restores the labels of the caller saved by setLabels.
+func restoreLabels(saved profLabels) {
+	setProfLabel(saved)
+}
@@ -0,0 +1,51 @@
+//go:build skiplist_pprof
+
+package skiplist
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goroutineLabels returns the goroutine profile with the labels of all goroutines.
+func goroutineLabels(t *testing.T) string {
+	var buf bytes.Buffer
+	require.NoError(t, pprof.Lookup("goroutine").WriteTo(&buf, 1))
+	return buf.String()
+}
+
+func TestPprofLabels(t *testing.T) {
+	var during string
+	s := NewSkipList[int, int](WithName("orders"), WithAdmissionControl(func(key int, _ int, _ int) error {
+		if key == 1 {
+			during = goroutineLabels(t)
+		}
+		return nil
+	}))
+	pprof.Do(context.Background(), pprof.Labels("caller", "test"), func(context.Context) {
+		s.Set(1, 1)
+		assert.Contains(t, during, `"op":"Set"`)
+		assert.Contains(t, during, `"skiplist":"orders"`)
+		assert.NotContains(t, during, `"caller":"test"`)
+
+		// the labels of the caller are restored
+		after := goroutineLabels(t)
+		assert.Contains(t, after, `"caller":"test"`)
+		assert.NotContains(t, after, `"op":"Set"`)
+	})
+	assert.NotContains(t, goroutineLabels(t), `"caller":"test"`)
+
+	for k := 0; k < 100; k++ {
+		s.Set(k, k)
+	}
+	assert.NotNil(t, s.GetByPos(10))
+	assert.NotNil(t, s.RemoveByPos(10))
+	x, _ := s.Remove(20)
+	assert.NotNil(t, x)
+	assert.Zero(t, testing.AllocsPerRun(100, func() { s.Get(50) }))
+}
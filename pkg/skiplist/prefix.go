@@ -0,0 +1,50 @@
+package skiplist
+
+// prefixEnd returns the smallest string greater than all strings starting with `prefix`. The bool value
+// is false if there is no such string, i.e. the prefix is empty or consists of 0xff bytes only.
+func prefixEnd[K ~string](prefix K) (K, bool) {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return K(b[:i+1]), true
+		}
+	}
+	return "", false
+}
+
+// prefixBounds returns the positions [begin, end) of all keys starting with `prefix`. The keys with the prefix
+// are >= prefix and < prefixEnd(prefix), so in a descending list they start behind prefixEnd(prefix) and end
+// behind prefix.
+func prefixBounds[K ~string, V any](s *SkipList[K, V], prefix K) (int, int) {
+	upper, ok := prefixEnd(prefix)
+	if s.descending {
+		begin := 0
+		if ok {
+			_, begin = s.upperBound(upper)
+		}
+		_, end := s.upperBound(prefix)
+		return begin, end
+	}
+	_, begin := s.lowerBound(prefix)
+	end := s.Size()
+	if ok {
+		_, end = s.lowerBound(upper)
+	}
+	return begin, end
+}
+
+// CountPrefix returns the number of keys starting with `prefix` in O(log(n)).
+func CountPrefix[K ~string, V any](s *SkipList[K, V], prefix K) int {
+	begin, end := prefixBounds(s, prefix)
+	return end - begin
+}
+
+// PrefixIterator returns an iterator over all elements whose keys start with `prefix`.
+func PrefixIterator[K ~string, V any](s *SkipList[K, V], prefix K, options ...IteratorOption) *Iterator[K, V] {
+	cfg, s := s.iteratorConfig(options)
+	begin, end := prefixBounds(s, prefix)
+	it := s.newIterator(begin, cfg)
+	it.end = end
+	return it
+}
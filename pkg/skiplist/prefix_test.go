@@ -0,0 +1,74 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefix(t *testing.T) {
+	s := NewSkipList[string, int]()
+	for i, k := range []string{"a:1", "tenant:a", "tenant:b", "tenant:c", "tenantx", "u\xff", "u\xff\xff", "z"} {
+		s.Set(k, i)
+	}
+
+	assert.Equal(t, 3, CountPrefix(s, "tenant:"))
+	assert.Equal(t, 4, CountPrefix(s, "tenant"))
+	assert.Equal(t, 0, CountPrefix(s, "b"))
+	assert.Equal(t, 2, CountPrefix(s, "u\xff"))
+	assert.Equal(t, 8, CountPrefix(s, ""))
+
+	var keys []string
+	for it := PrefixIterator(s, "tenant:"); it.Next(); {
+		keys = append(keys, it.Node().Key())
+	}
+	assert.Equal(t, []string{"tenant:a", "tenant:b", "tenant:c"}, keys)
+
+	keys = nil
+	for it := PrefixIterator(s, "tenant:", Offset(1), Limit(5)); it.Next(); {
+		keys = append(keys, it.Node().Key())
+		assert.Equal(t, len(keys)+1, it.Pos())
+	}
+	assert.Equal(t, []string{"tenant:b", "tenant:c"}, keys)
+
+	assert.False(t, PrefixIterator(s, "tenant:", Offset(3)).Next())
+	assert.False(t, PrefixIterator(s, "b").Next())
+}
+
+func TestPrefixDescending(t *testing.T) {
+	s := NewSkipList[string, int](WithDescending())
+	for i, k := range []string{"a:1", "tenant", "tenant:a", "tenant:b", "tenant:c", "tenantx", "u\xff", "u\xff\xff", "z"} {
+		s.Set(k, i)
+	}
+
+	assert.Equal(t, 3, CountPrefix(s, "tenant:"))
+	assert.Equal(t, 5, CountPrefix(s, "tenant"))
+	assert.Equal(t, 0, CountPrefix(s, "b"))
+	assert.Equal(t, 2, CountPrefix(s, "u\xff"))
+	assert.Equal(t, 9, CountPrefix(s, ""))
+
+	var keys []string
+	for it := PrefixIterator(s, "tenant"); it.Next(); {
+		keys = append(keys, it.Node().Key())
+	}
+	assert.Equal(t, []string{"tenantx", "tenant:c", "tenant:b", "tenant:a", "tenant"}, keys)
+	assert.False(t, PrefixIterator(s, "b").Next())
+}
+
+func TestPrefixIteratorSoftDeleted(t *testing.T) {
+	s := NewSkipList[string, int]()
+	for _, k := range []string{"a", "ab", "abc", "abd", "b", "bc"} {
+		s.Set(k, 0)
+	}
+	s.MarkDeleted("ab")
+	var keys []string
+	for it := PrefixIterator(s, "ab", IncludeDeleted()); it.Next(); {
+		keys = append(keys, it.Node().Key())
+	}
+	assert.Equal(t, []string{"ab", "abc", "abd"}, keys)
+	keys = nil
+	for it := PrefixIterator(s, "a"); it.Next(); {
+		keys = append(keys, it.Node().Key())
+	}
+	assert.Equal(t, []string{"a", "abc", "abd"}, keys)
+}
@@ -0,0 +1,95 @@
+package skiplist
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backwardKeys returns the keys from the last to the first node following the back links.
+func backwardKeys[K any, V any](last *Node[K, V]) []K {
+	var keys []K
+	for x := last; x != nil; x = x.Prev() {
+		keys = append(keys, x.Key())
+	}
+	return keys
+}
+
+func TestPrev(t *testing.T) {
+	s := NewSkipList[int, int](WithBackPointers())
+	assert.Nil(t, s.Last())
+	var want []int
+	for k := 0; k < 100; k++ {
+		s.Set((k*37)%100, k)
+		want = append(want, k)
+	}
+	for k := 0; k < 100; k += 3 {
+		s.Remove(k)
+		want = slices.DeleteFunc(want, func(key int) bool { return key == k })
+	}
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 98, s.Last().Key())
+	assert.Nil(t, s.First().Prev())
+	slices.Reverse(want)
+	assert.Equal(t, want, backwardKeys(s.Last()))
+
+	// the nodes copied from a snapshot are linked backwards as well
+	snap := s.Snapshot()
+	s.Set(1000, 0)
+	s.RemoveByPos(0)
+	require.NoError(t, s.Validate())
+	require.NoError(t, snap.Validate())
+	assert.Equal(t, want, backwardKeys(snap.Last()))
+
+	require.NoError(t, s.Compact().Validate())
+	require.NoError(t, s.CloneExact().Validate())
+	require.NoError(t, MapValues(s, func(_ int, v int) string { return "" }).Validate())
+}
+
+func TestPrevBulk(t *testing.T) {
+	s := NewSkipList[int, int](WithBackPointers())
+	s.Load([]Pair[int, int]{{3, 0}, {1, 0}, {2, 0}, {5, 0}, {4, 0}})
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, backwardKeys(s.Last()))
+
+	dst := s.ExtractRange(2, 3)
+	require.NoError(t, s.Validate())
+	require.NoError(t, dst.Validate())
+	assert.Equal(t, []int{3, 2}, backwardKeys(dst.Last()))
+	assert.Equal(t, []int{5, 4, 1}, backwardKeys(s.Last()))
+
+	// a broken back link is detected by Validate and rebuilt by Repair
+	s.Last().prev = nil
+	assert.ErrorIs(t, s.Validate(), ErrCorrupted)
+	require.NoError(t, s.Repair())
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{5, 4, 1}, backwardKeys(s.Last()))
+}
+
+func TestPrevDisabled(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	s.Remove(5)
+	s.Load([]Pair[int, int]{{1, 0}, {2, 0}})
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 2, s.Last().Key())
+	assert.Nil(t, s.Last().Prev())
+	assert.Nil(t, s.Snapshot().Last().Prev())
+}
+
+func TestPrevFunc(t *testing.T) {
+	s := NewSkipListFunc[string, int](func(a, b string) bool { return len(a) < len(b) }, WithBackPointers())
+	for _, key := range []string{"ccc", "a", "dddd", "bb"} {
+		s.Set(key, 0)
+	}
+	s.Remove("ccc")
+	var last *Node[string, int]
+	for x := s.First(); x != nil; x = x.Next() {
+		last = x
+	}
+	assert.Equal(t, []string{"dddd", "bb", "a"}, backwardKeys(last))
+}
@@ -0,0 +1,40 @@
+package skiplist
+
+import (
+	crand "crypto/rand"
+	"log"
+	"math/rand/v2"
+)
+
+// RandomLevelFunc returns a LevelFunc drawing the levels from the random generator r.
+// The generator must not be used concurrently.
+func RandomLevelFunc(r *rand.Rand) LevelFunc {
+	return func(p float64, maxLevel int) int {
+		level := 1
+		for r.Float64() < p && level < maxLevel {
+			level++
+		}
+		return level
+	}
+}
+
+// WithSeed generates the levels with a PCG generator seeded by `seed`, so the structure of the skip list
+// is reproducible for the same sequence of operations.
+func WithSeed(seed uint64) Option {
+	return func(c *config) {
+		c.levelFunc = RandomLevelFunc(rand.New(rand.NewPCG(seed, seed)))
+	}
+}
+
+// WithCryptoSeed generates the levels with a ChaCha8 generator seeded from crypto/rand. Every list created
+// with the option gets its own seed, so the tower heights cannot be predicted by an attacker observing other
+// lists or the process start.
+func WithCryptoSeed() Option {
+	return func(c *config) {
+		var seed [32]byte
+		if _, err := crand.Read(seed[:]); err != nil {
+			log.Panic("Reading a random seed failed: ", err)
+		}
+		c.levelFunc = RandomLevelFunc(rand.New(rand.NewChaCha8(seed)))
+	}
+}
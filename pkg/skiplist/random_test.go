@@ -0,0 +1,33 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func levels(s *SkipList[int, int]) []int {
+	var l []int
+	for x := s.First(); x != nil; x = x.Next() {
+		l = append(l, x.Level())
+	}
+	return l
+}
+
+func TestWithSeed(t *testing.T) {
+	a := NewSkipList[int, int](WithSeed(42))
+	b := NewSkipList[int, int](WithSeed(42))
+	crypto := WithCryptoSeed()
+	c := NewSkipList[int, int](crypto)
+	d := NewSkipList[int, int](crypto)
+	for k := 0; k < 200; k++ {
+		a.Set(k, k)
+		b.Set(k, k)
+		c.Set(k, k)
+		d.Set(k, k)
+	}
+	assert.Equal(t, levels(a), levels(b))
+	assert.NoError(t, c.Validate())
+	// a shared option seeds every list anew
+	assert.NotEqual(t, levels(c), levels(d))
+}
@@ -0,0 +1,128 @@
+package skiplist
+
+import (
+	"slices"
+)
+
+// RankDesc returns the position of `key` counted from the largest key, i.e. the largest key has the
+// rank 0 and the smallest key the rank Size()-1. Returns InvalidPos if the key was not found.
+func (s *SkipList[K, V]) RankDesc(key K) int {
+	_, pos := s.Get(key)
+	if pos == InvalidPos {
+		return InvalidPos
+	}
+	return s.count - 1 - pos
+}
+
+// Place returns the 1-based place of `key` in a descending ordering as shown in leaderboards: the largest
+// key has place 1. Returns 0 if the key was not found.
+func (s *SkipList[K, V]) Place(key K) int {
+	return s.RankDesc(key) + 1
+}
+
+// GetByNegPos returns the element at the negative position k in the interval [-Size(), -1] counted from the
+// end of the skip list: -1 is the last (largest) element, -Size() the first one. Returns nil if k is out of range.
+func (s *SkipList[K, V]) GetByNegPos(k int) *Node[K, V] {
+	if k >= 0 {
+		return nil
+	}
+	return s.GetByPos(s.count + k)
+}
+
+// CountRange returns the number of elements with from <= key <= to in O(log(n)).
+func (s *SkipList[K, V]) CountRange(from, to K) int {
+	_, begin := s.lowerBound(from)
+	_, end := s.upperBound(to)
+	return max(0, end-begin)
+}
+
+// GetByRank1 returns the element with the 1-based rank r in the interval [1, Size()] as used by systems
+// like SQL ROW_NUMBER(). Returns nil if r is out of range.
+func (s *SkipList[K, V]) GetByRank1(r int) *Node[K, V] {
+	return s.GetByPos(r - 1)
+}
+
+// RankOf1 returns the 1-based rank of `key` in ascending order or 0 if the key was not found.
+func (s *SkipList[K, V]) RankOf1(key K) int {
+	_, pos := s.Get(key)
+	if pos == InvalidPos {
+		return 0
+	}
+	return pos + 1
+}
+
+// Ranks returns for every key of the batch its rank, i.e. the number of elements with a smaller key, which is
+// the position the key has or would get when inserted. The batch is sorted once and searched in a single
+// sweep, where every search starts from the search path of the previous key. The batch is not modified.
+func (s *SkipList[K, V]) Ranks(keys []K) []int {
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return s.compare(keys[a], keys[b]) })
+
+	ranks := make([]int, len(keys))
+	update := make([]*Node[K, V], s.Level())
+	updatePos := make([]int, s.Level())
+	for i := range update {
+		update[i] = s.head
+		updatePos[i] = -1
+	}
+	for _, k := range order {
+		key := keys[k]
+		x := s.head
+		pos := -1
+		for i := s.Level() - 1; i >= 0; i-- {
+			// the predecessor of the previous key is a valid starting point since the keys are ascending
+			if updatePos[i] > pos {
+				x, pos = update[i], updatePos[i]
+			}
+			for x.next[i] != nil && s.less(x.next[i].key, key) {
+				pos += x.dist[i]
+				x = x.next[i]
+			}
+			update[i], updatePos[i] = x, pos
+		}
+		ranks[k] = pos + 1
+	}
+	return ranks
+}
+
+// ApproxRank estimates the rank of `key`, i.e. the number of elements with a smaller key, by a search which
+// stops at the highest level where the rank is known up to ±tolerance. The exact rank lies within
+// [rank-errBound, rank+errBound] with errBound <= tolerance. Since a level i node spans about 1/p^i elements,
+// the search saves about log(2*tolerance)/log(1/p) levels. A tolerance of 0 yields the exact rank.
+func (s *SkipList[K, V]) ApproxRank(key K, tolerance int) (rank int, errBound int) {
+	return s.approxBound(func(x K) bool { return s.less(x, key) }, tolerance)
+}
+
+// ApproxCountRange estimates the number of elements with from <= key <= to like CountRange from two searches
+// with the given tolerance (see ApproxRank). The exact count lies within [count-errBound, count+errBound]
+// with errBound <= 2*tolerance.
+func (s *SkipList[K, V]) ApproxCountRange(from, to K, tolerance int) (count int, errBound int) {
+	if s.less(to, from) {
+		return 0, 0
+	}
+	begin, beginErr := s.ApproxRank(from, tolerance)
+	end, endErr := s.approxBound(func(x K) bool { return !s.less(to, x) }, tolerance)
+	return max(0, end-begin), beginErr + endErr
+}
+
+// approxBound estimates the number of elements whose key satisfies `before`, which must hold for a prefix of
+// the list. Returns the center of the interval of the possible results and its half width.
+func (s *SkipList[K, V]) approxBound(before func(K) bool, tolerance int) (int, int) {
+	s.lazyInit()
+	x := s.head
+	pos := -1
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && before(x.next[i].key) {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		// the result lies within [pos+1, pos+dist] with the distance to the next node on this level
+		if width := x.dist[i] - 1; width <= 2*tolerance || i == 0 {
+			return pos + 1 + width/2, width - width/2
+		}
+	}
+	return 0, 0
+}
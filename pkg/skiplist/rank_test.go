@@ -0,0 +1,105 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankDesc(t *testing.T) {
+	s := NewSkipList[int, string]()
+	s.Set(100, "alice")
+	s.Set(300, "bob")
+	s.Set(200, "carol")
+
+	assert.Equal(t, 0, s.RankDesc(300))
+	assert.Equal(t, 2, s.RankDesc(100))
+	assert.Equal(t, InvalidPos, s.RankDesc(150))
+
+	assert.Equal(t, 1, s.Place(300))
+	assert.Equal(t, 2, s.Place(200))
+	assert.Equal(t, 3, s.Place(100))
+	assert.Equal(t, 0, s.Place(150))
+
+	assert.Equal(t, "bob", s.GetByNegPos(-1).Value)
+	assert.Equal(t, "alice", s.GetByNegPos(-3).Value)
+	assert.Nil(t, s.GetByNegPos(-4))
+	assert.Nil(t, s.GetByNegPos(0))
+}
+
+func TestCountRange(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 100; k += 2 {
+		s.Set(k, k)
+	}
+	assert.Equal(t, 50, s.CountRange(0, 98))
+	assert.Equal(t, 4, s.CountRange(9, 16))
+	assert.Equal(t, 1, s.CountRange(10, 10))
+	assert.Equal(t, 0, s.CountRange(11, 11))
+	assert.Equal(t, 0, s.CountRange(16, 9))
+	assert.Equal(t, 0, s.CountRange(200, 300))
+}
+
+func TestOneBasedRanks(t *testing.T) {
+	s := NewSkipList[string, int]()
+	s.Set("b", 2)
+	s.Set("a", 1)
+	s.Set("c", 3)
+
+	assert.Equal(t, "a", s.GetByRank1(1).Key())
+	assert.Equal(t, "c", s.GetByRank1(3).Key())
+	assert.Nil(t, s.GetByRank1(0))
+	assert.Nil(t, s.GetByRank1(4))
+
+	assert.Equal(t, 1, s.RankOf1("a"))
+	assert.Equal(t, 3, s.RankOf1("c"))
+	assert.Equal(t, 0, s.RankOf1("d"))
+}
+
+func TestRanks(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for _, k := range makeRandomData(1000) {
+		s.Set(2*k, k)
+	}
+	keys := []int{5000, -1, 0, 1, 2, 1999, 1998, 700, 700, 3}
+	expected := make([]int, len(keys))
+	for i, k := range keys {
+		_, expected[i] = s.lowerBound(k)
+	}
+	assert.Equal(t, expected, s.Ranks(keys))
+	assert.Equal(t, []int{1000, 0, 0, 1, 1, 1000, 999, 350, 350, 2}, s.Ranks(keys))
+	assert.Equal(t, []int{5000, -1, 0, 1, 2, 1999, 1998, 700, 700, 3}, keys)
+
+	assert.Empty(t, s.Ranks(nil))
+	assert.Equal(t, []int{0, 0}, NewSkipList[int, int]().Ranks([]int{3, 1}))
+}
+
+func TestApproxRank(t *testing.T) {
+	s := NewSkipList[int, int](WithSeed(42))
+	for k := 0; k < 10000; k++ {
+		s.Set(2*k, k)
+	}
+	for _, tolerance := range []int{0, 1, 10, 100, 1000} {
+		for _, key := range []int{-5, 0, 1, 777, 5000, 12345, 19998, 19999, 30000} {
+			rank, errBound := s.ApproxRank(key, tolerance)
+			exact := s.Ranks([]int{key})[0]
+			assert.LessOrEqual(t, errBound, tolerance)
+			assert.LessOrEqual(t, rank-errBound, exact, "key %d tolerance %d", key, tolerance)
+			assert.GreaterOrEqual(t, rank+errBound, exact, "key %d tolerance %d", key, tolerance)
+
+			count, errBound := s.ApproxCountRange(key, key+3000, tolerance)
+			exact = s.CountRange(key, key+3000)
+			assert.LessOrEqual(t, errBound, 2*tolerance)
+			assert.LessOrEqual(t, count-errBound, exact, "key %d tolerance %d", key, tolerance)
+			assert.GreaterOrEqual(t, count+errBound, exact, "key %d tolerance %d", key, tolerance)
+		}
+	}
+	rank, errBound := s.ApproxRank(777, 0)
+	assert.Equal(t, 389, rank)
+	assert.Equal(t, 0, errBound)
+	count, _ := s.ApproxCountRange(10, 5, 100)
+	assert.Equal(t, 0, count)
+	rank, errBound = NewSkipList[int, int]().ApproxRank(1, 10)
+	assert.Equal(t, 0, rank)
+	assert.Equal(t, 0, errBound)
+}
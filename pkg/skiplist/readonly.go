@@ -0,0 +1,59 @@
+package skiplist
+
+import "cmp"
+
+// ReadOnlySkipList is the read-only subset of the operations of a skip list. APIs accepting it instead of
+// *SkipList cannot modify the structure of the list, e.g. plugins can look up and iterate elements but not
+// remove them. It is implemented by *SkipList and by the view *SubList.
+type ReadOnlySkipList[K cmp.Ordered, V any] interface {
+	// Get returns the node with `key` and its position or nil and InvalidPos if it was not found.
+	Get(key K) (*Node[K, V], int)
+	// GetByPos returns the node at position k or nil if k is out of range.
+	GetByPos(k int) *Node[K, V]
+	// Size returns the number of elements.
+	Size() int
+	// First returns the first node or nil if there are no elements.
+	First() *Node[K, V]
+	// Range calls fn for the elements with from <= key <= to in ascending order until fn returns false.
+	Range(from, to K, fn func(key K, value V) bool)
+	// Iterator returns an iterator over all elements.
+	Iterator(options ...IteratorOption) *Iterator[K, V]
+}
+
+var (
+	_ ReadOnlySkipList[int, int] = (*SkipList[int, int])(nil)
+	_ ReadOnlySkipList[int, int] = (*SubList[int, int])(nil)
+)
+
+// ReadOnly returns the skip list as ReadOnlySkipList.
+func (s *SkipList[K, V]) ReadOnly() ReadOnlySkipList[K, V] {
+	return s
+}
+
+// Range calls fn for the elements with from <= key <= to in ascending order until fn returns false.
+// Soft deleted elements are skipped (see MarkDeleted). Compressed values are passed decompressed.
+func (s *SkipList[K, V]) Range(from, to K, fn func(key K, value V) bool) {
+	for it := s.IteratorRange(from, to); it.Next(); {
+		if !fn(it.Node().key, s.ValueOf(it.Node())) {
+			it.Close()
+			return
+		}
+	}
+}
+
+// AppendRange appends the elements with from <= key <= to in ascending order to dst and returns the extended
+// slice, like the Append functions of the standard library. Reusing dst[:0] avoids allocations in query loops.
+// Soft deleted elements are skipped (see MarkDeleted). Compressed values are appended decompressed.
+func (s *SkipList[K, V]) AppendRange(dst []Pair[K, V], from, to K) []Pair[K, V] {
+	for x, _ := s.lowerBound(from); x != nil && !s.less(to, x.key); x = x.Next() {
+		if !x.deleted {
+			dst = append(dst, Pair[K, V]{Key: x.key, Value: s.ValueOf(x)})
+		}
+	}
+	return dst
+}
+
+// Range calls fn for the elements with from <= key <= to within the bounds of the view like SkipList.Range.
+func (v *SubList[K, V]) Range(from, to K, fn func(key K, value V) bool) {
+	v.list.Range(v.list.laterKey(from, v.from), v.list.earlierKey(to, v.to), fn)
+}
@@ -0,0 +1,53 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sumRange(r ReadOnlySkipList[int, int], from, to int) int {
+	sum := 0
+	r.Range(from, to, func(_ int, v int) bool {
+		sum += v
+		return v < 50
+	})
+	return sum
+}
+
+func TestReadOnly(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 100; k += 10 {
+		s.Set(k, k)
+	}
+	r := s.ReadOnly()
+	assert.Equal(t, 10, r.Size())
+	assert.Equal(t, 0+10+20, sumRange(r, 0, 25))
+	assert.Equal(t, 40+50, sumRange(r, 35, 1000))
+
+	v := s.SubList(20, 40)
+	assert.Equal(t, 20+30+40, sumRange(v, 0, 1000))
+	assert.Equal(t, 30, sumRange(v, 25, 35))
+	x, _ := r.Get(30)
+	assert.Equal(t, 30, x.Value)
+}
+
+func TestAppendRange(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 100; k += 10 {
+		s.Set(k, k/10)
+	}
+	s.MarkDeleted(20)
+	buf := s.AppendRange(nil, 5, 35)
+	assert.Equal(t, []Pair[int, int]{{10, 1}, {30, 3}}, buf)
+	buf = s.AppendRange(buf, 90, 1000)
+	assert.Equal(t, []Pair[int, int]{{10, 1}, {30, 3}, {90, 9}}, buf)
+
+	// reusing the buffer does not allocate
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = s.AppendRange(buf[:0], 0, 100)
+	})
+	assert.Zero(t, allocs)
+	assert.Len(t, buf, 9)
+	assert.Empty(t, s.AppendRange(nil, 50, 40))
+}
@@ -0,0 +1,223 @@
+package skiplist
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"time"
+	"unsafe"
+)
+
+const (
+	// rebuildStep is the number of nodes copied into the replacement by each modifying operation during a
+	// rebuild.
+	rebuildStep = 256
+	// minRebuildJournal is the number of journaled keys which is always allowed before a rebuild starts over.
+	minRebuildJournal = 1024
+)
+
+// Rebuild is a handle of a rebuild started by SkipList.RebuildInBackground().
+type Rebuild[K cmp.Ordered, V any] struct {
+	list    *SkipList[K, V]
+	ctx     context.Context
+	done    chan struct{}
+	b       *builder[K, V]         // replacement under construction
+	deleted int                    // number of soft deleted nodes of the replacement
+	ids     map[uint64]*Node[K, V] // nodes of the replacement by their stable IDs
+	err     error
+	started time.Time
+	cursor  K              // key of the last copied node
+	copying bool           // cursor is valid
+	touched map[K]struct{} // copied keys modified since they were copied
+	before  searchLengths  // of the copied nodes of the list
+	after   searchLengths  // of the replacement
+	memory  [2]int         // memory of the copied nodes of the list and of the replacement
+	report  MaintenanceReport
+	end     func(count int)
+}
+
+// Done returns a channel which is closed when the rebuild is finished, i.e. the replacement is swapped in or
+// the rebuild is canceled.
+func (r *Rebuild[K, V]) Done() <-chan struct{} {
+	return r.done
+}
+
+// RebuildInBackground constructs a replacement of the skip list with an ideal level distribution while the list
+// can still be used. The nodes are copied in steps of a few hundred nodes by the modifying operations, so the
+// list is neither copied nor locked as a whole. Modifications of keys which were already copied are journaled
+// once per key and applied to the replacement, which is then swapped in after the last step. If the journal
+// grows beyond a quarter of the list the copy starts over. Rebuild.Wait() and the maintenance ticks of a
+// ConcurrentSkipList copy the remaining nodes at once. If a rebuild is already running its handle is returned.
+func (s *SkipList[K, V]) RebuildInBackground(ctx context.Context) *Rebuild[K, V] {
+	s.lazyInit()
+	if s.rebuild != nil {
+		return s.rebuild
+	}
+	r := &Rebuild[K, V]{list: s, ctx: ctx, done: make(chan struct{}), started: time.Now()}
+	r.end = s.trace(ctx, "Rebuild", s.count)
+	r.restart()
+	s.rebuild = r
+	return r
+}
+
+// Wait copies the remaining nodes and performs the cutover. It must be called from the goroutine using the
+// skip list. Returns the error of the context if the rebuild was canceled.
+func (r *Rebuild[K, V]) Wait() error {
+	for r.list.rebuild == r {
+		r.step()
+	}
+	<-r.done
+	return r.err
+}
+
+// Report returns a report comparing the skip list while it was copied and the replacement before the
+// modifications made meanwhile were applied. It is available after the cutover (see Wait); the size and the
+// duration include the cutover.
+func (r *Rebuild[K, V]) Report() MaintenanceReport {
+	return r.report
+}
+
+// restart discards the copied nodes and starts the copy from the first node.
+func (r *Rebuild[K, V]) restart() {
+	s := r.list
+	r.b = newBuilder[K, V](s.maxLevel, s.p)
+	r.b.back = s.backPointers
+	r.deleted = 0
+	r.ids = nil
+	if s.stableIDs {
+		r.ids = make(map[uint64]*Node[K, V])
+	}
+	r.copying = false
+	r.touched = make(map[K]struct{})
+	r.before = searchLengths{since: make([]int, s.maxLevel)}
+	r.after = searchLengths{since: make([]int, s.maxLevel)}
+	r.memory = [2]int{}
+}
+
+// step copies the next nodes into the replacement and performs the cutover after the last node. Nodes with a
+// key equal to the last copied one are copied in the same step, so the cursor never splits duplicates.
+func (r *Rebuild[K, V]) step() {
+	s := r.list
+	if err := r.ctx.Err(); err != nil {
+		r.err = err
+		s.rebuild = nil
+		r.end(0)
+		close(r.done)
+		return
+	}
+	x := s.First()
+	if r.copying {
+		x, _ = s.upperBound(r.cursor)
+	}
+	for n := 0; x != nil && (n < rebuildStep || x.key == r.cursor); n++ {
+		y := r.b.append(x.key, x.Value)
+		y.id = x.id
+		y.modified = x.modified
+		y.seq = x.seq
+		if y.deleted = x.deleted; y.deleted {
+			r.deleted++
+		}
+		if r.ids != nil {
+			r.ids[y.id] = y
+		}
+		r.before.add(x.Level())
+		r.after.add(y.Level())
+		r.memory[0] += s.elementMemory(x)
+		r.memory[1] += s.elementMemory(y)
+		r.cursor, r.copying = x.key, true
+		x = x.Next()
+	}
+	if x == nil {
+		s.cutover()
+	}
+}
+
+// pollRebuild copies the next nodes of a running rebuild.
+func (s *SkipList[K, V]) pollRebuild() {
+	if s.rebuild != nil {
+		s.rebuild.step()
+	}
+}
+
+// touched records a modified key for a running rebuild if the key was already copied. The copy starts over if
+// the journal becomes too large to be replayed at once.
+func (s *SkipList[K, V]) touched(key K) {
+	r := s.rebuild
+	if r == nil || !r.copying || s.less(r.cursor, key) {
+		return
+	}
+	r.touched[key] = struct{}{}
+	if len(r.touched) > max(minRebuildJournal, s.count/4) {
+		r.restart()
+	}
+}
+
+// cutover applies the journaled modifications to the finished replacement and swaps it in. The modifications
+// are replayed on a copy of the list without callbacks, since they don't change the contents of the list.
+func (s *SkipList[K, V]) cutover() {
+	r := s.rebuild
+	s.rebuild = nil
+	head, count := r.b.finish()
+	r.report = MaintenanceReport{
+		Operation:          "Rebuild",
+		MemoryBefore:       int(unsafe.Sizeof(*s)) + nodeMemory(s.head) + r.memory[0],
+		MemoryAfter:        int(unsafe.Sizeof(*s)) + nodeMemory(head) + r.memory[1],
+		LevelBefore:        s.Level(),
+		LevelAfter:         head.Level(),
+		SearchLengthBefore: r.before.average(),
+		SearchLengthAfter:  r.after.average(),
+	}
+	replacement := *s
+	replacement.head = head
+	replacement.count = count
+	replacement.deleted = r.deleted
+	replacement.ids = r.ids
+	replacement.interned = maps.Clone(s.interned)
+	replacement.refs = nil
+	replacement.insChain = false
+	replacement.onEvent = nil
+	replacement.watermarks = nil
+	replacement.searchTrace = nil
+	replacement.iterationGuard = false
+	replacement.allocator = nil
+	for key := range r.touched {
+		if s.duplicates {
+			// replace all nodes with an equal key in their current order
+			for x, _ := replacement.Remove(key); x != nil; x, _ = replacement.Remove(key) {
+			}
+			for x, _ := s.Get(key); x != nil && x.key == key; x = x.Next() {
+				y, _, _, _ := replacement.set(key, s.ValueOf(x), x.Value, false)
+				replacement.copyState(y, x)
+			}
+		} else if x, _ := s.Get(key); x != nil {
+			y, _, _, _ := replacement.set(key, s.ValueOf(x), x.Value, false)
+			replacement.copyState(y, x)
+		} else {
+			replacement.Remove(key)
+		}
+	}
+	s.releaseNodes()
+	s.head = replacement.head
+	s.count = replacement.count
+	s.deleted = replacement.deleted
+	s.ids = replacement.ids
+	s.interned = replacement.interned
+	s.nextID = replacement.nextID
+	s.nextSeq = replacement.nextSeq
+	r.end(s.count)
+	r.report.Size = s.count
+	r.report.Duration = time.Since(r.started)
+	close(r.done)
+	s.emit(Event{Type: EventRebuild, Level: s.Level(), Count: s.count, Duration: r.report.Duration})
+}
+
+// copyState copies the soft deleted flag, the stable ID, and the modification time of the node x to the node y
+// of the replacement.
+func (s *SkipList[K, V]) copyState(y, x *Node[K, V]) {
+	s.markDeleted(y, x.deleted)
+	y.modified = x.modified
+	s.setSeq(y, x.seq)
+	if s.stableIDs && y.id != x.id {
+		s.setID(y, x.id)
+	}
+}
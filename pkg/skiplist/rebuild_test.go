@@ -0,0 +1,127 @@
+package skiplist
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildInBackground(t *testing.T) {
+	var events []Event
+	s := NewSkipList[int, int](WithEventHandler(func(e Event) { events = append(events, e) }))
+	for _, k := range makeRandomData(5000) {
+		s.Set(k, k)
+	}
+
+	r := s.RebuildInBackground(context.Background())
+	assert.Same(t, r, s.RebuildInBackground(context.Background()))
+	s.Set(10000, 10000)
+	s.Set(5, -5)
+	s.Remove(10)
+	s.RemoveByPos(0)
+	require.NoError(t, r.Wait())
+	assert.Nil(t, s.rebuild)
+
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 4999, s.Size())
+	x, _ := s.Get(10000)
+	assert.NotNil(t, x)
+	x, _ = s.Get(5)
+	assert.Equal(t, -5, x.Value)
+	x, _ = s.Get(10)
+	assert.Nil(t, x)
+	x, _ = s.Get(0)
+	assert.Nil(t, x)
+	assert.Equal(t, EventRebuild, events[len(events)-1].Type)
+	assert.Equal(t, 4999, events[len(events)-1].Count)
+}
+
+func TestRebuildCanceled(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for _, k := range makeRandomData(5000) {
+		s.Set(k, k)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	first := s.First()
+	r := s.RebuildInBackground(ctx)
+	assert.ErrorIs(t, r.Wait(), context.Canceled)
+	assert.Same(t, first, s.First())
+	require.NoError(t, s.Validate())
+}
+
+func TestRebuildJournal(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 3000; k++ {
+		s.Set(k, k)
+	}
+	r := s.RebuildInBackground(context.Background())
+	for i := 0; i < 5; i++ {
+		s.Set(0, -i)
+	}
+	s.Set(2500, 0) // not copied yet
+	assert.Equal(t, map[int]struct{}{0: {}}, r.touched)
+
+	// the journal exceeding its bound starts the copy over
+	keys := make([]int, 1200)
+	for k := range keys {
+		keys[k] = k
+	}
+	assert.Equal(t, 1200, s.RemoveBatch(keys))
+	assert.False(t, r.copying)
+	assert.Empty(t, r.touched)
+	s.Set(1, 1)
+	require.NoError(t, r.Wait())
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 1801, s.Size())
+	x, _ := s.Get(0)
+	assert.Nil(t, x)
+	x, _ = s.Get(1)
+	assert.NotNil(t, x)
+}
+
+func TestRebuildReplayWithoutCallbacks(t *testing.T) {
+	var events []Event
+	crossings := 0
+	s := NewSkipList[int, int](WithDuplicates(), WithWatermarks(998, 1000, func(int, bool) { crossings++ }),
+		WithEventHandler(func(e Event) { events = append(events, e) }))
+	for k := 0; k < 997; k++ {
+		s.Set(k, k)
+	}
+	for i := 0; i < 3; i++ {
+		s.Set(5, i)
+	}
+	assert.Equal(t, 1, crossings)
+	r := s.RebuildInBackground(context.Background())
+	s.Set(5, 3)
+	events = nil
+	// the replay removes and sets all nodes with the key 5 again
+	require.NoError(t, r.Wait())
+	assert.Equal(t, 1, crossings)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventRebuild, events[0].Type)
+	assert.Equal(t, 1001, s.Size())
+	require.NoError(t, s.Validate())
+}
+
+func TestRebuildReplacedContent(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 1000; k++ {
+		s.Set(k, k)
+	}
+	r := s.RebuildInBackground(context.Background())
+	s.Set(0, 0)
+	s.Load([]Pair[int, int]{{1, 1}, {2000, 2}})
+	require.NoError(t, r.Wait())
+	assert.Equal(t, []int{1, 2000}, slices.Collect(s.Keys()))
+
+	r = s.RebuildInBackground(context.Background())
+	s.Set(1, 1)
+	s.Clear()
+	require.NoError(t, r.Wait())
+	assert.Equal(t, 0, s.Size())
+	require.NoError(t, s.Validate())
+}
@@ -0,0 +1,163 @@
+package skiplist
+
+import (
+	"cmp"
+	"sync"
+)
+
+// Reduce folds fn over all elements with from <= key <= to in ascending key order starting with init. Soft
+// deleted elements are skipped.
+func Reduce[K cmp.Ordered, V any, A any](s *SkipList[K, V], from, to K, init A, fn func(A, K, V) A) A {
+	acc := init
+	x, _ := s.lowerBound(from)
+	for ; x != nil && !s.less(to, x.key); x = x.Next() {
+		if !x.deleted {
+			acc = fn(acc, x.key, s.ValueOf(x))
+		}
+	}
+	return acc
+}
+
+// ReduceParallel folds fn over all elements with from <= key <= to like Reduce, but splits the range by
+// position into `parts` chunks which are folded concurrently. Every chunk starts with init, so init must be
+// the neutral element of merge. The partial results are combined in ascending key order by merge. Soft deleted
+// elements are skipped. The skip list must not be modified until ReduceParallel returns.
+func ReduceParallel[K cmp.Ordered, V any, A any](s *SkipList[K, V], from, to K, init A,
+	fn func(A, K, V) A, merge func(A, A) A, parts int) A {
+	_, begin := s.lowerBound(from)
+	_, end := s.upperBound(to)
+	n := end - begin
+	if n <= 0 {
+		return init
+	}
+	parts = max(1, min(parts, n))
+
+	results := make([]A, parts)
+	var wg sync.WaitGroup
+	for p := 0; p < parts; p++ {
+		lo := begin + p*n/parts
+		hi := begin + (p+1)*n/parts
+		wg.Add(1)
+		go func(p, lo, hi int) {
+			defer wg.Done()
+			acc := init
+			x := s.GetByPos(lo)
+			for i := lo; i < hi; i++ {
+				if !x.deleted {
+					acc = fn(acc, x.key, s.ValueOf(x))
+				}
+				x = x.Next()
+			}
+			results[p] = acc
+		}(p, lo, hi)
+	}
+	wg.Wait()
+
+	acc := results[0]
+	for _, r := range results[1:] {
+		acc = merge(acc, r)
+	}
+	return acc
+}
+
+// GroupBy aggregates all elements of s into groups and returns a new skip list with an element per group. The
+// group of an element is derived by keyFn from its key; the value of a group is folded by agg over its elements
+// in ascending key order starting with the zero value of A. The result list is configured by options.
+//
+// If keyFn is monotonic (e.g. truncating timestamps to minutes) the groups are contiguous and the result is
+// built in a single pass in O(n) with an ideal level distribution. Otherwise the groups are looked up in the
+// result list, which costs O(log(m)) per element for m groups.
+func GroupBy[K cmp.Ordered, V any, K2 cmp.Ordered, A any](s *SkipList[K, V], keyFn func(K) K2,
+	agg func(A, K, V) A, options ...Option) *SkipList[K2, A] {
+	dst := NewSkipList[K2, A](options...)
+	b := newBuilder[K2, A](dst.maxLevel, dst.p)
+	b.back = dst.backPointers
+	var group K2
+	var acc A
+	n := 0
+	x := s.First()
+	for ; x != nil; x = x.Next() {
+		k2 := keyFn(x.key)
+		if n > 0 && k2 != group {
+			if dst.less(k2, group) {
+				break
+			}
+			b.append(group, dst.storeValue(acc))
+			acc = *new(A)
+		}
+		group = k2
+		acc = agg(acc, x.key, s.ValueOf(x))
+		n++
+	}
+	if n > 0 {
+		b.append(group, dst.storeValue(acc))
+	}
+	dst.head, dst.count = b.finish()
+	if dst.stableIDs {
+		dst.assignIDs()
+	}
+	if dst.interned != nil {
+		dst.indexKeys()
+	}
+	if dst.modClock != nil {
+		dst.stampAll()
+	}
+
+	// keyFn is not monotonic: aggregate the remaining elements by lookups
+	for ; x != nil; x = x.Next() {
+		k2 := keyFn(x.key)
+		if y, _ := dst.Get(k2); y != nil {
+			y.Value = dst.storeValue(agg(dst.ValueOf(y), x.key, s.ValueOf(x)))
+			if dst.modClock != nil {
+				dst.stamp(y)
+			}
+		} else {
+			dst.Set(k2, agg(*new(A), x.key, s.ValueOf(x)))
+		}
+	}
+	return dst
+}
+
+// MapValues returns a new skip list with the keys of s and the values transformed by fn. The result has the
+// exact structure of s (see CloneExact) and is built in a single pass in O(n) without any search. Soft deleted
+// elements stay soft deleted. The result list is configured by options, except that it allows duplicates if s
+// does, it has the order of s (see WithDescending), and its maximum level is raised to the level of s if
+// necessary.
+func MapValues[K cmp.Ordered, V any, V2 any](s *SkipList[K, V], fn func(K, V) V2,
+	options ...Option) *SkipList[K, V2] {
+	s.lazyInit()
+	dst := NewSkipList[K, V2](options...)
+	dst.duplicates = dst.duplicates || s.duplicates
+	dst.descending = s.descending
+	dst.maxLevel = max(dst.maxLevel, s.Level())
+	dst.head = dst.newNode(s.head.key, *new(V2), s.Level(), dst.maxLevel)
+	copy(dst.head.dist, s.head.dist)
+	last := make([]*Node[K, V2], s.Level())
+	for i := range last {
+		last[i] = dst.head
+	}
+	for x := s.First(); x != nil; x = x.Next() {
+		y := dst.newNode(x.key, dst.storeValue(fn(x.key, s.ValueOf(x))), x.Level(), x.Level())
+		copy(y.dist, x.dist)
+		y.deleted = x.deleted
+		y.modified = x.modified
+		y.seq = x.seq
+		if dst.backPointers {
+			y.linkPrev(last[0], dst.head)
+		}
+		for i := 0; i < y.Level(); i++ {
+			last[i].next[i] = y
+			last[i] = y
+		}
+	}
+	dst.count = s.count
+	dst.deleted = s.deleted
+	dst.nextSeq = s.nextSeq
+	if dst.stableIDs {
+		dst.assignIDs()
+	}
+	if dst.interned != nil {
+		dst.indexKeys()
+	}
+	return dst
+}
@@ -0,0 +1,94 @@
+package skiplist
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReduce(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for _, k := range makeRandomData(1000) {
+		s.Set(k, 2*k)
+	}
+	sum := func(acc int, _ int, v int) int { return acc + v }
+	add := func(a, b int) int { return a + b }
+
+	expected := 0
+	for k := 100; k <= 200; k++ {
+		expected += 2 * k
+	}
+	assert.Equal(t, expected, Reduce(s, 100, 200, 0, sum))
+	assert.Equal(t, 0, Reduce(s, 2000, 3000, 0, sum))
+
+	for _, parts := range []int{1, 3, 8, 500} {
+		assert.Equal(t, expected, ReduceParallel(s, 100, 200, 0, sum, add, parts))
+	}
+	assert.Equal(t, 0, ReduceParallel(s, 200, 100, 0, sum, add, 4))
+
+	keys := Reduce(s, 10, 13, []int{}, func(acc []int, k int, _ int) []int { return append(acc, k) })
+	assert.Equal(t, []int{10, 11, 12, 13}, keys)
+	keys = ReduceParallel(s, 10, 13, []int{}, func(acc []int, k int, _ int) []int { return append(acc, k) },
+		func(a, b []int) []int { return append(a, b...) }, 3)
+	assert.Equal(t, []int{10, 11, 12, 13}, keys)
+
+	// soft deleted elements are skipped
+	s.MarkDeleted(150)
+	s.MarkDeleted(200)
+	expected -= 2*150 + 2*200
+	assert.Equal(t, expected, Reduce(s, 100, 200, 0, sum))
+	for _, parts := range []int{1, 3, 8, 500} {
+		assert.Equal(t, expected, ReduceParallel(s, 100, 200, 0, sum, add, parts))
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 300; k++ {
+		s.Set(k, 1)
+	}
+	count := func(acc int, _ int, v int) int { return acc + v }
+
+	perMinute := GroupBy(s, func(k int) int { return k / 60 }, count)
+	require.NoError(t, perMinute.Validate())
+	assert.Equal(t, 5, perMinute.Size())
+	for x := perMinute.First(); x != nil; x = x.Next() {
+		assert.Equal(t, 60, x.Value)
+	}
+
+	// a non-monotonic key function
+	parity := GroupBy(s, func(k int) string { return []string{"even", "odd"}[k%2] },
+		func(acc []int, k int, _ int) []int { return append(acc, k) }, WithMaxLevel(4))
+	require.NoError(t, parity.Validate())
+	assert.Equal(t, 2, parity.Size())
+	even, _ := parity.Get("even")
+	assert.Len(t, even.Value, 150)
+	assert.Equal(t, []int{0, 2, 4}, even.Value[:3])
+
+	assert.Equal(t, 0, GroupBy(NewSkipList[int, int](), func(k int) int { return k }, count).Size())
+}
+
+func TestMapValues(t *testing.T) {
+	s := createSkipList(example1)
+	s.MarkDeleted(7)
+	m := MapValues(s, func(k, v int) string { return strconv.Itoa(k * v) }, WithMaxLevel(2), WithStableIDs())
+	require.NoError(t, m.Validate())
+	assert.Equal(t, s.Level(), m.Level())
+	assert.Equal(t, s.Size(), m.Size())
+	assert.Equal(t, s.LiveSize(), m.LiveSize())
+	for x, y := s.First(), m.First(); x != nil; x, y = x.Next(), y.Next() {
+		assert.Equal(t, x.Key(), y.Key())
+		assert.Equal(t, x.Level(), y.Level())
+		assert.Equal(t, strconv.Itoa(x.Key()*x.Value), y.Value)
+		assert.Equal(t, x.Deleted(), y.Deleted())
+	}
+	assert.Equal(t, 3, m.GetByID(1).Key())
+
+	// the source is not modified
+	m.Set(1, "1")
+	x, _ := s.Get(1)
+	assert.Nil(t, x)
+	assert.Equal(t, 0, MapValues(NewSkipList[int, int](), func(k, v int) int { return v }).Size())
+}
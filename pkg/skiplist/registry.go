@@ -0,0 +1,86 @@
+package skiplist
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// ErrAlreadyRegistered is returned by Register if the name is already in use.
+var ErrAlreadyRegistered = errors.New("skiplist: name already registered")
+
+// StatsProvider is implemented by *SkipList and *ConcurrentSkipList of any key and value type.
+type StatsProvider interface {
+	Stats() Stats
+}
+
+var registry = struct {
+	sync.RWMutex
+	lists map[string]StatsProvider
+}{lists: map[string]StatsProvider{}}
+
+// Register adds a list to the package-level registry under `name`, so it appears in AllStats() and the
+// StatsHandler(). Statistics are read from other goroutines, so lists modified concurrently must be
+// registered as *ConcurrentSkipList.
+func Register(name string, list StatsProvider) error {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.lists[name]; ok {
+		return ErrAlreadyRegistered
+	}
+	registry.lists[name] = list
+	return nil
+}
+
+// Unregister removes the list registered under `name`.
+func Unregister(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.lists, name)
+}
+
+// Lists returns the names of all registered lists in ascending order.
+func Lists() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.lists))
+	for name := range registry.lists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the list registered under `name` or nil.
+func Lookup(name string) StatsProvider {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.lists[name]
+}
+
+// AllStats returns the statistics of all registered lists ordered by their names. The name of the
+// statistics is the registered name.
+func AllStats() []Stats {
+	names := Lists()
+	stats := make([]Stats, 0, len(names))
+	for _, name := range names {
+		if list := Lookup(name); list != nil {
+			st := list.Stats()
+			st.Name = name
+			stats = append(stats, st)
+		}
+	}
+	return stats
+}
+
+// StatsHandler returns an http.Handler serving AllStats() as JSON, e.g. for a debug endpoint.
+func StatsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(AllStats()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package skiplist
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	a := NewSkipList[int, int](WithMaxLevel(8))
+	a.Set(1, 1)
+	b := NewConcurrentSkipList[string, string]()
+	b.Set("x", "y")
+	b.Set("z", "y")
+
+	require.NoError(t, Register("b", b))
+	require.NoError(t, Register("a", a))
+	defer Unregister("a")
+	defer Unregister("b")
+	assert.ErrorIs(t, Register("a", a), ErrAlreadyRegistered)
+
+	assert.Equal(t, []string{"a", "b"}, Lists())
+	assert.Same(t, a, Lookup("a"))
+	assert.Nil(t, Lookup("c"))
+
+	stats := AllStats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "a", stats[0].Name)
+	assert.Equal(t, 1, stats[0].Size)
+	assert.Equal(t, 8, stats[0].MaxLevel)
+	assert.Equal(t, 2, stats[1].Size)
+
+	rec := httptest.NewRecorder()
+	StatsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/skiplists", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var served []Stats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	assert.Equal(t, stats, served)
+
+	Unregister("a")
+	assert.Equal(t, []string{"b"}, Lists())
+}
@@ -0,0 +1,122 @@
+package skiplist
+
+import (
+	"cmp"
+	"log"
+	"time"
+)
+
+// retention holds the settings of WithRetention.
+type retention[K cmp.Ordered] struct {
+	maxAge  time.Duration
+	clock   func() time.Time
+	keyTime func(key K) time.Time
+}
+
+// RetentionStats describes the pruning done by the retention policy (see WithRetention).
+type RetentionStats struct {
+	Pruned     int       // total number of pruned elements
+	LastPruned time.Time // time of the last pruning which removed elements
+}
+
+// WithRetention limits the age of the elements for skip lists with time ordered keys. keyTime returns the
+// time of a key; it must be monotonic in the key order. After every insert all elements older than maxAge
+// relative to clock() are removed from the oldest end of the list, which is the front of an ascending and the
+// back of a descending list (see WithDescending). Since every element is pruned once, the
+// costs are amortized over the inserts. A nil clock uses time.Now. An EventTrim is emitted for every
+// pruning which removed elements. Positions returned by the insert do not reflect the pruned elements.
+// The type parameters are inferred from `keyTime`.
+func WithRetention[K cmp.Ordered, V any](maxAge time.Duration, clock func() time.Time,
+	keyTime func(key K) time.Time) Option {
+	if maxAge <= 0 {
+		log.Panic("Parameter maxAge out of range (must be > 0)")
+	}
+	if clock == nil {
+		clock = time.Now
+	}
+	return typedOption(func(s *SkipList[K, V]) {
+		s.retention = &retention[K]{maxAge: maxAge, clock: clock, keyTime: keyTime}
+	})
+}
+
+// RetentionStats returns the pruning statistics of the retention policy (see WithRetention).
+func (s *SkipList[K, V]) RetentionStats() RetentionStats {
+	return s.retentionStats
+}
+
+// WithRetentionJitter extends the retention period (see WithRetention) of every element by a jitter in
+// [0, jitter) derived from a hash of its key, so elements created at the same time do not expire all at once.
+// Since pruning proceeds from the oldest end of the list and stops at the first element not yet expired, the
+// elements behind it may be kept up to `jitter` longer than their own period.
+func WithRetentionJitter(jitter time.Duration) Option {
+	if jitter < 0 {
+		log.Panic("Parameter jitter out of range (must be >= 0)")
+	}
+	return func(c *config) {
+		c.jitter = jitter
+	}
+}
+
+// WithRetentionBatch limits the number of elements removed by a single pruning of the retention policy (see
+// WithRetention) to maxPerTick, which bounds the latency added to an insert. The remaining expired elements
+// are removed by the following prunings; call Prune() periodically if there are few inserts.
+func WithRetentionBatch(maxPerTick int) Option {
+	if maxPerTick <= 0 {
+		log.Panic("Parameter maxPerTick out of range (must be > 0)")
+	}
+	return func(c *config) {
+		c.pruneBatch = maxPerTick
+	}
+}
+
+// Prune removes the expired elements like the pruning after an insert and returns their number. It allows
+// pruning lists with few inserts, e.g. driven by a ticker. Returns 0 without a retention policy.
+func (s *SkipList[K, V]) Prune() int {
+	if s.retention == nil {
+		return 0
+	}
+	return s.prune()
+}
+
+// prune removes the elements older than the retention period and returns their number.
+func (s *SkipList[K, V]) prune() int {
+	if s.retention == nil {
+		return 0
+	}
+	now := s.retention.clock()
+	cutoff := now.Add(-s.retention.maxAge)
+	oldest := s.First
+	if s.descending {
+		oldest = s.Last
+	}
+	n := 0
+	for x := oldest(); x != nil && s.expired(x.key, cutoff); x = oldest() {
+		if s.pruneBatch > 0 && n == s.pruneBatch {
+			break
+		}
+		s.removeSmallest()
+		n++
+	}
+	if n > 0 {
+		s.retentionStats.Pruned += n
+		s.retentionStats.LastPruned = now
+		s.emit(Event{Type: EventTrim, Level: s.Level(), Count: n})
+	}
+	return n
+}
+
+// expired reports whether the time of key (extended by its jitter) is before the cutoff.
+func (s *SkipList[K, V]) expired(key K, cutoff time.Time) bool {
+	t := s.retention.keyTime(key)
+	if s.jitter > 0 {
+		t = t.Add(time.Duration(sipHash(0, 0, appendKey(nil, key)) % uint64(s.jitter)))
+	}
+	return t.Before(cutoff)
+}
+
+// inserted is called after a new element was inserted and applies the policies limiting the size.
+func (s *SkipList[K, V]) inserted() {
+	s.checkMemory()
+	s.prune()
+	s.checkWatermarks()
+}
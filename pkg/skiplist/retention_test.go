@@ -0,0 +1,90 @@
+package skiplist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetention(t *testing.T) {
+	now := time.Unix(1000, 0)
+	clock := func() time.Time { return now }
+	var events []Event
+	s := NewSkipList[int64, string](
+		WithRetention[int64, string](10*time.Second, clock, func(k int64) time.Time { return time.Unix(k, 0) }),
+		WithEventHandler(func(e Event) { events = append(events, e) }),
+	)
+	for k := int64(990); k <= 1000; k++ {
+		s.Set(k, "v")
+	}
+	assert.Equal(t, 11, s.Size())
+	assert.Zero(t, s.RetentionStats().Pruned)
+
+	now = now.Add(5 * time.Second)
+	s.Set(1005, "v")
+	assert.Equal(t, 7, s.Size())
+	assert.Equal(t, int64(995), s.First().Key())
+	assert.Equal(t, RetentionStats{Pruned: 5, LastPruned: now}, s.RetentionStats())
+	assert.Equal(t, Event{Type: EventTrim, Level: s.Level(), Count: 5}, events[len(events)-1])
+
+	// overriding a value does not prune
+	now = now.Add(time.Hour)
+	s.Set(1005, "w")
+	assert.Equal(t, 7, s.Size())
+
+	// an expired key is pruned immediately
+	s.Set(1, "old")
+	assert.Equal(t, 0, s.Size())
+	assert.Equal(t, 13, s.RetentionStats().Pruned)
+
+	assert.Panics(t, func() { WithRetention[int, int](0, nil, func(int) time.Time { return time.Time{} }) })
+}
+
+func TestRetentionBatch(t *testing.T) {
+	now := time.Unix(1000, 0)
+	clock := func() time.Time { return now }
+	s := NewSkipList[int64, string](
+		WithRetention[int64, string](10*time.Second, clock, func(k int64) time.Time { return time.Unix(k, 0) }),
+		WithRetentionBatch(3),
+	)
+	for k := int64(991); k <= 1000; k++ {
+		s.Set(k, "v")
+	}
+	now = now.Add(time.Hour)
+	s.Set(5000, "v")
+	assert.Equal(t, 8, s.Size())
+	assert.Equal(t, 3, s.Prune())
+	assert.Equal(t, 3, s.Prune())
+	assert.Equal(t, 1, s.Prune())
+	assert.Equal(t, 0, s.Prune())
+	assert.Equal(t, 1, s.Size())
+	assert.Equal(t, 0, NewSkipList[int, int]().Prune())
+
+	assert.Panics(t, func() { WithRetentionBatch(0) })
+}
+
+func TestRetentionJitter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	clock := func() time.Time { return now }
+	s := NewSkipList[int64, string](
+		WithRetention[int64, string](10*time.Second, clock, func(k int64) time.Time { return time.Unix(k/1000, 0) }),
+		WithRetentionJitter(10*time.Second),
+	)
+	// 1000 elements created at the same second expire spread over the jitter period
+	for k := int64(0); k < 1000; k++ {
+		s.Set(1000_000+k, "v")
+	}
+	var sizes []int
+	for i := 0; i <= 20; i++ {
+		now = time.Unix(1010, 0).Add(time.Duration(i) * time.Second / 2)
+		s.Prune()
+		sizes = append(sizes, s.Size())
+	}
+	assert.Equal(t, 1000, sizes[0])
+	assert.Less(t, sizes[4], 1000)
+	assert.Greater(t, sizes[16], 0)
+	assert.Equal(t, 0, sizes[20])
+
+	assert.Panics(t, func() { WithRetentionJitter(-1) })
+}
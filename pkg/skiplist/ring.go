@@ -0,0 +1,30 @@
+package skiplist
+
+// LookupOwner treats the keys as points of a consistent hashing ring and returns the node owning the point
+// `hash`: the first node with a key >= hash, wrapping around to the first node if hash is beyond the largest
+// key. Returns nil if the list is empty.
+func (s *SkipList[K, V]) LookupOwner(hash K) *Node[K, V] {
+	if x, _ := s.lowerBound(hash); x != nil {
+		return x
+	}
+	return s.First()
+}
+
+// LookupOwners returns up to n nodes following the point `hash` on the ring like LookupOwner, e.g. the replicas
+// of a key. Every node is returned at most once, so fewer than n nodes are returned if the list is smaller.
+func (s *SkipList[K, V]) LookupOwners(hash K, n int) []*Node[K, V] {
+	n = min(n, s.count)
+	if n <= 0 {
+		return nil
+	}
+	owners := make([]*Node[K, V], 0, n)
+	x, _ := s.lowerBound(hash)
+	for len(owners) < n {
+		if x == nil {
+			x = s.First()
+		}
+		owners = append(owners, x)
+		x = x.Next()
+	}
+	return owners
+}
@@ -0,0 +1,31 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupOwner(t *testing.T) {
+	ring := NewSkipList[uint32, string]()
+	assert.Nil(t, ring.LookupOwner(5))
+	assert.Nil(t, ring.LookupOwners(5, 2))
+	ring.Set(100, "a")
+	ring.Set(200, "b")
+	ring.Set(300, "c")
+
+	assert.Equal(t, "a", ring.LookupOwner(0).Value)
+	assert.Equal(t, "b", ring.LookupOwner(101).Value)
+	assert.Equal(t, "b", ring.LookupOwner(200).Value)
+	assert.Equal(t, "a", ring.LookupOwner(301).Value)
+
+	values := func(nodes []*Node[uint32, string]) []string {
+		var v []string
+		for _, x := range nodes {
+			v = append(v, x.Value)
+		}
+		return v
+	}
+	assert.Equal(t, []string{"c", "a"}, values(ring.LookupOwners(250, 2)))
+	assert.Equal(t, []string{"a", "b", "c"}, values(ring.LookupOwners(350, 5)))
+}
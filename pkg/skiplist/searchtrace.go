@@ -0,0 +1,59 @@
+package skiplist
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"strings"
+)
+
+// WithSearchTrace writes the search path of a sampled fraction `sampleRate` in [0, 1] of the Get, Set, and
+// Remove operations to w, one line per operation. For every level the line contains the position and key
+// of the node where the search went down, followed by the number of key comparisons and the result:
+//
+//	skiplist "prices" Set key=42 path=[L3 -1:head L2 7:30 L1 7:30 L0 9:41] comparisons=8 pos=10 found=false
+//
+// This allows analyzing slow keys in production without a special build. The traced search is done in
+// addition to the operation, so it doubles the costs of sampled operations.
+func WithSearchTrace(w io.Writer, sampleRate float64) Option {
+	if sampleRate < 0.0 || sampleRate > 1.0 {
+		log.Panic("Parameter sampleRate out of range (must be in [0, 1])")
+	}
+	return func(c *config) {
+		c.searchTrace = w
+		c.traceRate = sampleRate
+	}
+}
+
+// traceSearch writes the search path of `key` if the operation is sampled.
+func (s *SkipList[K, V]) traceSearch(op string, key K) {
+	if s.traceRate < 1.0 && rand.Float64() >= s.traceRate {
+		return
+	}
+	var path strings.Builder
+	x := s.head
+	pos := -1
+	comparisons := 0
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			comparisons++
+			if !s.less(x.next[i].key, key) {
+				break
+			}
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+		if path.Len() > 0 {
+			path.WriteByte(' ')
+		}
+		if x == s.head {
+			fmt.Fprintf(&path, "L%d -1:head", i)
+		} else {
+			fmt.Fprintf(&path, "L%d %d:%v", i, pos, x.key)
+		}
+	}
+	found := x.Next() != nil && x.Next().key == key
+	fmt.Fprintf(s.searchTrace, "skiplist %q %s key=%v path=[%s] comparisons=%d pos=%d found=%v\n",
+		s.name, op, key, path.String(), comparisons, pos+1, found)
+}
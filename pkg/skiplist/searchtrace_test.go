@@ -0,0 +1,43 @@
+package skiplist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTrace(t *testing.T) {
+	var buf bytes.Buffer
+	data := []testData{{1, 1, 0}, {2, 3, 1}, {3, 1, 2}}
+	s := NewSkipList[int, int](WithLevelFunc(createPlayBackLevelFunc(data)), WithName("t"),
+		WithSearchTrace(&buf, 1.0))
+	for _, x := range data {
+		s.Set(x.key, x.pos)
+	}
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+	assert.True(t, strings.HasPrefix(buf.String(),
+		`skiplist "t" Set key=1 path=[] comparisons=0 pos=0 found=false`))
+
+	buf.Reset()
+	s.Get(3)
+	assert.Equal(t, `skiplist "t" Get key=3 path=[L2 1:2 L1 1:2 L0 1:2] comparisons=2 pos=2 found=true`+"\n",
+		buf.String())
+
+	buf.Reset()
+	s.Remove(0)
+	assert.Equal(t, `skiplist "t" Remove key=0 path=[L2 -1:head L1 -1:head L0 -1:head] comparisons=3 pos=0 found=false`+"\n",
+		buf.String())
+}
+
+func TestSearchTraceSampling(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewSkipList[int, int](WithSearchTrace(&buf, 0.0))
+	for k := 0; k < 100; k++ {
+		s.Set(k, k)
+	}
+	assert.Zero(t, buf.Len())
+
+	assert.Panics(t, func() { WithSearchTrace(&buf, 1.5) })
+}
@@ -0,0 +1,107 @@
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Collect creates a new skip list configured by options and sets all pairs of seq like maps.Collect, e.g.
+// skiplist.Collect(maps.All(m)). Without duplicates the last value of a key wins.
+func Collect[K cmp.Ordered, V any](seq iter.Seq2[K, V], options ...Option) *SkipList[K, V] {
+	s := NewSkipList[K, V](options...)
+	s.AppendSeq(seq)
+	return s
+}
+
+// AppendSeq sets all pairs of seq like maps.Insert. Pairs rejected by the admission control (see
+// WithAdmissionControl) are skipped. Sorted input is merged faster by MergeSorted.
+func (s *SkipList[K, V]) AppendSeq(seq iter.Seq2[K, V]) {
+	for key, value := range seq {
+		s.Set(key, value)
+	}
+}
+
+// All returns an iterator over the keys and values in ascending order like maps.All, e.g. for
+// maps.Collect(s.All()). Soft deleted elements are skipped (see MarkDeleted) and compressed values are
+// decompressed (see WithValueCodec). The list must not be modified during the iteration.
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.First(); x != nil; x = x.Next() {
+			if !x.deleted && !yield(x.key, s.ValueOf(x)) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys in ascending order like maps.Keys, e.g. for slices.Collect(s.Keys()).
+// Soft deleted elements are skipped. The list must not be modified during the iteration.
+func (s *SkipList[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range s.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in the order of their keys like maps.Values. Soft deleted
+// elements are skipped. The list must not be modified during the iteration.
+func (s *SkipList[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range s.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// backwardChunk is the number of nodes buffered by Backward per position lookup.
+const backwardChunk = 64
+
+// Backward returns an iterator over the keys and values in descending order like slices.Backward. With
+// WithBackPointers it follows the back links from the last node. Otherwise the list is read in chunks of
+// backwardChunk nodes, each found by its position and yielded in reverse, which costs
+// O(n + n/backwardChunk*log(n)) and O(backwardChunk) memory. Soft deleted elements are skipped. The list must
+// not be modified during the iteration.
+func (s *SkipList[K, V]) Backward() iter.Seq2[K, V] {
+	if s.backPointers {
+		return func(yield func(K, V) bool) {
+			for x := s.Last(); x != nil; x = x.Prev() {
+				if !x.deleted && !yield(x.key, s.ValueOf(x)) {
+					return
+				}
+			}
+		}
+	}
+	return func(yield func(K, V) bool) {
+		chunk := make([]*Node[K, V], 0, backwardChunk)
+		for end := s.Size(); end > 0; end -= backwardChunk {
+			chunk = chunk[:0]
+			x := s.GetByPos(max(0, end-backwardChunk))
+			for ; len(chunk) < min(end, backwardChunk); x = x.Next() {
+				chunk = append(chunk, x)
+			}
+			for i := len(chunk) - 1; i >= 0; i-- {
+				if x := chunk[i]; !x.deleted && !yield(x.key, s.ValueOf(x)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// RangeSeq returns an iterator over the keys and values with from <= key <= to in ascending order, the
+// counterpart of Range for range-over-func loops. Soft deleted elements are skipped. The list must not be
+// modified during the iteration.
+func (s *SkipList[K, V]) RangeSeq(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x, _ := s.lowerBound(from); x != nil && !s.less(to, x.key); x = x.Next() {
+			if !x.deleted && !yield(x.key, s.ValueOf(x)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package skiplist
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollect(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+	s := Collect(maps.All(m))
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(s.Keys()))
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(s.Values()))
+	assert.Equal(t, m, maps.Collect(s.All()))
+
+	s.AppendSeq(maps.All(map[string]int{"x": 0, "a": 10}))
+	s.MarkDeleted("b")
+	assert.Equal(t, []string{"a", "c", "x"}, slices.Collect(s.Keys()))
+	assert.Equal(t, []int{10, 3, 0}, slices.Collect(s.Values()))
+
+	d := Collect(pairSeq(5, 5, 7), WithDuplicates())
+	assert.Equal(t, []int{5, 5, 7}, slices.Collect(d.Keys()))
+	n := 0
+	for range d.All() {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+}
+
+func TestBackward(t *testing.T) {
+	for _, options := range [][]Option{nil, {WithBackPointers()}} {
+		s := NewSkipList[int, int](options...)
+		var want []int
+		for k := 0; k < 3*backwardChunk+5; k++ {
+			s.Set(k, k*10)
+			want = append(want, k)
+		}
+		s.MarkDeleted(backwardChunk)
+		want = slices.Delete(want, backwardChunk, backwardChunk+1)
+		slices.Reverse(want)
+		var keys []int
+		for key, value := range s.Backward() {
+			assert.Equal(t, key*10, value)
+			keys = append(keys, key)
+		}
+		assert.Equal(t, want, keys)
+
+		keys = keys[:0]
+		for key := range s.Backward() {
+			if keys = append(keys, key); len(keys) == 3 {
+				break
+			}
+		}
+		assert.Equal(t, want[:3], keys)
+		assert.Empty(t, maps.Collect(NewSkipList[int, int](options...).Backward()))
+	}
+}
+
+func TestRangeSeq(t *testing.T) {
+	s := Collect(pairSeq(1, 3, 5, 7, 9))
+	s.MarkDeleted(5)
+	var keys []int
+	for key, value := range s.RangeSeq(2, 7) {
+		assert.Equal(t, key*10, value)
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []int{3, 7}, keys)
+	assert.Empty(t, maps.Collect(s.RangeSeq(10, 20)))
+}
@@ -0,0 +1,156 @@
+package skiplist
+
+import "math"
+
+// sequenceGap is the distance of neighboring labels after relabeling a Sequence.
+const sequenceGap = 1 << 32
+
+// Sequence is a list with the method set of container/list (Front, Back, PushFront, PushBack, InsertBefore,
+// InsertAfter, MoveToFront, ...) on top of a skip list, easing the migration of code written against
+// container/list. Besides the list operations it provides the index of an element and the element at an index
+// in O(log(n)). The elements are nodes keyed by labels which keep the order of the sequence; a new element gets
+// a label between its neighbors. If two neighbors have no free label in between, all elements are relabeled
+// in O(n), which happens at most once per 32 inserts at the same place.
+type Sequence[V any] struct {
+	list *SkipList[uint64, V]
+}
+
+// NewSequence creates an empty Sequence.
+func NewSequence[V any]() *Sequence[V] {
+	return &Sequence[V]{list: NewSkipList[uint64, V](WithBackPointers())}
+}
+
+// Len returns the number of elements.
+func (q *Sequence[V]) Len() int {
+	return q.list.Size()
+}
+
+// Front returns the first element or nil if the sequence is empty.
+func (q *Sequence[V]) Front() *Node[uint64, V] {
+	return q.list.Min()
+}
+
+// Back returns the last element or nil if the sequence is empty.
+func (q *Sequence[V]) Back() *Node[uint64, V] {
+	return q.list.Max()
+}
+
+// Prev returns the element before e or nil if e is the first element.
+func (q *Sequence[V]) Prev(e *Node[uint64, V]) *Node[uint64, V] {
+	return e.Prev()
+}
+
+// At returns the element at index i or nil if i is out of range.
+func (q *Sequence[V]) At(i int) *Node[uint64, V] {
+	return q.list.GetByPos(i)
+}
+
+// Index returns the index of e or InvalidPos if e is not contained in the sequence.
+func (q *Sequence[V]) Index(e *Node[uint64, V]) int {
+	if x, pos := q.list.Get(e.key); x == e {
+		return pos
+	}
+	return InvalidPos
+}
+
+// PushFront inserts `value` at the front and returns its element.
+func (q *Sequence[V]) PushFront(value V) *Node[uint64, V] {
+	return q.insert(nil, q.Front(), value)
+}
+
+// PushBack inserts `value` at the back and returns its element.
+func (q *Sequence[V]) PushBack(value V) *Node[uint64, V] {
+	return q.insert(q.Back(), nil, value)
+}
+
+// InsertBefore inserts `value` directly before `mark` and returns its element or nil if mark is not contained
+// in the sequence.
+func (q *Sequence[V]) InsertBefore(value V, mark *Node[uint64, V]) *Node[uint64, V] {
+	if q.Index(mark) == InvalidPos {
+		return nil
+	}
+	return q.insert(q.Prev(mark), mark, value)
+}
+
+// InsertAfter inserts `value` directly behind `mark` and returns its element or nil if mark is not contained
+// in the sequence.
+func (q *Sequence[V]) InsertAfter(value V, mark *Node[uint64, V]) *Node[uint64, V] {
+	if q.Index(mark) == InvalidPos {
+		return nil
+	}
+	return q.insert(mark, mark.Next(), value)
+}
+
+// Remove removes e and returns its value. The sequence is not modified if e is not contained in it.
+func (q *Sequence[V]) Remove(e *Node[uint64, V]) V {
+	if q.Index(e) != InvalidPos {
+		q.list.Remove(e.key)
+	}
+	return e.Value
+}
+
+// MoveToFront moves e to the front and returns the element replacing e, or nil if e is not contained in the
+// sequence. Unlike container/list the moved value gets a new element.
+func (q *Sequence[V]) MoveToFront(e *Node[uint64, V]) *Node[uint64, V] {
+	if q.Index(e) == InvalidPos {
+		return nil
+	}
+	q.list.Remove(e.key)
+	return q.PushFront(e.Value)
+}
+
+// MoveToBack moves e to the back and returns the element replacing e like MoveToFront.
+func (q *Sequence[V]) MoveToBack(e *Node[uint64, V]) *Node[uint64, V] {
+	if q.Index(e) == InvalidPos {
+		return nil
+	}
+	q.list.Remove(e.key)
+	return q.PushBack(e.Value)
+}
+
+// insert inserts `value` between the neighbors prev and next, nil at the ends of the sequence.
+func (q *Sequence[V]) insert(prev, next *Node[uint64, V], value V) *Node[uint64, V] {
+	label, ok := sequenceLabel(prev, next)
+	if !ok {
+		q.relabel()
+		label, _ = sequenceLabel(prev, next)
+	}
+	x, _, _ := q.list.Set(label, value)
+	return x
+}
+
+// sequenceLabel returns a free label between the neighbors prev and next or false if there is none.
+func sequenceLabel[V any](prev, next *Node[uint64, V]) (uint64, bool) {
+	switch {
+	case prev == nil && next == nil:
+		return 1 << 63, true
+	case prev == nil && next.key >= sequenceGap:
+		return next.key - sequenceGap, true
+	case next == nil && prev.key <= math.MaxUint64-sequenceGap:
+		return prev.key + sequenceGap, true
+	}
+	lo, hi := uint64(0), uint64(math.MaxUint64)
+	if prev != nil {
+		lo = prev.key
+	}
+	if next != nil {
+		hi = next.key
+	}
+	if hi-lo < 2 {
+		return 0, false
+	}
+	return lo + (hi-lo)/2, true
+}
+
+// relabel spreads the labels evenly by sequenceGap around the middle of the label space. The order of the
+// labels is kept, so the skip list stays valid.
+func (q *Sequence[V]) relabel() {
+	s := q.list
+	s.ensureOwned()
+	label := uint64(1<<63) - uint64(s.count/2)*sequenceGap
+	for x := s.First(); x != nil; x = x.Next() {
+		x.key = label
+		label += sequenceGap
+	}
+	s.version++
+}
@@ -0,0 +1,61 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sequenceValues(q *Sequence[string]) []string {
+	var values []string
+	for e := q.Front(); e != nil; e = e.Next() {
+		values = append(values, e.Value)
+	}
+	return values
+}
+
+func TestSequence(t *testing.T) {
+	q := NewSequence[string]()
+	assert.Nil(t, q.Front())
+	assert.Nil(t, q.Back())
+	b := q.PushBack("b")
+	q.PushFront("a")
+	d := q.PushBack("d")
+	c := q.InsertBefore("c", d)
+	q.InsertAfter("e", d)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, sequenceValues(q))
+	assert.Equal(t, 5, q.Len())
+	assert.Equal(t, "a", q.Front().Value)
+	assert.Equal(t, "e", q.Back().Value)
+	assert.Equal(t, 2, q.Index(c))
+	assert.Same(t, b, q.Prev(c))
+	assert.Same(t, d, q.At(3))
+
+	assert.Equal(t, "b", q.Remove(b))
+	assert.Equal(t, InvalidPos, q.Index(b))
+	assert.Nil(t, q.InsertAfter("x", b))
+	d = q.MoveToFront(d)
+	assert.Equal(t, []string{"d", "a", "c", "e"}, sequenceValues(q))
+	q.MoveToBack(d)
+	assert.Equal(t, []string{"a", "c", "e", "d"}, sequenceValues(q))
+}
+
+func TestSequenceRelabel(t *testing.T) {
+	q := NewSequence[string]()
+	a := q.PushBack("a")
+	z := q.PushBack("z")
+	// every insert between the same neighbors halves the gap, forcing relabels
+	for i := 0; i < 100; i++ {
+		z = q.InsertBefore("y", z)
+		q.PushFront("<")
+		q.InsertAfter("b", a)
+	}
+	require.NoError(t, q.list.Validate())
+	assert.Equal(t, 302, q.Len())
+	assert.Equal(t, "<", q.Front().Value)
+	assert.Equal(t, "z", q.Back().Value)
+	assert.Equal(t, 100, q.Index(a))
+	assert.Equal(t, "b", q.At(101).Value)
+	assert.Equal(t, "y", q.At(201).Value)
+}
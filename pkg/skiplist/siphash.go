@@ -0,0 +1,121 @@
+package skiplist
+
+import (
+	"cmp"
+	crand "crypto/rand"
+	"encoding/binary"
+	"log"
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+// WithHashedLevels derives the level of each key from a SipHash-2-4 of the key keyed by `secret` instead of
+// drawing it randomly. An attacker who does not know the secret can neither predict the tower heights nor
+// force a degenerated structure by replaying operations, whereas the structure stays deterministic for the
+// same secret. Use WithRandomHashedLevels for a random secret per list.
+func WithHashedLevels(secret [16]byte) Option {
+	return func(c *config) {
+		c.hashSecret = &secret
+	}
+}
+
+// WithRandomHashedLevels is WithHashedLevels with a secret read from crypto/rand.
+func WithRandomHashedLevels() Option {
+	var secret [16]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		log.Panic("Reading a random secret failed: ", err)
+	}
+	return WithHashedLevels(secret)
+}
+
+// hashedLevelFunc returns a function deriving the level of a key from its SipHash.
+func hashedLevelFunc[K cmp.Ordered](secret [16]byte, p float64, maxLevel int) func(key K) int {
+	k0 := binary.LittleEndian.Uint64(secret[:8])
+	k1 := binary.LittleEndian.Uint64(secret[8:])
+	return func(key K) int {
+		return hashLevel(sipHash(k0, k1, appendKey(nil, key)), p, maxLevel)
+	}
+}
+
+// hashLevel draws a level with the probability p from the bits of the hash h.
+func hashLevel(h uint64, p float64, maxLevel int) int {
+	level := 1
+	for level < maxLevel {
+		if float64(h>>11)/(1<<53) >= p {
+			break
+		}
+		level++
+		// splitmix64 step to obtain the next uniform value
+		h += 0x9e3779b97f4a7c15
+		z := h
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		h = z ^ (z >> 31)
+	}
+	return level
+}
+
+// appendKey appends a binary representation of an ordered key to b.
+func appendKey[K cmp.Ordered](b []byte, key K) []byte {
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return append(b, v.String()...)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.LittleEndian.AppendUint64(b, uint64(v.Int()))
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Float()))
+	default:
+		return binary.LittleEndian.AppendUint64(b, v.Uint())
+	}
+}
+
+// sipHash computes SipHash-2-4 of m with the key (k0, k1).
+func sipHash(k0, k1 uint64, m []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	n := len(m)
+	for ; len(m) >= 8; m = m[8:] {
+		w := binary.LittleEndian.Uint64(m)
+		v3 ^= w
+		round()
+		round()
+		v0 ^= w
+	}
+	var last [8]byte
+	copy(last[:], m)
+	last[7] = byte(n)
+	w := binary.LittleEndian.Uint64(last[:])
+	v3 ^= w
+	round()
+	round()
+	v0 ^= w
+
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+	return v0 ^ v1 ^ v2 ^ v3
+}
@@ -0,0 +1,43 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSipHash(t *testing.T) {
+	// test vectors of the SipHash reference implementation: key 00 01 ... 0f, message 00 01 ... (n-1)
+	k0 := uint64(0x0706050403020100)
+	k1 := uint64(0x0f0e0d0c0b0a0908)
+	msg := make([]byte, 15)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	assert.Equal(t, uint64(0x726fdb47dd0e0e31), sipHash(k0, k1, msg[:0]))
+	assert.Equal(t, uint64(0x74f839c593dc67fd), sipHash(k0, k1, msg[:1]))
+	assert.Equal(t, uint64(0x93f5f5799a932462), sipHash(k0, k1, msg[:8]))
+	assert.Equal(t, uint64(0xa129ca6149be45e5), sipHash(k0, k1, msg[:15]))
+}
+
+func TestHashedLevels(t *testing.T) {
+	secret := [16]byte{1, 2, 3}
+	a := NewSkipList[int, int](WithHashedLevels(secret))
+	b := NewSkipList[int, int](WithHashedLevels(secret))
+	for _, k := range makeRandomData(500) {
+		a.Set(k, k)
+	}
+	for k := 0; k < 500; k++ {
+		b.Set(k, k)
+	}
+	// the structure only depends on the keys, not on the order of the operations
+	assert.Equal(t, levels(a), levels(b))
+	assert.NoError(t, a.Validate())
+	assert.Greater(t, a.Level(), 3)
+
+	c := NewSkipList[string, int](WithRandomHashedLevels())
+	for _, k := range []string{"a", "b", "c"} {
+		c.Set(k, 0)
+	}
+	assert.NoError(t, c.Validate())
+}
@@ -2,9 +2,13 @@ package skiplist
 
 import (
 	"cmp"
+	"context"
 	"fmt"
+	"io"
 	"log"
-	"math/rand"
+	"math/rand/v2"
+	"time"
+	"unique"
 )
 
 type LevelFunc func(p float64, maxLevel int) int
@@ -27,65 +31,165 @@ func defaultLevelFunc(p float64, maxLevel int) int {
 // It allows in addition to the standard key operations SkipList.Set(), SkipList.Get(), and SkipList.Remove()
 // the indexed linear list operations SkipList.GetByPos() and SkipList.RemoveByPos().
 // There are two generic parameters K is the key, which must be cmp.Ordered policy, and the value V can be of any type.
+//
+// The zero value is an empty skip list with the default options, so a SkipList can be embedded by value. It is
+// initialized by its first use; like a modification, this first call must not run concurrently with others.
 type SkipList[K cmp.Ordered, V any] struct {
-	p         float64     // probability for increasing the level of the skip list
-	maxLevel  int         // maximum levels of the skip list
-	count     int         // count is the number of elements in the skip list
-	levelFunc LevelFunc   // function for generating a random level
-	head      *Node[K, V] // the head node of the skip list
+	config
+	count          int            // count is the number of elements in the skip list
+	head           *Node[K, V]    // the head node of the skip list
+	refs           *int32         // number of lists sharing the nodes, nil if the nodes are not shared (see Snapshot)
+	version        uint64         // incremented by every structural modification
+	rebuild        *Rebuild[K, V] // running background rebuild or nil
+	admit          func(key K, value V, currentSize int) error
+	keyBounds      *[2]K                  // inclusive minimum and maximum key or nil (see WithKeyBounds)
+	keyLevelFunc   func(key K) int        // derives the level from the key instead of levelFunc if not nil
+	interned       map[K]unique.Handle[K] // handles of the interned keys or nil (see WithKeyInterning)
+	onPressure     func(s *SkipList[K, V])
+	inPressure     bool
+	aboveWatermark bool          // the high watermark was crossed (see WithWatermarks)
+	retention      *retention[K] // retention policy or nil (see WithRetention)
+	retentionStats RetentionStats
+	deleted        int                        // number of soft deleted nodes
+	sizer          func(key K, value V) int   // referenced memory of elements (see WithSizer)
+	iterators      map[*Iterator[K, V]]string // open guarded iterators and their creation sites
+	allocator      Allocator[K, V]            // allocator of the nodes or nil for the heap
+	ids            map[uint64]*Node[K, V]     // nodes by their stable IDs (see WithStableIDs)
+	copier         func(V) V                  // copies values passed in and out or nil (see WithValueCopier)
+	codec          *valueCodec[V]             // compresses the stored values or nil (see WithValueCodec)
+	tieBreak       func(a, b V) bool          // orders the values of equal keys or nil (see WithTieBreak)
+	compression    CompressionStats
+	nextID         uint64 // last assigned stable ID
+	nextSeq        uint64 // last assigned insertion sequence number
+	insFirst       *Node[K, V]
+	insLast        *Node[K, V]
+	insChain       bool                  // insFirst and insLast link all nodes in insertion order
+	requests       *requestWindow        // recently applied request IDs (see SetIdempotent)
+	reserved       *ArenaAllocator[K, V] // nodes reserved for the heap allocator or nil (see Reserve)
+	paths          *searchPaths[K, V]    // reusable update paths of inserts or nil (see Reserve)
 }
 
-type skipListOption[K cmp.Ordered, V any] func(*SkipList[K, V])
+// config holds the settings of a skip list which do not depend on the key and value types.
+type config struct {
+	p              float64   // probability for increasing the level of the skip list
+	maxLevel       int       // maximum levels of the skip list
+	levelFunc      LevelFunc // function for generating a random level
+	onEvent        EventHandler
+	tracer         Tracer           // tracer of bulk and slow operations
+	autoRepair     bool             // repair detected inconsistencies (see WithAutoRepair)
+	hashSecret     *[16]byte        // secret for deriving levels from keys (see WithHashedLevels)
+	memBudget      int              // memory budget in bytes, 0 if unlimited
+	duplicates     bool             // allow multiple nodes with equal keys
+	name           string           // name of the list used in profiles and diagnostics
+	searchTrace    io.Writer        // destination of sampled search traces or nil (see WithSearchTrace)
+	traceRate      float64          // fraction of traced operations
+	iterationGuard bool             // detect modifications while iterating (see WithIterationGuard)
+	onViolation    func(error)      // receives detected violations, panics if nil
+	stableIDs      bool             // assign stable IDs to the nodes (see WithStableIDs)
+	jitter         time.Duration    // maximum extension of the retention period per key (see WithRetentionJitter)
+	pruneBatch     int              // maximum number of elements pruned per pruning or 0 for no limit
+	modClock       func() time.Time // clock of the modification times or nil (see WithModTimes)
+	descending     bool             // order the keys descending (see WithDescending)
+	watermarks     *watermarks      // size thresholds or nil (see WithWatermarks)
+	insertionOrder bool             // maintain the insertion order (see WithInsertionOrder)
+	requestWindow  int              // number of remembered request IDs (see WithIdempotencyWindow)
+	internKeys     bool             // intern string keys (see WithKeyInterning)
+	backPointers   bool             // maintain the backward links of the nodes (see WithBackPointers)
+	typed          []any            // options depending on the key and value types, see typedOption
+}
+
+// Option configures a skip list created by NewSkipList. Options do not carry the key and value types, so
+// they can be written without type parameters and the same options can be shared between skip lists of
+// different types:
+//
+//	options := []skiplist.Option{skiplist.WithMaxLevel(16), skiplist.WithProbability(0.25)}
+//	a := skiplist.NewSkipList[int, string](options...)
+//	b := skiplist.NewSkipList[string, float64](options...)
+type Option func(*config)
+
+// typedOption returns an Option which is only applicable to skip lists with key K and value V.
+// Type parameters of such options can be usually inferred from function arguments.
+func typedOption[K cmp.Ordered, V any](apply func(s *SkipList[K, V])) Option {
+	return func(c *config) {
+		c.typed = append(c.typed, apply)
+	}
+}
 
 // WithLevelFunc adds a custom function for generating the level of each inserted element in the list.
-func WithLevelFunc[K cmp.Ordered, V any](levelFunc LevelFunc) skipListOption[K, V] {
-	return func(s *SkipList[K, V]) {
-		s.levelFunc = levelFunc
+func WithLevelFunc(levelFunc LevelFunc) Option {
+	return func(c *config) {
+		c.levelFunc = levelFunc
 	}
 }
 
 // WithMaxLevel overrides the DefaultMaxLevel.
-func WithMaxLevel[K cmp.Ordered, V any](maxLevel int) skipListOption[K, V] {
+func WithMaxLevel(maxLevel int) Option {
 	if maxLevel < 1 || maxLevel > MaxLevel {
 		log.Panic("Parameter maxLevel out of range (must be >=1 and <= MaxLevel)")
 	}
-	return func(s *SkipList[K, V]) {
-		s.maxLevel = maxLevel
+	return func(c *config) {
+		c.maxLevel = maxLevel
 	}
 }
 
 // WithProbability overrides the DefaultProbability.
-func WithProbability[K cmp.Ordered, V any](prob float64) skipListOption[K, V] {
+func WithProbability(prob float64) Option {
 	if prob < 0.01 || prob > 0.99 {
 		log.Panic("Parameter probability out of range (must be >= 0.01 and <= 0.99)")
 	}
-	return func(s *SkipList[K, V]) {
-		s.p = prob
+	return func(c *config) {
+		c.p = prob
 	}
 }
 
 // NewSkipList creates a new empty SkipList object.
-func NewSkipList[K cmp.Ordered, V any](options ...skipListOption[K, V]) *SkipList[K, V] {
+func NewSkipList[K cmp.Ordered, V any](options ...Option) *SkipList[K, V] {
+	return new(SkipList[K, V]).Init(options...)
+}
+
+// Init initializes the skip list as an empty list configured by options, e.g. a SkipList embedded by value
+// which shall not use the default options. All elements are dropped. Returns s.
+func (s *SkipList[K, V]) Init(options ...Option) *SkipList[K, V] {
 	var dummyKey K
 	var dummyValue V
-	s := &SkipList[K, V]{
-		p:         DefaultProbability,
-		maxLevel:  DefaultMaxLevel,
-		count:     0,
-		levelFunc: defaultLevelFunc,
+	if s.head != nil {
+		s.releaseNodes()
+	}
+	*s = SkipList[K, V]{
+		config: config{
+			p:         DefaultProbability,
+			maxLevel:  DefaultMaxLevel,
+			levelFunc: defaultLevelFunc,
+		},
+		count: 0,
 	}
 
 	for _, opt := range options {
-		opt(s)
+		opt(&s.config)
+	}
+	for _, t := range s.typed {
+		apply, ok := t.(func(s *SkipList[K, V]))
+		if !ok {
+			log.Panicf("Option %T is not applicable to %T", t, s)
+		}
+		apply(s)
+	}
+	s.typed = nil
+	if s.hashSecret != nil {
+		s.keyLevelFunc = hashedLevelFunc[K](*s.hashSecret, s.p, s.maxLevel)
+	}
+	if s.internKeys {
+		s.interned = newInternMap[K](0)
 	}
 
-	s.head = newNode[K, V](dummyKey, dummyValue, 0, s.maxLevel)
+	s.head = s.newNode(dummyKey, dummyValue, 0, s.maxLevel)
 	return s
 }
 
 // First returns the first node of a skip list or nil if the list is empty. With the Node.Next() function
 // the list can be iterated.
 func (s *SkipList[K, V]) First() *Node[K, V] {
+	s.lazyInit()
 	return s.head.Next()
 }
 
@@ -95,80 +199,160 @@ func (s *SkipList[K, V]) Size() int {
 }
 
 func (s *SkipList[K, V]) Level() int {
+	s.lazyInit()
 	return s.head.Level()
 }
 
-func (s *SkipList[K, V]) randomLevel() int {
+// lazyInit initializes a zero value SkipList with the default options.
+func (s *SkipList[K, V]) lazyInit() {
+	if s.head == nil {
+		s.Init()
+	}
+}
+
+func (s *SkipList[K, V]) randomLevel(key K) int {
+	if s.keyLevelFunc != nil {
+		return s.keyLevelFunc(key)
+	}
 	return s.levelFunc(s.p, s.maxLevel)
 
 }
 
 // Set sets the value `value` of a key `key` within the skip list.
 // Replaces the value if the key was already added to the set or inserts the key if not.
+// If duplicates are allowed (see WithDuplicates) a new node is always inserted behind all nodes with an equal key
+// (or ordered by the tie-break of WithTieBreak).
 // Returns a reference to the node and its current position 0...n-1 within the skip list.
 // The bool value is true, if a new node was created and false if the value was overridden.
+// If the element is rejected by the admission control (see WithAdmissionControl), nil, InvalidPos, and false
+// are returned. Use TrySet to receive the reason of the rejection.
 func (s *SkipList[K, V]) Set(key K, value V) (*Node[K, V], int, bool) {
-	update := make([]*Node[K, V], s.Level(), s.maxLevel)
-	updatePos := make([]int, s.Level(), s.maxLevel)
-	x := s.head
-	pos := -1 // the head has position -1, the first element 0
-	for i := s.Level() - 1; i >= 0; i-- {
-		for x.next[i] != nil && cmp.Less(x.next[i].key, key) {
-			pos += x.dist[i]
-			x = x.next[i]
+	x, pos, created, _ := s.trySet(key, value, false)
+	return x, pos, created
+}
+
+// TrySet is like Set but consults the admission control (see WithAdmissionControl) before and returns its
+// error if the element is rejected. If an inconsistency of the structure is detected and not repaired (see
+// WithAutoRepair), an error wrapping ErrCorrupted is returned and the list is not modified.
+func (s *SkipList[K, V]) TrySet(key K, value V) (*Node[K, V], int, bool, error) {
+	return s.trySet(key, value, true)
+}
+
+// trySet implements Set and TrySet. If strict is set, a detected inconsistency is returned as an error.
+func (s *SkipList[K, V]) trySet(key K, value V, strict bool) (*Node[K, V], int, bool, error) {
+	if pprofLabels {
+		defer restoreLabels(s.setLabels("Set"))
+	}
+	if s.searchTrace != nil {
+		s.traceSearch("Set", key)
+	}
+	if err := s.admission(key, value); err != nil {
+		return nil, InvalidPos, false, err
+	}
+	x, pos, created, err := s.set(key, value, s.storeValue(value), strict)
+	if err != nil {
+		return nil, InvalidPos, false, err
+	}
+	if created {
+		s.inserted()
+	}
+	return x, pos, created, nil
+}
+
+// set inserts or overrides the element with `key` and the stored value `value`. raw is the value as passed in,
+// which is compared by the tie-break (see precedes).
+func (s *SkipList[K, V]) set(key K, raw, value V, strict bool) (*Node[K, V], int, bool, error) {
+	s.lazyInit()
+	s.pollRebuild()
+	s.touched(key)
+	s.ensureOwned()
+	update, updatePos, reserved := s.takePaths()
+	if reserved {
+		defer s.putPaths()
+	}
+	// with duplicates the new node is inserted behind all nodes with an equal key (see precedes)
+	// the head has position -1, the first element 0
+	x, pos := descend(s.head, func(y *Node[K, V]) bool { return s.precedes(y, key, raw) }, update, updatePos)
+	if pos >= s.count {
+		if err := s.corrupted("Set"); err == nil {
+			return s.set(key, raw, value, strict)
+		} else if strict {
+			return nil, InvalidPos, false, err
 		}
-		update[i] = x
-		updatePos[i] = pos
 	}
-	if len(x.next) > 0 && x.next[0] != nil && x.next[0].key == key {
+	if !s.duplicates && len(x.next) > 0 && x.next[0] != nil && x.next[0].key == key {
 		// key already exists: override value
 		x = x.next[0]
 		x.Value = value
-		return x, pos, false
+		s.markDeleted(x, false)
+		if s.modClock != nil {
+			s.stamp(x)
+		}
+		return x, pos + 1, false, nil
 	}
 
 	// now x.key shall be smaller than key
-	newLevel := s.randomLevel()
+	x = s.insert(update, updatePos, pos, key, value)
+	return x, pos + 1, true, nil
+}
+
+// insert links a new node behind the node at position `pos`. update and updatePos hold the rightmost nodes
+// on each level with a position <= pos and their positions.
+func (s *SkipList[K, V]) insert(update []*Node[K, V], updatePos []int, pos int, key K, value V) *Node[K, V] {
+	if s.iterationGuard {
+		s.checkIterators("insert")
+	}
+	if debugChecks && len(update) > 0 {
+		s.checkInsertion(update[0], key)
+	}
+	newLevel := s.randomLevel(key)
 
 	if newLevel > s.Level() {
-		update = update[:newLevel]
-		updatePos = updatePos[:newLevel]
-		oldLevel := s.Level()
-		s.head.extendLevel(newLevel)
-		for i := oldLevel; i < newLevel; i++ {
-			update[i] = s.head
-			updatePos[i] = -1
-			s.head.dist[i] = s.Size() + 1
-		}
+		update, updatePos = growHead(s.head, update, updatePos, newLevel, s.count)
+		s.emit(Event{Type: EventLevelGrow, Level: newLevel})
 	}
-	x = newNode[K, V](key, value, newLevel, newLevel)
-	for i := 0; i < s.Level(); i++ {
-		if i >= newLevel {
-			update[i].dist[i]++
-		} else {
-			x.next[i] = update[i].next[i]
-			update[i].next[i] = x
-			delta := pos - updatePos[i]
-			x.dist[i] = update[i].dist[i] - delta
-			update[i].dist[i] = delta + 1
-		}
+	if s.interned != nil {
+		key = s.intern(key)
+	}
+	x := s.newNode(key, value, newLevel, newLevel)
+	if s.stableIDs {
+		s.newID(x)
+	}
+	if s.modClock != nil {
+		s.stamp(x)
+	}
+	if s.insertionOrder {
+		s.appendInsertion(x)
+	}
+	linkNode(s.head, update, updatePos, pos, x)
+	if s.backPointers {
+		x.linkBack(update[0], s.head)
 	}
 
 	s.count++
+	s.version++
 
-	return x, pos + 1, true
+	return x
 }
 
 // InvalidPos is returned, when an element is not found within the skip list.
 const InvalidPos = -1
 
-// Get returns the node matching the searched key or nil if it was not found. The second return argument is the
+// Get returns the node matching the searched key or nil if it was not found. With duplicates the first
+// node with an equal key is returned. The second return argument is the
 // position 0...n-1 of the key or InvalidPos if the element was not found.
 func (s *SkipList[K, V]) Get(key K) (*Node[K, V], int) {
+	s.lazyInit()
+	if pprofLabels {
+		defer restoreLabels(s.setLabels("Get"))
+	}
+	if s.searchTrace != nil {
+		s.traceSearch("Get", key)
+	}
 	x := s.head
 	pos := -1
 	for i := s.Level() - 1; i >= 0; i-- {
-		for x.next[i] != nil && x.next[i].key < key {
+		for x.next[i] != nil && s.less(x.next[i].key, key) {
 			pos += x.dist[i]
 			x = x.next[i]
 		}
@@ -183,64 +367,107 @@ func (s *SkipList[K, V]) Get(key K) (*Node[K, V], int) {
 	return nil, InvalidPos
 }
 
-// GetByPos returns the kth element of the skip list where k must be in the interval [0, Size()).
-// This operation is performed in O(log(n)) steps in the average due to the maintenance of the
-// distance vectors within each element.
-// Returns a node pointer to the element.
-func (s *SkipList[K, V]) GetByPos(k int) *Node[K, V] {
-	if k < 0 || k >= s.count {
+// GetCopy returns a copy of the value stored for `key` and true, or the zero value and false if the key
+// was not found. The value is copied by the function registered by WithValueCopier, if any, or decompressed
+// (see WithValueCodec).
+func (s *SkipList[K, V]) GetCopy(key K) (V, bool) {
+	x, _ := s.Get(key)
+	if x == nil {
+		var zero V
+		return zero, false
+	}
+	if s.codec == nil && s.copier != nil {
+		return s.copier(x.Value), true
+	}
+	return s.ValueOf(x), true
+}
+
+// GetRef returns a pointer to the value stored for `key` for in-place mutation or nil if the key was not
+// found. Nodes shared with a snapshot are copied before, so mutations are not visible in the snapshot.
+// The pointer becomes invalid when the key is removed.
+func (s *SkipList[K, V]) GetRef(key K) *V {
+	s.ensureOwned()
+	x, _ := s.Get(key)
+	if x == nil {
 		return nil
 	}
+	return &x.Value
+}
+
+// lowerBound returns the first node with a key >= `key` and its position 0...n-1. If all keys are smaller,
+// nil and the position Size() are returned.
+func (s *SkipList[K, V]) lowerBound(key K) (*Node[K, V], int) {
+	s.lazyInit()
 	x := s.head
 	pos := -1
 	for i := s.Level() - 1; i >= 0; i-- {
-		for x.next[i] != nil && pos+x.dist[i] <= k {
+		for x.next[i] != nil && s.less(x.next[i].key, key) {
 			pos += x.dist[i]
 			x = x.next[i]
 		}
 	}
+	return x.Next(), pos + 1
+}
+
+// upperBound returns the first node with a key > `key` and its position 0...n-1. If no key is greater,
+// nil and the position Size() are returned.
+func (s *SkipList[K, V]) upperBound(key K) (*Node[K, V], int) {
+	s.lazyInit()
+	x := s.head
+	pos := -1
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && !s.less(key, x.next[i].key) {
+			pos += x.dist[i]
+			x = x.next[i]
+		}
+	}
+	return x.Next(), pos + 1
+}
+
+// GetByPos returns the kth element of the skip list where k must be in the interval [0, Size()).
+// This operation is performed in O(log(n)) steps in the average due to the maintenance of the
+// distance vectors within each element.
+// Returns a node pointer to the element.
+func (s *SkipList[K, V]) GetByPos(k int) *Node[K, V] {
+	if pprofLabels {
+		defer restoreLabels(s.setLabels("GetByPos"))
+	}
+	if k < 0 || k >= s.count {
+		return nil
+	}
+	x, pos := descendByPos(s.head, k+1, nil, nil)
+	if pos != k && s.corrupted("GetByPos") == nil {
+		return s.GetByPos(k)
+	}
 
 	return x
 }
 
-// Remove removes an element with key `key` from the skip list.
+// Remove removes an element with key `key` from the skip list. With duplicates the first node with an
+// equal key is removed.
 // Returns a reference to the removed element and its position 0...n-1 before it was removed.
 func (s *SkipList[K, V]) Remove(key K) (*Node[K, V], int) {
+	s.lazyInit()
+	if pprofLabels {
+		defer restoreLabels(s.setLabels("Remove"))
+	}
+	if s.searchTrace != nil {
+		s.traceSearch("Remove", key)
+	}
+	s.pollRebuild()
+	s.touched(key)
+	s.ensureOwned()
 	update := make([]*Node[K, V], s.Level(), s.maxLevel)
 	updatePos := make([]int, s.Level(), s.maxLevel)
-	x := s.head
-	pos := -1 // the head has position -1, the first element 0
-	for i := s.Level() - 1; i >= 0; i-- {
-		for x.next[i] != nil && cmp.Less(x.next[i].key, key) {
-			pos += x.dist[i]
-			x = x.next[i]
-		}
-		update[i] = x
-		updatePos[i] = pos
+	x, pos := descend(s.head, func(y *Node[K, V]) bool { return s.less(y.key, key) }, update, updatePos)
+	if pos >= s.count && s.corrupted("Remove") == nil {
+		return s.Remove(key)
 	}
 	if len(x.next) > 0 && x.next[0] != nil && x.next[0].key == key {
 		// key found
 		x = x.next[0]
 		pos++
-
-		// remove node from list
-		for i := 0; i < s.Level(); i++ {
-			if update[i].next[i] == x {
-				update[i].next[i] = x.next[i]
-				update[i].dist[i] += x.dist[i] - 1
-			} else {
-				update[i].dist[i]--
-			}
-		}
-
-		// adapt level
-		newLevel := s.Level()
-		for newLevel > 0 && s.head.next[newLevel-1] == nil {
-			newLevel--
-		}
-		s.head.shrinkLevel(newLevel)
-		s.count--
-
+		s.unlink(update, x)
 		return x, pos
 	}
 	return nil, InvalidPos
@@ -249,44 +476,81 @@ func (s *SkipList[K, V]) Remove(key K) (*Node[K, V], int) {
 // Remove removes an element at position k [0, Size()) from the skip list.
 // Returns a reference to the removed element.
 func (s *SkipList[K, V]) RemoveByPos(k int) *Node[K, V] {
+	if pprofLabels {
+		defer restoreLabels(s.setLabels("RemoveByPos"))
+	}
 	if k < 0 || k >= s.count {
 		return nil
 	}
+	s.pollRebuild()
+	s.ensureOwned()
 
 	update := make([]*Node[K, V], s.Level(), s.maxLevel)
 	updatePos := make([]int, s.Level(), s.maxLevel)
-	x := s.head
-	pos := -1 // the head has position -1, the first element 0
-
-	for i := s.Level() - 1; i >= 0; i-- {
-		for x.next[i] != nil && pos+x.dist[i] < k {
-			pos += x.dist[i]
-			x = x.next[i]
+	x, pos := descendByPos(s.head, k, update, updatePos)
+	if pos+1 != k || x.Next() == nil {
+		if s.corrupted("RemoveByPos") == nil {
+			return s.RemoveByPos(k)
+		}
+		if x.Next() == nil {
+			return nil
 		}
-		update[i] = x
-		updatePos[i] = pos
 	}
 	// remove node from list
 	pos++
 	x = x.Next()
-	for i := 0; i < s.Level(); i++ {
-		if update[i].next[i] == x {
-			update[i].next[i] = x.next[i]
-			update[i].dist[i] += x.dist[i] - 1
-		} else {
-			update[i].dist[i]--
-		}
+	s.touched(x.key)
+	s.unlink(update, x)
+	return x
+}
+
+// RemoveBelow removes all elements with a key < `key` from the front of the skip list, e.g. to drop expired
+// entries of a time ordered list. Returns the number of removed elements.
+func (s *SkipList[K, V]) RemoveBelow(key K) int {
+	end := s.trace(context.Background(), "RemoveBelow", s.count)
+	n := 0
+	for x := s.First(); x != nil && s.less(x.key, key); x = s.First() {
+		s.RemoveByPos(0)
+		n++
+	}
+	end(n)
+	return n
+}
+
+// unlink removes the node x from the list. update holds the rightmost nodes on each level before x.
+func (s *SkipList[K, V]) unlink(update []*Node[K, V], x *Node[K, V]) {
+	if s.iterationGuard {
+		s.checkIterators("remove")
+	}
+	unlinkNode(s.head, update, x)
+	if s.backPointers {
+		x.unlinkBack()
+	}
+	if s.interned != nil {
+		s.releaseKey(x.key, update[0], x.next[0])
 	}
 
-	// adapt level
-	newLevel := s.Level()
-	for newLevel > 0 && s.head.next[newLevel-1] == nil {
-		newLevel--
+	if x.deleted {
+		s.deleted--
+	}
+	if s.stableIDs {
+		delete(s.ids, x.id)
+	}
+	if s.insertionOrder {
+		s.unlinkInsertion(x)
 	}
-	s.head.shrinkLevel(newLevel)
+	s.adaptLevel()
 	s.count--
+	s.version++
+	s.freeNode(x)
+	s.checkWatermarks()
+}
 
-	return x
+// adaptLevel shrinks the level of the head to the highest level still in use.
+func (s *SkipList[K, V]) adaptLevel() {
+	if shrinkHead(s.head) {
+		s.emit(Event{Type: EventLevelShrink, Level: s.Level()})
+	}
 }
 
 func (s *SkipList[K, V]) String() string {
@@ -56,7 +56,7 @@ func createPlayBackLevelFunc(data []testData) LevelFunc {
 }
 
 func createSkipList(data []testData) *SkipList[int, int] {
-	s := NewSkipList[int, int](WithLevelFunc[int, int](createPlayBackLevelFunc(data)))
+	s := NewSkipList[int, int](WithLevelFunc(createPlayBackLevelFunc(data)))
 
 	for i, x := range example1 {
 		s.Set(x.key, i)
@@ -67,7 +67,7 @@ func createSkipList(data []testData) *SkipList[int, int] {
 
 func TestGetByPosWithFixed(t *testing.T) {
 	data := example1
-	s := NewSkipList[int, int](WithLevelFunc[int, int](createPlayBackLevelFunc(data)))
+	s := NewSkipList[int, int](WithLevelFunc(createPlayBackLevelFunc(data)))
 
 	fmt.Print(s.String())
 	for i, x := range data {
@@ -88,7 +88,7 @@ func TestGetByPosWithFixed2(t *testing.T) {
 	data := make([]testData, len(example2))
 	copy(data, example2)
 	Shuffle(data)
-	s := NewSkipList[int, int](WithLevelFunc[int, int](createPlayBackLevelFunc(data)))
+	s := NewSkipList[int, int](WithLevelFunc(createPlayBackLevelFunc(data)))
 
 	fmt.Print(s.String())
 	for i, x := range data {
@@ -241,3 +241,92 @@ func TestGetRemoveByPos(t *testing.T) {
 		break
 	}
 }
+
+func TestRemoveBelow(t *testing.T) {
+	s := NewSkipList[int, int](WithDuplicates())
+	for _, k := range []int{1, 2, 2, 3, 5, 8} {
+		s.Set(k, k)
+	}
+	assert.Equal(t, 0, s.RemoveBelow(1))
+	assert.Equal(t, 3, s.RemoveBelow(3))
+	assert.Equal(t, []int{3, 5, 8}, valuesOf(s))
+	assert.Equal(t, 3, s.RemoveBelow(100))
+	assert.Equal(t, 0, s.Size())
+	require.NoError(t, s.Validate())
+}
+
+func TestGetCopyGetRef(t *testing.T) {
+	s := NewSkipList[int, []int]()
+	s.Set(1, []int{1})
+
+	v, ok := s.GetCopy(1)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1}, v)
+	_, ok = s.GetCopy(2)
+	assert.False(t, ok)
+	assert.Nil(t, s.GetRef(2))
+
+	snap := s.Snapshot()
+	ref := s.GetRef(1)
+	*ref = append(*ref, 2)
+	v, _ = s.GetCopy(1)
+	assert.Equal(t, []int{1, 2}, v)
+	v, _ = snap.GetCopy(1)
+	assert.Equal(t, []int{1}, v)
+}
+
+func TestSharedOptions(t *testing.T) {
+	options := []Option{WithMaxLevel(4), WithProbability(0.25), WithSeed(1)}
+	a := NewSkipList[int, string](options...)
+	b := NewSkipList[string, float64](options...)
+	assert.Equal(t, 4, a.maxLevel)
+	assert.Equal(t, 0.25, b.p)
+
+	admit := WithAdmissionControl(func(int, string, int) error { return nil })
+	assert.NotPanics(t, func() { NewSkipList[int, string](admit) })
+	assert.Panics(t, func() { NewSkipList[int, int](admit) })
+}
+
+func TestWithName(t *testing.T) {
+	assert.Equal(t, "orders", NewSkipList[int, int](WithName("orders")).Name())
+	assert.Equal(t, "", NewSkipList[int, int]().Name())
+}
+
+func TestZeroValue(t *testing.T) {
+	var s SkipList[int, string]
+	assert.Equal(t, 0, s.Size())
+	assert.Nil(t, s.First())
+	x, pos := s.Get(1)
+	assert.Nil(t, x)
+	assert.Equal(t, InvalidPos, pos)
+	s.Set(2, "b")
+	s.Set(1, "a")
+	require.NoError(t, s.Validate())
+	assert.Equal(t, "a", s.First().Value)
+	assert.Equal(t, 2, s.First().Next().Key())
+	x, _ = s.Remove(1)
+	require.NotNil(t, x)
+	assert.Equal(t, 1, s.Size())
+
+	type cache struct {
+		entries SkipList[string, int]
+	}
+	var c cache
+	it := c.entries.Iterator()
+	assert.False(t, it.Next())
+	c.entries.Set("x", 1)
+	assert.Equal(t, 1, c.entries.Size())
+}
+
+func TestInit(t *testing.T) {
+	var s SkipList[int, int]
+	s.Set(1, 1)
+	s.Init(WithMaxLevel(2), WithDuplicates())
+	assert.Equal(t, 0, s.Size())
+	for i := 0; i < 100; i++ {
+		s.Set(i%10, i)
+	}
+	assert.Equal(t, 100, s.Size())
+	assert.LessOrEqual(t, s.Level(), 2)
+	require.NoError(t, s.Validate())
+}
@@ -0,0 +1,109 @@
+package skiplist
+
+import (
+	"context"
+	"maps"
+	"sync/atomic"
+)
+
+// Snapshot returns a copy of the skip list in O(1). The snapshot and the original share their nodes until
+// one of them is modified: the first modifying operation (Set, Remove, RemoveByPos) on either list copies
+// the nodes it owns, so all following writes are not visible in the other list.
+//
+// The copy of the first modification is a full copy of all nodes in O(n), not an incremental copy of the
+// touched towers tagged by generations: every node is reachable through the level 0 link of its predecessor,
+// so a node can only be shared if all nodes before it are shared as well, and copying the towers on the search
+// path degrades to copying the prefix of the list up to the written position. Appends, the common write of
+// time ordered lists, would copy the whole list anyway. The first write after a snapshot stalls for the copy
+// (about 0.35s for 1M and 1.1s for 5M int elements on a current x86 machine), all following writes don't copy.
+//
+// The copy is avoided if the snapshot is released before the next write: Clear() on the snapshot drops its
+// references to the shared nodes, e.g. after a backup has been written from it:
+//
+//	snap := s.Snapshot()
+//	err := backup(snap)
+//	snap.Clear() // the next write of s does not copy
+//
+// Node references obtained before the snapshot point into the shared structure. Modifying Node.Value through
+// such a reference changes the value in both lists.
+func (s *SkipList[K, V]) Snapshot() *SkipList[K, V] {
+	s.lazyInit()
+	if s.refs == nil {
+		s.refs = new(int32)
+		*s.refs = 1
+	}
+	atomic.AddInt32(s.refs, 1)
+	snap := *s
+	snap.rebuild = nil
+	snap.iterators = nil
+	snap.requests = nil
+	snap.reserved = nil
+	snap.paths = nil
+	return &snap
+}
+
+// ensureOwned must be called before modifying the structure. It copies the shared nodes if other
+// lists created by Snapshot() still reference them.
+func (s *SkipList[K, V]) ensureOwned() {
+	if s.refs == nil {
+		return
+	}
+	if atomic.LoadInt32(s.refs) > 1 {
+		end := s.trace(context.Background(), "SnapshotCopy", s.count)
+		s.head = s.copyNodes()
+		s.insChain = false
+		if s.stableIDs {
+			s.indexIDs()
+		}
+		if s.interned != nil {
+			s.interned = maps.Clone(s.interned)
+		}
+		s.version++
+		end(s.count)
+		atomic.AddInt32(s.refs, -1)
+	}
+	s.refs = nil
+}
+
+// copyNodes returns a new head of an exact copy of all nodes preserving levels and distances.
+func (s *SkipList[K, V]) copyNodes() *Node[K, V] {
+	head := s.newNode(s.head.key, s.head.Value, s.Level(), s.maxLevel)
+	copy(head.dist, s.head.dist)
+	last := make([]*Node[K, V], s.Level())
+	for i := range last {
+		last[i] = head
+	}
+	for x := s.First(); x != nil; x = x.Next() {
+		y := s.newNode(x.key, x.Value, x.Level(), x.Level())
+		copy(y.dist, x.dist)
+		y.deleted = x.deleted
+		y.id = x.id
+		y.modified = x.modified
+		y.seq = x.seq
+		if s.backPointers {
+			y.linkPrev(last[0], head)
+		}
+		for i := 0; i < y.Level(); i++ {
+			last[i].next[i] = y
+			last[i] = y
+		}
+	}
+	return head
+}
+
+// releaseNodes drops the reference to the current nodes before the head is replaced. A running rebuild
+// starts over, since the replaced content is not journaled.
+func (s *SkipList[K, V]) releaseNodes() {
+	if s.iterationGuard {
+		s.checkIterators("replace")
+	}
+	if s.rebuild != nil {
+		s.rebuild.restart()
+	}
+	if s.refs != nil {
+		atomic.AddInt32(s.refs, -1)
+		s.refs = nil
+	}
+	s.insChain = false
+	s.version++
+}
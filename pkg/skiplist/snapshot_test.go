@@ -0,0 +1,87 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireSameStructure(t *testing.T, expected, actual *SkipList[int, int]) {
+	require.Equal(t, expected.Size(), actual.Size())
+	require.Equal(t, expected.Level(), actual.Level())
+	require.Equal(t, expected.head.dist, actual.head.dist)
+	for x, y := expected.First(), actual.First(); x != nil; x, y = x.Next(), y.Next() {
+		require.NotNil(t, y)
+		require.Equal(t, x.Key(), y.Key())
+		require.Equal(t, x.Value, y.Value)
+		require.Equal(t, x.dist, y.dist)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for _, k := range makeRandomData(100) {
+		s.Set(k, k)
+	}
+
+	snap := s.Snapshot()
+	assert.Same(t, s.First(), snap.First())
+
+	s.Set(1000, 1000)
+	s.Set(0, -1)
+	s.Remove(50)
+	assert.NotSame(t, s.First(), snap.First())
+
+	assert.Equal(t, 100, snap.Size())
+	x, _ := snap.Get(0)
+	assert.Equal(t, 0, x.Value)
+	x, _ = snap.Get(50)
+	assert.NotNil(t, x)
+	x, _ = snap.Get(1000)
+	assert.Nil(t, x)
+
+	x, _ = s.Get(0)
+	assert.Equal(t, -1, x.Value)
+	assert.Equal(t, 100, s.Size())
+
+	// the snapshot owns the original nodes now and may be written without copying
+	first := snap.First()
+	snap.Set(0, -2)
+	assert.Same(t, first, snap.First())
+	x, _ = s.Get(0)
+	assert.Equal(t, -1, x.Value)
+}
+
+func TestSnapshotCopyStructure(t *testing.T) {
+	s := createSkipList(example1)
+	snap := s.Snapshot()
+	snap.RemoveByPos(3)
+
+	c := NewSkipList[int, int]()
+	c.head = s.copyNodes()
+	c.count = s.count
+	requireSameStructure(t, s, c)
+}
+
+func TestSnapshotClearReleases(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 100; k++ {
+		s.Set(k, k)
+	}
+	first := s.First()
+	a, b := s.Snapshot(), s.Snapshot()
+	a.Clear()
+	assert.Equal(t, 0, a.Size())
+	s.Set(100, 0)
+	assert.NotSame(t, first, s.First(), "b still shares the nodes")
+	assert.Equal(t, 100, b.Size())
+
+	first = s.First()
+	c := s.Snapshot()
+	c.Clear()
+	s.Set(101, 0)
+	assert.Same(t, first, s.First())
+	require.NoError(t, s.Validate())
+	assert.Equal(t, 102, s.Size())
+}
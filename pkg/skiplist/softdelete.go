@@ -0,0 +1,67 @@
+package skiplist
+
+// MarkDeleted soft deletes the element with `key` (with duplicates the first one). A soft deleted element stays
+// in the skip list and keeps its position, so Get, GetByPos, and Size still see it, but it is skipped by
+// iterators (see IncludeDeleted) and not counted by LiveSize. Setting the key again or Undelete restore the
+// element, Purge removes all soft deleted elements. Returns false if the key was not found.
+func (s *SkipList[K, V]) MarkDeleted(key K) bool {
+	return s.setDeleted(key, true)
+}
+
+// Undelete restores an element soft deleted by MarkDeleted. Returns false if the key was not found.
+func (s *SkipList[K, V]) Undelete(key K) bool {
+	return s.setDeleted(key, false)
+}
+
+// LiveSize returns the number of elements which are not soft deleted.
+func (s *SkipList[K, V]) LiveSize() int {
+	return s.count - s.deleted
+}
+
+// Purge removes all soft deleted elements and returns their number.
+func (s *SkipList[K, V]) Purge() int {
+	if s.deleted == 0 {
+		return 0
+	}
+	var positions []int
+	pos := 0
+	for x := s.First(); x != nil; x = x.Next() {
+		if x.deleted {
+			positions = append(positions, pos)
+		}
+		pos++
+	}
+	// remove from the back so the positions in front stay valid
+	for i := len(positions) - 1; i >= 0; i-- {
+		s.RemoveByPos(positions[i])
+	}
+	return len(positions)
+}
+
+func (s *SkipList[K, V]) setDeleted(key K, deleted bool) bool {
+	s.pollRebuild()
+	s.ensureOwned()
+	x, _ := s.Get(key)
+	if x == nil {
+		return false
+	}
+	s.touched(key)
+	if x.deleted != deleted && s.modClock != nil {
+		s.stamp(x)
+	}
+	s.markDeleted(x, deleted)
+	return true
+}
+
+// markDeleted sets the soft deleted flag of the node x and maintains the number of deleted nodes.
+func (s *SkipList[K, V]) markDeleted(x *Node[K, V], deleted bool) {
+	if x.deleted == deleted {
+		return
+	}
+	x.deleted = deleted
+	if deleted {
+		s.deleted++
+	} else {
+		s.deleted--
+	}
+}
@@ -0,0 +1,85 @@
+package skiplist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	assert.True(t, s.MarkDeleted(3))
+	assert.True(t, s.MarkDeleted(3))
+	assert.True(t, s.MarkDeleted(0))
+	assert.True(t, s.MarkDeleted(9))
+	assert.False(t, s.MarkDeleted(42))
+	assert.Equal(t, 10, s.Size())
+	assert.Equal(t, 7, s.LiveSize())
+
+	x, pos := s.Get(3)
+	assert.True(t, x.Deleted())
+	assert.Equal(t, 3, pos)
+
+	var keys, positions []int
+	for it := s.Iterator(); it.Next(); {
+		keys = append(keys, it.Node().Key())
+		positions = append(positions, it.Pos())
+	}
+	assert.Equal(t, []int{1, 2, 4, 5, 6, 7, 8}, keys)
+	assert.Equal(t, []int{1, 2, 4, 5, 6, 7, 8}, positions)
+	assert.Equal(t, 10, len(collectKeys(s.Iterator(IncludeDeleted()))))
+	assert.Equal(t, []int{2, 4}, collectKeys(s.IteratorRange(2, 5, Limit(2))))
+
+	assert.True(t, s.Undelete(9))
+	s.Set(0, 100)
+	assert.False(t, s.First().Deleted())
+	assert.Equal(t, 9, s.LiveSize())
+
+	snap := s.Snapshot()
+	assert.Equal(t, 1, s.Purge())
+	assert.Equal(t, 9, s.Size())
+	assert.Equal(t, 9, s.LiveSize())
+	x, _ = s.Get(3)
+	assert.Nil(t, x)
+	assert.Equal(t, 0, s.Purge())
+	require.NoError(t, s.Validate())
+
+	// the snapshot keeps the soft deleted element
+	assert.Equal(t, 10, snap.Size())
+	assert.Equal(t, 9, snap.LiveSize())
+}
+
+func TestSoftDeleteRemove(t *testing.T) {
+	s := NewSkipList[int, int]()
+	s.Set(1, 1)
+	s.MarkDeleted(1)
+	s.Remove(1)
+	assert.Equal(t, 0, s.LiveSize())
+}
+
+func TestSoftDeleteRebuildAndCompact(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 100; k++ {
+		s.Set(k, k)
+	}
+	s.MarkDeleted(10)
+	s.MarkDeleted(20)
+	r := s.RebuildInBackground(context.Background())
+	s.MarkDeleted(30)
+	s.Undelete(10)
+	require.NoError(t, r.Wait())
+	assert.Equal(t, 98, s.LiveSize())
+	for k, deleted := range map[int]bool{10: false, 20: true, 30: true, 40: false} {
+		x, _ := s.Get(k)
+		assert.Equal(t, deleted, x.Deleted(), "key %d", k)
+	}
+
+	c := s.Compact()
+	assert.Equal(t, 98, c.LiveSize())
+	assert.Equal(t, 2, c.Purge())
+}
@@ -0,0 +1,54 @@
+package skiplist
+
+// WithStableIDs assigns every element a unique ID (monotonically increasing from 1), which is returned by
+// Node.ID() and can be resolved by SkipList.GetByID(). Unlike node pointers the IDs survive Compact,
+// RebuildInBackground, and copies caused by snapshots, so external systems can use them as durable
+// references. Load assigns new IDs. The IDs are resolved by a map costing about 50 bytes per element.
+func WithStableIDs() Option {
+	return func(c *config) {
+		c.stableIDs = true
+	}
+}
+
+// ID returns the stable ID of the node (see WithStableIDs) or 0 if stable IDs are not enabled.
+func (n *Node[K, V]) ID() uint64 {
+	return n.id
+}
+
+// GetByID returns the node with the stable ID `id` (see WithStableIDs) or nil if there is no such node.
+func (s *SkipList[K, V]) GetByID(id uint64) *Node[K, V] {
+	return s.ids[id]
+}
+
+// newID assigns a new ID to a node inserted into the list.
+func (s *SkipList[K, V]) newID(x *Node[K, V]) {
+	s.nextID++
+	s.setID(x, s.nextID)
+}
+
+// setID assigns the ID `id` to a node of the list.
+func (s *SkipList[K, V]) setID(x *Node[K, V], id uint64) {
+	if s.ids == nil {
+		s.ids = make(map[uint64]*Node[K, V])
+	}
+	delete(s.ids, x.id)
+	x.id = id
+	s.ids[id] = x
+}
+
+// indexIDs rebuilds the ID map after the nodes were replaced by copies keeping their IDs. The map may be
+// shared with a snapshot, so it is not modified but replaced.
+func (s *SkipList[K, V]) indexIDs() {
+	s.ids = make(map[uint64]*Node[K, V], s.count)
+	for x := s.First(); x != nil; x = x.Next() {
+		s.ids[x.id] = x
+	}
+}
+
+// assignIDs assigns new IDs to all nodes after the content was replaced.
+func (s *SkipList[K, V]) assignIDs() {
+	s.ids = make(map[uint64]*Node[K, V], s.count)
+	for x := s.First(); x != nil; x = x.Next() {
+		s.newID(x)
+	}
+}
@@ -0,0 +1,67 @@
+package skiplist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStableIDs(t *testing.T) {
+	s := NewSkipList[int, int](WithStableIDs())
+	ids := map[int]uint64{}
+	for k := 0; k < 100; k++ {
+		x, _, _ := s.Set(k, k)
+		ids[k] = x.ID()
+	}
+	assert.Equal(t, uint64(1), ids[0])
+	assert.Equal(t, uint64(100), ids[99])
+	assert.Equal(t, 50, s.GetByID(ids[50]).Key())
+
+	s.Remove(50)
+	assert.Nil(t, s.GetByID(ids[50]))
+	x, _, _ := s.Set(50, 0)
+	assert.Equal(t, uint64(101), x.ID())
+
+	// IDs survive copies of the nodes shared with a snapshot
+	snap := s.Snapshot()
+	s.Set(1000, 1000)
+	assert.Equal(t, 10, s.GetByID(ids[10]).Key())
+	assert.NotSame(t, snap.GetByID(ids[10]), s.GetByID(ids[10]))
+	assert.Nil(t, snap.GetByID(102))
+	assert.Equal(t, 1000, s.GetByID(102).Key())
+
+	// IDs survive compaction
+	s = s.Compact()
+	for k, id := range ids {
+		if k != 50 {
+			require.NotNil(t, s.GetByID(id))
+			assert.Equal(t, k, s.GetByID(id).Key())
+		}
+	}
+	x, _, _ = s.Set(2000, 0)
+	assert.Equal(t, uint64(103), x.ID())
+
+	// without the option no IDs are assigned
+	x, _, _ = NewSkipList[int, int]().Set(1, 1)
+	assert.Equal(t, uint64(0), x.ID())
+}
+
+func TestStableIDsRebuild(t *testing.T) {
+	s := NewSkipList[int, int](WithStableIDs())
+	for k := 0; k < 1000; k++ {
+		s.Set(k, k)
+	}
+	id := s.GetByPos(10).ID()
+	r := s.RebuildInBackground(context.Background())
+	s.Remove(20)
+	s.Set(5000, 0)
+	added, _ := s.Get(5000)
+	require.NoError(t, r.Wait())
+	assert.Equal(t, 10, s.GetByID(id).Key())
+	assert.Same(t, s.GetByPos(10), s.GetByID(id))
+	assert.Equal(t, 5000, s.GetByID(added.ID()).Key())
+	assert.Nil(t, s.GetByID(21))
+	require.NoError(t, s.Validate())
+}
@@ -0,0 +1,117 @@
+package skiplist
+
+import (
+	"cmp"
+	"time"
+)
+
+// Stats summarizes the state of a skip list for monitoring.
+type Stats struct {
+	Name            string  `json:"name"`
+	Size            int     `json:"size"`
+	Level           int     `json:"level"`
+	MaxLevel        int     `json:"maxLevel"`
+	Probability     float64 `json:"probability"`
+	EstimatedMemory int     `json:"estimatedMemory"`
+}
+
+// Stats returns the current statistics of the skip list.
+func (s *SkipList[K, V]) Stats() Stats {
+	return Stats{
+		Name:            s.name,
+		Size:            s.count,
+		Level:           s.Level(),
+		MaxLevel:        s.maxLevel,
+		Probability:     s.p,
+		EstimatedMemory: s.EstimatedMemory(),
+	}
+}
+
+// Stats returns the current statistics of the skip list.
+func (c *ConcurrentSkipList[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.list.Stats()
+}
+
+// BucketCounts counts the elements per bucket in one pass over the skip list, e.g. per tenant or per day.
+// bucketizer maps each key to the name of its bucket.
+func (s *SkipList[K, V]) BucketCounts(bucketizer func(key K) string) map[string]int {
+	counts := map[string]int{}
+	for x := s.First(); x != nil; x = x.Next() {
+		counts[bucketizer(x.key)]++
+	}
+	return counts
+}
+
+// AverageSearchLength returns the average number of links followed by Get to reach an element, which measures
+// how well the levels are distributed: an ideal distribution for the probability p needs about
+// log(n)/(p*log(1/p)) links. It is computed in one pass in O(n*L).
+func (s *SkipList[K, V]) AverageSearchLength() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	l := searchLengths{since: make([]int, s.Level())}
+	for x := s.First(); x != nil; x = x.Next() {
+		l.add(x.Level())
+	}
+	return l.average()
+}
+
+// searchLengths sums up the search lengths of the nodes of a list in their order.
+type searchLengths struct {
+	// since[i] counts the nodes of level i+1 passed since the last node of a higher level, which are exactly the
+	// links followed on level i by the search of the next node
+	since []int
+	total int
+	n     int
+}
+
+// add adds the next node with the level h.
+func (l *searchLengths) add(h int) {
+	for _, n := range l.since {
+		l.total += n
+	}
+	l.total++ // the final step to the node itself
+	for i := 0; i < h-1; i++ {
+		l.since[i] = 0
+	}
+	l.since[h-1]++
+	l.n++
+}
+
+// average returns the average search length of the added nodes.
+func (l *searchLengths) average() float64 {
+	if l.n == 0 {
+		return 0
+	}
+	return float64(l.total) / float64(l.n)
+}
+
+// MaintenanceReport compares a skip list before and after a maintenance operation like Compact or
+// RebuildInBackground, so the benefit of maintenance windows can be measured.
+type MaintenanceReport struct {
+	Operation          string        `json:"operation"`
+	Size               int           `json:"size"`
+	MemoryBefore       int           `json:"memoryBefore"` // see MemoryUsage
+	MemoryAfter        int           `json:"memoryAfter"`
+	LevelBefore        int           `json:"levelBefore"`
+	LevelAfter         int           `json:"levelAfter"`
+	SearchLengthBefore float64       `json:"searchLengthBefore"` // see AverageSearchLength
+	SearchLengthAfter  float64       `json:"searchLengthAfter"`
+	Duration           time.Duration `json:"duration"`
+}
+
+// newMaintenanceReport measures the lists before and after the operation `op`.
+func newMaintenanceReport[K cmp.Ordered, V any](op string, before, after *SkipList[K, V]) MaintenanceReport {
+	return MaintenanceReport{
+		Operation:          op,
+		Size:               after.count,
+		MemoryBefore:       before.MemoryUsage(),
+		MemoryAfter:        after.MemoryUsage(),
+		LevelBefore:        before.Level(),
+		LevelAfter:         after.Level(),
+		SearchLengthBefore: before.AverageSearchLength(),
+		SearchLengthAfter:  after.AverageSearchLength(),
+	}
+}
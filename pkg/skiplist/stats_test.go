@@ -0,0 +1,95 @@
+package skiplist
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	s := NewSkipList[int, int](WithName("s"), WithProbability(0.25))
+	for k := 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	st := s.Stats()
+	assert.Equal(t, "s", st.Name)
+	assert.Equal(t, 10, st.Size)
+	assert.Equal(t, s.Level(), st.Level)
+	assert.Equal(t, DefaultMaxLevel, st.MaxLevel)
+	assert.Equal(t, 0.25, st.Probability)
+	assert.Equal(t, s.EstimatedMemory(), st.EstimatedMemory)
+}
+
+func TestBucketCounts(t *testing.T) {
+	s := NewSkipList[string, int]()
+	for _, k := range []string{"a:1", "a:2", "b:1", "c:1", "c:2", "c:3"} {
+		s.Set(k, 0)
+	}
+	counts := s.BucketCounts(func(key string) string {
+		tenant, _, _ := strings.Cut(key, ":")
+		return tenant
+	})
+	assert.Equal(t, map[string]int{"a": 2, "b": 1, "c": 3}, counts)
+	assert.Empty(t, NewSkipList[string, int]().BucketCounts(strings.ToUpper))
+}
+
+// searchLength counts the links followed by Get to reach key.
+func searchLength(s *SkipList[int, int], key int) int {
+	n := 1
+	x := s.head
+	for i := s.Level() - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+			n++
+		}
+	}
+	return n
+}
+
+func TestAverageSearchLength(t *testing.T) {
+	assert.Zero(t, NewSkipList[int, int]().AverageSearchLength())
+	random := NewSkipList[int, int]()
+	for _, k := range makeRandomData(1000) {
+		random.Set(k, k)
+	}
+	for _, s := range []*SkipList[int, int]{createSkipList(example1), random} {
+		total := 0
+		for x := s.First(); x != nil; x = x.Next() {
+			total += searchLength(s, x.Key())
+		}
+		assert.InDelta(t, float64(total)/float64(s.Size()), s.AverageSearchLength(), 1e-9)
+	}
+}
+
+func TestMaintenanceReport(t *testing.T) {
+	// a degenerated list with level 1 only
+	s := NewSkipList[int, int](WithLevelFunc(func(float64, int) int { return 1 }))
+	for k := 0; k < 1000; k++ {
+		s.Set(k, k)
+	}
+	c, report := s.CompactWithReport()
+	assert.Equal(t, 1000, c.Size())
+	assert.Equal(t, "Compact", report.Operation)
+	assert.Equal(t, 1000, report.Size)
+	assert.Equal(t, 500.5, report.SearchLengthBefore)
+	assert.Less(t, report.SearchLengthAfter, 20.0)
+	assert.Equal(t, 1, report.LevelBefore)
+	assert.Equal(t, c.Level(), report.LevelAfter)
+	assert.Equal(t, s.MemoryUsage(), report.MemoryBefore)
+	assert.Equal(t, c.MemoryUsage(), report.MemoryAfter)
+	assert.Positive(t, report.Duration)
+
+	r := s.RebuildInBackground(context.Background())
+	s.Set(5000, 0)
+	require.NoError(t, r.Wait())
+	report = r.Report()
+	assert.Equal(t, "Rebuild", report.Operation)
+	assert.Equal(t, 1001, report.Size)
+	// the key 5000 was set before it was copied
+	assert.Equal(t, 501.0, report.SearchLengthBefore)
+	assert.Less(t, report.SearchLengthAfter, 20.0)
+	assert.Positive(t, report.Duration)
+}
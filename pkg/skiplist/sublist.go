@@ -0,0 +1,88 @@
+package skiplist
+
+import "cmp"
+
+// SubList is a read-through view on the elements of a SkipList with from <= key <= to. Positions are
+// relative to the bounds: position 0 is the first element within the bounds. The view reflects all
+// modifications of the parent list; the positions of the bounds are cached until the parent is modified.
+type SubList[K cmp.Ordered, V any] struct {
+	list     *SkipList[K, V]
+	from, to K
+	version  uint64 // version of the list when begin and end were computed
+	valid    bool
+	begin    int // position of the first element within the bounds
+	end      int // position behind the last element within the bounds
+}
+
+// SubList returns a view on all elements with from <= key <= to.
+func (s *SkipList[K, V]) SubList(from, to K) *SubList[K, V] {
+	return &SubList[K, V]{list: s, from: from, to: to}
+}
+
+// SubList returns a view on the elements of the view with from <= key <= to.
+func (v *SubList[K, V]) SubList(from, to K) *SubList[K, V] {
+	return v.list.SubList(v.list.laterKey(from, v.from), v.list.earlierKey(to, v.to))
+}
+
+// Bounds returns the inclusive key bounds of the view.
+func (v *SubList[K, V]) Bounds() (K, K) {
+	return v.from, v.to
+}
+
+// positions returns the position range [begin, end) of the view within the parent list.
+func (v *SubList[K, V]) positions() (int, int) {
+	if !v.valid || v.version != v.list.version {
+		_, v.begin = v.list.lowerBound(v.from)
+		_, v.end = v.list.upperBound(v.to)
+		v.end = max(v.begin, v.end)
+		v.version = v.list.version
+		v.valid = true
+	}
+	return v.begin, v.end
+}
+
+// Size returns the number of elements within the bounds.
+func (v *SubList[K, V]) Size() int {
+	begin, end := v.positions()
+	return end - begin
+}
+
+// First returns the first element within the bounds or nil if there is none.
+func (v *SubList[K, V]) First() *Node[K, V] {
+	return v.GetByPos(0)
+}
+
+// Last returns the last element within the bounds or nil if there is none.
+func (v *SubList[K, V]) Last() *Node[K, V] {
+	return v.GetByPos(v.Size() - 1)
+}
+
+// GetByPos returns the element at the position k in [0, Size()) relative to the bounds or nil if k is out
+// of range.
+func (v *SubList[K, V]) GetByPos(k int) *Node[K, V] {
+	begin, end := v.positions()
+	if k < 0 || begin+k >= end {
+		return nil
+	}
+	return v.list.GetByPos(begin + k)
+}
+
+// Get returns the node with `key` and its position relative to the bounds, or nil and InvalidPos if the key
+// was not found or is out of bounds.
+func (v *SubList[K, V]) Get(key K) (*Node[K, V], int) {
+	if v.list.less(key, v.from) || v.list.less(v.to, key) {
+		return nil, InvalidPos
+	}
+	x, pos := v.list.Get(key)
+	if x == nil {
+		return nil, InvalidPos
+	}
+	begin, _ := v.positions()
+	return x, pos - begin
+}
+
+// Iterator returns an iterator over the elements within the bounds. The positions reported by the iterator
+// are positions within the parent list.
+func (v *SubList[K, V]) Iterator(options ...IteratorOption) *Iterator[K, V] {
+	return v.list.IteratorRange(v.from, v.to, options...)
+}
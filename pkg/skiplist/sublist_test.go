@@ -0,0 +1,52 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubList(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 100; k += 10 {
+		s.Set(k, k)
+	}
+	v := s.SubList(15, 55)
+	assert.Equal(t, 4, v.Size())
+	assert.Equal(t, 20, v.First().Key())
+	assert.Equal(t, 50, v.Last().Key())
+	assert.Equal(t, 30, v.GetByPos(1).Key())
+	assert.Nil(t, v.GetByPos(4))
+	assert.Nil(t, v.GetByPos(-1))
+
+	x, pos := v.Get(40)
+	assert.Equal(t, 40, x.Key())
+	assert.Equal(t, 2, pos)
+	x, pos = v.Get(60)
+	assert.Nil(t, x)
+	assert.Equal(t, InvalidPos, pos)
+	x, _ = v.Get(41)
+	assert.Nil(t, x)
+
+	assert.Equal(t, []int{20, 30, 40, 50}, collectKeys(v.Iterator()))
+
+	// the view reads through to the parent
+	s.Set(15, 15)
+	s.Set(0, -1)
+	s.Remove(50)
+	assert.Equal(t, 4, v.Size())
+	assert.Equal(t, 15, v.First().Key())
+	assert.Equal(t, 40, v.Last().Key())
+
+	w := v.SubList(30, 1000)
+	from, to := w.Bounds()
+	assert.Equal(t, 30, from)
+	assert.Equal(t, 55, to)
+	assert.Equal(t, 2, w.Size())
+
+	empty := s.SubList(71, 79)
+	assert.Equal(t, 0, empty.Size())
+	assert.Nil(t, empty.First())
+	assert.Nil(t, empty.Last())
+	assert.Equal(t, 0, s.SubList(50, 10).Size())
+}
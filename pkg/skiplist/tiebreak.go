@@ -0,0 +1,26 @@
+package skiplist
+
+import "cmp"
+
+// WithTieBreak allows duplicates (see WithDuplicates) and orders the elements with equal keys by their values:
+// a new element is inserted behind all elements with an equal key except those whose value is greater, i.e.
+// less(new, existing) is true. Elements with equal keys and values not ordered by less keep their insertion
+// order, so the order of duplicates stays deterministic. less compares the values as passed in, also if they
+// are stored compressed (see WithValueCodec). InsertBefore and InsertAfter place elements regardless of the tie-break.
+// The type parameters are inferred from `less`.
+func WithTieBreak[K cmp.Ordered, V any](less func(a, b V) bool) Option {
+	return typedOption(func(s *SkipList[K, V]) {
+		s.duplicates = true
+		s.tieBreak = less
+	})
+}
+
+// precedes reports whether the node x precedes a new element with `key` and `value`. With duplicates a new
+// element follows all elements with an equal key in insertion order unless a tie-break orders it before them.
+// value is not stored yet; the tie-break compares it with the decoded value of x.
+func (s *SkipList[K, V]) precedes(x *Node[K, V], key K, value V) bool {
+	if s.less(x.key, key) {
+		return true
+	}
+	return s.duplicates && x.key == key && (s.tieBreak == nil || !s.tieBreak(value, s.ValueOf(x)))
+}
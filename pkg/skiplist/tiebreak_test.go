@@ -0,0 +1,82 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type prioritized struct {
+	prio int
+	seq  int
+}
+
+func byPrio(a, b prioritized) bool {
+	return a.prio < b.prio
+}
+
+func TestTieBreak(t *testing.T) {
+	s := NewSkipList[string, prioritized](WithTieBreak[string](byPrio))
+	s.Set("a", prioritized{2, 0})
+	s.Set("a", prioritized{1, 1})
+	s.Set("a", prioritized{2, 2})
+	s.Set("a", prioritized{3, 3})
+	s.Set("a", prioritized{1, 4})
+	s.Set("b", prioritized{0, 5})
+	require.NoError(t, s.Validate())
+	var seqs []int
+	for x := s.First(); x != nil; x = x.Next() {
+		seqs = append(seqs, x.Value.seq)
+	}
+	assert.Equal(t, []int{1, 4, 0, 2, 3, 5}, seqs)
+}
+
+func TestTieBreakBulk(t *testing.T) {
+	less := func(a, b int) bool { return a/10 < b/10 }
+	s := NewSkipList[int, int](WithTieBreak[int](less))
+	s.Load([]Pair[int, int]{{1, 25}, {1, 13}, {0, 7}, {1, 21}, {1, 10}})
+	require.NoError(t, s.Validate())
+	assert.Equal(t, []int{7, 13, 10, 25, 21}, valuesOf(s))
+
+	err := s.LoadSorted([]Pair[int, int]{{1, 13}, {1, 25}, {1, 10}})
+	var orderErr *OrderError
+	require.ErrorAs(t, err, &orderErr)
+	assert.Equal(t, 2, orderErr.Index)
+
+	m := NewSkipList[int, int](WithTieBreak[int](less))
+	m.Set(1, 15)
+	require.NoError(t, m.MergeSorted(pairSeq(0, 1), nil))
+	require.NoError(t, m.Validate())
+	assert.Equal(t, []int{0, 15, 10}, valuesOf(m))
+	require.ErrorAs(t, m.MergeSorted(func(yield func(int, int) bool) {
+		_ = yield(2, 30) && yield(2, 20)
+	}, nil), &orderErr)
+	assert.Equal(t, 1, orderErr.Index)
+}
+
+// mirror maps the letters a...z to z...a, which reverses the order of the stored values.
+func mirror(v string) string {
+	b := []byte(v)
+	for i, c := range b {
+		b[i] = 'a' + 'z' - c
+	}
+	return string(b)
+}
+
+func TestTieBreakCodec(t *testing.T) {
+	s := NewSkipList[int, string](WithValueCodec[int](mirror, mirror),
+		WithTieBreak[int](func(a, b string) bool { return a < b }))
+	for _, v := range []string{"b", "d", "a", "c"} {
+		s.Set(1, v)
+	}
+	require.NoError(t, s.MergeSorted(func(yield func(int, string) bool) {
+		_ = yield(1, "bb") && yield(1, "cc")
+	}, nil))
+	require.NoError(t, s.Validate())
+	var values []string
+	for _, v := range s.All() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []string{"a", "b", "bb", "c", "cc", "d"}, values)
+}
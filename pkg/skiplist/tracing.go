@@ -0,0 +1,31 @@
+package skiplist
+
+import "context"
+
+// Tracer is called at the start of a bulk or slow operation with the name of the operation and the number
+// of input elements. The returned function is called when the operation has finished with the number of
+// resulting elements. Operations running without a context pass context.Background().
+//
+// A Tracer maps directly onto span based tracing APIs like OpenTelemetry: start a span in the Tracer and
+// end it in the returned function.
+//
+// Traced operations are Load ("Load"), Compact and CompactInto ("Compact"), RebuildInBackground ("Rebuild",
+// ending with the cutover), the copy of nodes shared with a snapshot ("SnapshotCopy"), automatic evictions
+// ("Trim"), and the range deletes ExtractRange ("ExtractRange"), RemoveRangeByPos ("RemoveRange"), and
+// RemoveBelow ("RemoveBelow"). Evictions and range deletes end with the number of removed elements. The
+// package skiplistotel creates OpenTelemetry spans for them.
+type Tracer func(ctx context.Context, op string, size int) func(count int)
+
+// WithTracer registers a tracer for bulk and slow operations.
+func WithTracer(tracer Tracer) Option {
+	return func(c *config) {
+		c.tracer = tracer
+	}
+}
+
+func (s *SkipList[K, V]) trace(ctx context.Context, op string, size int) func(count int) {
+	if s.tracer == nil {
+		return func(int) {}
+	}
+	return s.tracer(ctx, op, size)
+}
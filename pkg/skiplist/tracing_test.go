@@ -0,0 +1,58 @@
+package skiplist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tracedOp struct {
+	op          string
+	size, count int
+}
+
+func TestTracer(t *testing.T) {
+	var ops []tracedOp
+	tracer := func(_ context.Context, op string, size int) func(int) {
+		return func(count int) { ops = append(ops, tracedOp{op, size, count}) }
+	}
+	s := NewSkipList[int, int](WithTracer(tracer))
+
+	s.Load([]Pair[int, int]{{3, 3}, {1, 1}, {2, 2}})
+	s.Compact()
+	snap := s.Snapshot()
+	s.Set(4, 4)
+	require.NoError(t, s.RebuildInBackground(context.Background()).Wait())
+	assert.Equal(t, []tracedOp{
+		{"Load", 3, 3},
+		{"Compact", 3, 3},
+		{"SnapshotCopy", 3, 3},
+		{"Rebuild", 4, 4},
+	}, ops)
+	assert.Equal(t, 3, snap.Size())
+
+	ops = nil
+	s.ExtractRange(2, 3)
+	s.RemoveRangeByPos(1, 5)
+	s.RemoveBelow(10)
+	assert.Equal(t, []tracedOp{
+		{"ExtractRange", 4, 2},
+		{"RemoveRange", 2, 1},
+		{"RemoveBelow", 1, 1},
+	}, ops)
+}
+
+func TestTracerRebuildContext(t *testing.T) {
+	type key struct{}
+	var got any
+	s := NewSkipList[int, int](WithTracer(func(ctx context.Context, op string, size int) func(int) {
+		got = ctx.Value(key{})
+		return func(int) {}
+	}))
+	s.Set(1, 1)
+	ctx := context.WithValue(context.Background(), key{}, "parent")
+	require.NoError(t, s.RebuildInBackground(ctx).Wait())
+	assert.Equal(t, "parent", got)
+}
@@ -0,0 +1,152 @@
+package skiplist
+
+import "cmp"
+
+// Tx groups modifications of one or more skip lists, e.g. of paired indexes like key -> value and
+// expiry -> key, so they are applied all or nothing. Every modification made through TxSet and TxRemove
+// records how to undo it; Rollback undoes them in reverse order. Use Transact to roll back automatically on
+// errors and panics.
+//
+// The lists must not be modified otherwise while the transaction is open. Elements removed by policies during a
+// modification (WithMemoryBudget, WithRetention) are not restored, but the rollback locates the modified
+// elements by their keys, so it is not confused by them. Rolled back elements get their previous values, soft
+// deleted flags, stable IDs, and modification times back, but their levels are drawn anew.
+type Tx struct {
+	undo []func()
+}
+
+// Transact runs fn with a new transaction. If fn returns an error or panics, all modifications made through
+// the transaction are rolled back and the error is returned or the panic continued.
+func Transact(fn func(tx *Tx) error) (err error) {
+	tx := &Tx{}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	committed = true
+	tx.Commit()
+	return nil
+}
+
+// Commit keeps all modifications and ends the transaction.
+func (tx *Tx) Commit() {
+	tx.undo = nil
+}
+
+// Rollback undoes all modifications in reverse order and ends the transaction.
+func (tx *Tx) Rollback() {
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+	tx.undo = nil
+}
+
+// TxSet sets the value of `key` like SkipList.TrySet as part of the transaction tx.
+func TxSet[K cmp.Ordered, V any](tx *Tx, s *SkipList[K, V], key K, value V) (*Node[K, V], int, bool, error) {
+	var old Node[K, V]
+	if !s.duplicates {
+		if x, _ := s.Get(key); x != nil {
+			old = *x
+		}
+	}
+	first := s.firstPos(key)
+	x, pos, created, err := s.TrySet(key, value)
+	if err != nil {
+		return x, pos, created, err
+	}
+	if created {
+		offset := s.keyOffset(pos, first)
+		tx.undo = append(tx.undo, func() { s.removeAt(key, offset) })
+	} else {
+		tx.undo = append(tx.undo, func() {
+			s.ensureOwned()
+			if x, _ := s.Get(key); x != nil {
+				x.Value = old.Value
+				s.markDeleted(x, old.deleted)
+				x.modified = old.modified
+			}
+		})
+	}
+	return x, pos, created, nil
+}
+
+// TxRemove removes the element with `key` like SkipList.Remove as part of the transaction tx.
+func TxRemove[K cmp.Ordered, V any](tx *Tx, s *SkipList[K, V], key K) (*Node[K, V], int) {
+	x, pos := s.Remove(key)
+	if x != nil {
+		// Remove removes the first node with the key
+		tx.undo = append(tx.undo, func() { s.restore(0, x) })
+	}
+	return x, pos
+}
+
+// Undoing a modification has to locate its element again. Positions are not stable, since the policies
+// (WithRetention, WithMemoryBudget) may remove other elements during a modification, so elements are located by
+// their key and, with duplicates, by their offset among the nodes with an equal key.
+
+// firstPos returns the position of the first node with `key` (or where it would be inserted) with
+// duplicates, otherwise 0. It must be taken before a modification to compute the offset of its position.
+func (s *SkipList[K, V]) firstPos(key K) int {
+	if !s.duplicates {
+		return 0
+	}
+	_, pos := s.lowerBound(key)
+	return pos
+}
+
+// keyOffset returns the offset of the position pos among the nodes with an equal key starting at `first`
+// (see firstPos). Without duplicates the offset is 0.
+func (s *SkipList[K, V]) keyOffset(pos, first int) int {
+	if !s.duplicates {
+		return 0
+	}
+	return pos - first
+}
+
+// nodeAt returns the node with `key` at `offset` among the nodes with an equal key and its position, or nil
+// and InvalidPos if there is no such node.
+func (s *SkipList[K, V]) nodeAt(key K, offset int) (*Node[K, V], int) {
+	x, pos := s.lowerBound(key)
+	if offset > 0 {
+		pos += offset
+		x = s.GetByPos(pos)
+	}
+	if x == nil || x.key != key {
+		return nil, InvalidPos
+	}
+	return x, pos
+}
+
+// removeAt removes the node with `key` at `offset` among the nodes with an equal key if it exists.
+func (s *SkipList[K, V]) removeAt(key K, offset int) *Node[K, V] {
+	if _, pos := s.nodeAt(key, offset); pos != InvalidPos {
+		return s.RemoveByPos(pos)
+	}
+	return nil
+}
+
+// restore links a removed node x again with its state at `offset` among the nodes with an equal key, or
+// behind them if there are fewer.
+func (s *SkipList[K, V]) restore(offset int, x *Node[K, V]) {
+	_, pos := s.lowerBound(x.key)
+	if offset > 0 {
+		_, end := s.upperBound(x.key)
+		pos = min(pos+offset, end)
+	}
+	y, err := s.linkAt(pos, x.key, x.Value)
+	if err != nil {
+		// the list was modified outside of the transaction
+		return
+	}
+	s.markDeleted(y, x.deleted)
+	y.modified = x.modified
+	s.setSeq(y, x.seq)
+	if s.stableIDs {
+		s.setID(y, x.id)
+	}
+}
@@ -0,0 +1,111 @@
+package skiplist
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransact(t *testing.T) {
+	values := NewSkipList[string, int](WithStableIDs())
+	byValue := NewSkipList[int, string](WithDuplicates())
+	for i, k := range []string{"a", "b", "c"} {
+		values.Set(k, i)
+		byValue.Set(i, k)
+	}
+	values.MarkDeleted("c")
+	x, _ := values.Get("b")
+	idB := x.ID()
+
+	// move "b" to the value 5 in both indexes and fail at the end
+	errFail := errors.New("fail")
+	err := Transact(func(tx *Tx) error {
+		TxRemove(tx, byValue, 1)
+		TxSet(tx, byValue, 5, "b")
+		TxSet(tx, values, "b", 5)
+		TxSet(tx, values, "c", 7)
+		TxSet(tx, values, "d", 9)
+		TxRemove(tx, values, "b")
+		return errFail
+	})
+	assert.ErrorIs(t, err, errFail)
+	assert.Equal(t, []int{0, 1, 2}, valuesOf(values))
+	assert.Equal(t, []string{"a", "b", "c"}, valuesOf(byValue))
+	x, _ = values.Get("c")
+	assert.True(t, x.Deleted())
+	assert.Equal(t, idB, values.GetByPos(1).ID())
+	require.NoError(t, values.Validate())
+	require.NoError(t, byValue.Validate())
+
+	// a panic rolls back as well
+	assert.Panics(t, func() {
+		_ = Transact(func(tx *Tx) error {
+			TxSet(tx, values, "e", 1)
+			panic("boom")
+		})
+	})
+	assert.Equal(t, 3, values.Size())
+
+	// committed
+	require.NoError(t, Transact(func(tx *Tx) error {
+		TxRemove(tx, byValue, 1)
+		TxSet(tx, byValue, 5, "b")
+		_, _, _, err := TxSet(tx, values, "b", 5)
+		return err
+	}))
+	assert.Equal(t, []int{0, 5, 2}, valuesOf(values))
+	assert.Equal(t, []string{"a", "c", "b"}, valuesOf(byValue))
+}
+
+func TestTxDuplicatesOrder(t *testing.T) {
+	s := NewSkipList[int, string](WithDuplicates())
+	s.Set(1, "x")
+	s.Set(1, "y")
+	s.Set(1, "z")
+	tx := &Tx{}
+	TxRemove(tx, s, 1)
+	TxRemove(tx, s, 1)
+	TxSet(tx, s, 1, "new")
+	assert.Equal(t, []string{"z", "new"}, valuesOf(s))
+	tx.Rollback()
+	assert.Equal(t, []string{"x", "y", "z"}, valuesOf(s))
+
+	// a rejected modification is not recorded
+	s = NewSkipList[int, string](WithAdmissionControl(func(k int, _ string, _ int) error {
+		if k < 0 {
+			return errors.New("negative")
+		}
+		return nil
+	}))
+	err := Transact(func(tx *Tx) error {
+		_, _, _, err := TxSet(tx, s, -1, "v")
+		return err
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 0, s.Size())
+}
+
+func TestTxRollbackAfterPruning(t *testing.T) {
+	now := time.Unix(1008, 0)
+	s := NewSkipList[int, int](WithRetention[int, int](10*time.Second, func() time.Time { return now },
+		func(k int) time.Time { return time.Unix(int64(k), 0) }), WithDuplicates())
+	for k := 1000; k <= 1008; k += 2 {
+		s.Set(k, 0)
+	}
+	s.Set(1006, 1)
+
+	// the insert prunes 1000 and shifts the positions
+	now = time.Unix(1011, 0)
+	tx := &Tx{}
+	_, pos, _, err := TxSet(tx, s, 1006, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 5, pos)
+	TxRemove(tx, s, 1004)
+	tx.Rollback()
+	assert.Equal(t, []int{1002, 1004, 1006, 1006, 1008}, collectKeys(s.Iterator()))
+	assert.Equal(t, []int{0, 0, 0, 1, 0}, valuesOf(s))
+	require.NoError(t, s.Validate())
+}
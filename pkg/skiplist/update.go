@@ -0,0 +1,42 @@
+package skiplist
+
+// UpdateRange replaces the value of every element with from <= key <= to by fn(key, value) in the order of the
+// list and returns the number of updated elements. Soft deleted elements are skipped. fn must not modify the
+// list.
+//
+// Updating values is not a structural modification: it neither invalidates paths nor triggers the iteration
+// guard. Unlike assigning Node.Value while iterating, UpdateRange first copies nodes shared with snapshots, so
+// snapshots keep their values, stores the values like Set (see WithValueCodec and WithValueCopier), and
+// records the modifications for modification times and running rebuilds.
+func (s *SkipList[K, V]) UpdateRange(from, to K, fn func(key K, value V) V) int {
+	n, _ := s.updateRange(from, to, -1, fn)
+	return n
+}
+
+// updateRange updates the values like UpdateRange, but stops after `limit` elements unless limit is negative.
+// The chunk is extended to all elements with the key of its last element. Returns the number of updated
+// elements and the next element of the range or nil if the range is done.
+func (s *SkipList[K, V]) updateRange(from, to K, limit int, fn func(key K, value V) V) (int, *Node[K, V]) {
+	s.lazyInit()
+	s.pollRebuild()
+	s.ensureOwned()
+	n := 0
+	var last *Node[K, V]
+	x, _ := s.lowerBound(from)
+	for ; x != nil && !s.less(to, x.key); x = x.Next() {
+		if n == limit && x.key != last.key {
+			return n, x
+		}
+		if x.deleted {
+			continue
+		}
+		s.touched(x.key)
+		x.Value = s.storeValue(fn(x.key, s.ValueOf(x)))
+		if s.modClock != nil {
+			s.stamp(x)
+		}
+		last = x
+		n++
+	}
+	return n, nil
+}
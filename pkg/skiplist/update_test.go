@@ -0,0 +1,75 @@
+package skiplist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRange(t *testing.T) {
+	s := NewSkipList[int, int](WithIterationGuard(nil))
+	for k := 0; k < 10; k++ {
+		s.Set(k, k)
+	}
+	s.MarkDeleted(5)
+	snap := s.Snapshot()
+
+	n := s.UpdateRange(3, 6, func(key, value int) int { return value * 10 })
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []int{0, 1, 2, 30, 40, 60, 7, 8, 9}, slices.Collect(s.Values()))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 6, 7, 8, 9}, slices.Collect(snap.Values()))
+	assert.Equal(t, 0, s.UpdateRange(20, 30, func(key, value int) int { return 0 }))
+	require.NoError(t, s.Validate())
+
+	// a value update is not a structural modification
+	path := s.FindPath(7)
+	s.UpdateRange(7, 7, func(key, value int) int { return -1 })
+	x, _, _, err := path.Set(70)
+	require.NoError(t, err)
+	assert.Equal(t, 70, x.Value)
+}
+
+func TestUpdateRangeCodec(t *testing.T) {
+	s := NewSkipList[int, []int](WithValueCopier[int, []int](func(v []int) []int { return append([]int(nil), v...) }))
+	s.Set(1, []int{1})
+	var seen []int
+	s.UpdateRange(1, 1, func(key int, value []int) []int {
+		seen = value
+		return append(value, 2)
+	})
+	seen[0] = 42
+	x, _ := s.Get(1)
+	assert.Equal(t, []int{1, 2}, x.Value)
+}
+
+func TestConcurrentUpdateRange(t *testing.T) {
+	c := NewConcurrentSkipList[int, int](WithDuplicates())
+	for k := 0; k < 1000; k++ {
+		c.Set(k/3, 0)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 1000, c.UpdateRange(0, 1000, func(key, value int) int { return value + 1 }))
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.Range(0, 1000, Fast, func(key, value int) bool {
+				assert.True(t, value >= 0 && value <= 4)
+				return true
+			})
+		}
+	}()
+	wg.Wait()
+	for _, v := range c.All(Consistent) {
+		assert.Equal(t, 4, v)
+	}
+}
@@ -0,0 +1,345 @@
+package skiplist
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// ErrCorrupted is returned (wrapped) when the skip list violates one of its structural invariants.
+var ErrCorrupted = errors.New("skiplist: corrupted structure")
+
+// WithAutoRepair enables the resilience mode: inconsistencies detected during an operation are logged and
+// repaired by SkipList.Repair() before the operation is retried. Without this option TrySet returns an error
+// wrapping ErrCorrupted for a detected inconsistency, and the other operations are not affected by the
+// detection.
+func WithAutoRepair() Option {
+	return func(c *config) {
+		c.autoRepair = true
+	}
+}
+
+// Validate checks all invariants of the skip list in O(n*L): the keys on level 0 are strictly ascending
+// (ascending if duplicates are allowed), the number of elements matches Size(), every level links exactly
+// the nodes having at least that level, all distances match the positions of the nodes, and the back links
+// (see WithBackPointers) point to the preceding nodes. Returns nil or an error wrapping ErrCorrupted.
+func (s *SkipList[K, V]) Validate() error {
+	n := 0
+	level := 0
+	var prev *Node[K, V]
+	for x := s.First(); x != nil; x = x.Next() {
+		if len(x.next) != len(x.dist) {
+			return fmt.Errorf("%w: node %v has %d pointers but %d distances", ErrCorrupted, x.key, len(x.next), len(x.dist))
+		}
+		if y := x.Next(); y != nil && !s.ordered(x.key, y.key) {
+			return fmt.Errorf("%w: keys %v and %v at position %d are not ascending", ErrCorrupted, x.key, y.key, n)
+		}
+		if s.backPointers && x.prev != prev {
+			return fmt.Errorf("%w: back link of node %v at position %d is broken", ErrCorrupted, x.key, n)
+		}
+		prev = x
+		level = max(level, x.Level())
+		n++
+	}
+	if n != s.count {
+		return fmt.Errorf("%w: found %d elements but size is %d", ErrCorrupted, n, s.count)
+	}
+	if level != s.Level() {
+		return fmt.Errorf("%w: highest node level is %d but head level is %d", ErrCorrupted, level, s.Level())
+	}
+
+	last := make([]*Node[K, V], level)
+	lastPos := make([]int, level)
+	for i := range last {
+		last[i] = s.head
+		lastPos[i] = -1
+	}
+	check := func(i int, next *Node[K, V], pos int) error {
+		if last[i].next[i] != next {
+			return fmt.Errorf("%w: broken chain on level %d after position %d", ErrCorrupted, i, lastPos[i])
+		}
+		if last[i].dist[i] != pos-lastPos[i] {
+			return fmt.Errorf("%w: distance on level %d at position %d is %d instead of %d",
+				ErrCorrupted, i, lastPos[i], last[i].dist[i], pos-lastPos[i])
+		}
+		last[i] = next
+		lastPos[i] = pos
+		return nil
+	}
+	pos := 0
+	for x := s.First(); x != nil; x = x.Next() {
+		for i := 0; i < x.Level(); i++ {
+			if err := check(i, x, pos); err != nil {
+				return err
+			}
+		}
+		pos++
+	}
+	for i := range last {
+		if err := check(i, nil, pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateParallel checks the same invariants as Validate, but splits the list into about `parts` chunks
+// which are checked concurrently. The chunks start at nodes of a high level, so the split costs only a walk
+// over a few nodes; the levels above are checked while splitting. Every finding is passed to report (if not
+// nil) as soon as it is found; the checks of a chunk stop at its first finding. The calls of report are
+// serialized. Returns one of the findings or nil.
+func (s *SkipList[K, V]) ValidateParallel(parts int, report func(err error)) error {
+	var mu sync.Mutex
+	var first error
+	found := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if first == nil {
+			first = err
+		}
+		if report != nil {
+			report(err)
+		}
+	}
+	if parts <= 1 || s.Level() == 0 {
+		if err := s.Validate(); err != nil {
+			found(err)
+		}
+		return first
+	}
+
+	// choose the highest level with enough nodes to split the list
+	top := s.Level() - 1
+	var bounds []*Node[K, V]
+	for ; top >= 0; top-- {
+		bounds = bounds[:0]
+		for x := s.head.next[top]; x != nil && len(x.dist) > top; x = x.next[top] {
+			bounds = append(bounds, x)
+		}
+		if len(bounds) >= parts {
+			break
+		}
+	}
+	top = max(top, 0)
+
+	// walk the chain of the split level: compute the chunk positions and check the levels above
+	chunks := []validateChunk[K, V]{{start: s.head, pos: -1}}
+	last := make([]*Node[K, V], s.Level())
+	lastPos := make([]int, s.Level())
+	for i := range last {
+		last[i] = s.head
+		lastPos[i] = -1
+	}
+	level := 0
+	pos := -1
+	broken := false
+	for x, i := s.head, 0; !broken; i++ {
+		if len(x.next) != len(x.dist) {
+			found(fmt.Errorf("%w: node %v has %d pointers but %d distances", ErrCorrupted, x.key, len(x.next),
+				len(x.dist)))
+			return first
+		}
+		next := x.next[top]
+		if next != nil {
+			pos += x.dist[top]
+			if len(next.dist) <= top {
+				found(fmt.Errorf("%w: broken chain on level %d after position %d", ErrCorrupted, top, pos))
+				return first
+			}
+			level = max(level, next.Level())
+			for j := top + 1; j < next.Level() && !broken; j++ {
+				if err := validateLink(last, lastPos, j, next, pos); err != nil {
+					found(err)
+					broken = true
+				}
+			}
+			if (i+1)%(len(bounds)/parts+1) == 0 {
+				chunks[len(chunks)-1].end, chunks[len(chunks)-1].endPos = next, pos
+				chunks = append(chunks, validateChunk[K, V]{start: next, pos: pos})
+			}
+		}
+		if next == nil {
+			break
+		}
+		x = next
+	}
+	chunks[len(chunks)-1].end, chunks[len(chunks)-1].endPos = nil, s.count
+	for j := top + 1; j < s.Level() && !broken; j++ {
+		if err := validateLink(last, lastPos, j, nil, s.count); err != nil {
+			found(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for c := range chunks {
+		wg.Add(1)
+		go func(c *validateChunk[K, V]) {
+			defer wg.Done()
+			if err := c.validate(s, top); err != nil {
+				found(err)
+			}
+		}(&chunks[c])
+	}
+	wg.Wait()
+
+	n := 0
+	for _, c := range chunks {
+		n += c.count
+		level = max(level, c.level)
+	}
+	if first == nil && n != s.count {
+		found(fmt.Errorf("%w: found %d elements but size is %d", ErrCorrupted, n, s.count))
+	}
+	if first == nil && level != s.Level() {
+		found(fmt.Errorf("%w: highest node level is %d but head level is %d", ErrCorrupted, level, s.Level()))
+	}
+	return first
+}
+
+// validateChunk is a part of the list checked by ValidateParallel: the nodes from start up to end
+// (exclusive) with the claimed positions pos and endPos.
+type validateChunk[K cmp.Ordered, V any] struct {
+	start, end  *Node[K, V]
+	pos, endPos int
+	count       int // number of nodes of the chunk without the head
+	level       int // highest level of the nodes of the chunk
+}
+
+// validate checks the chunk on the levels 0...top.
+func (c *validateChunk[K, V]) validate(s *SkipList[K, V], top int) error {
+	last := make([]*Node[K, V], top+1)
+	lastPos := make([]int, top+1)
+	for i := range last {
+		last[i] = c.start
+		lastPos[i] = c.pos
+	}
+	if c.start != s.head {
+		c.count++
+		c.level = c.start.Level()
+	}
+	prev := c.start
+	pos := c.pos
+	for x := c.start.Next(); ; x = x.Next() {
+		if x != nil && x != c.end && len(x.next) != len(x.dist) {
+			return fmt.Errorf("%w: node %v has %d pointers but %d distances", ErrCorrupted, x.key, len(x.next),
+				len(x.dist))
+		}
+		if x != nil && prev != s.head && !s.ordered(prev.key, x.key) {
+			return fmt.Errorf("%w: keys %v and %v at position %d are not ascending", ErrCorrupted, prev.key, x.key,
+				pos)
+		}
+		// the back link of the end node is checked here, as it points into this chunk
+		want := prev
+		if prev == s.head {
+			want = nil
+		}
+		if s.backPointers && x != nil && x.prev != want {
+			return fmt.Errorf("%w: back link of node %v at position %d is broken", ErrCorrupted, x.key, pos+1)
+		}
+		if x == c.end || x == nil {
+			break
+		}
+		pos++
+		c.count++
+		c.level = max(c.level, x.Level())
+		for i := 0; i < min(x.Level(), top+1); i++ {
+			if err := validateLink(last, lastPos, i, x, pos); err != nil {
+				return err
+			}
+		}
+		prev = x
+	}
+	if pos+1 != c.endPos && c.end != nil {
+		return fmt.Errorf("%w: found %d elements before position %d", ErrCorrupted, pos+1, c.endPos)
+	}
+	for i := range last {
+		if err := validateLink(last, lastPos, i, c.end, c.endPos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLink checks that the last visited node on level i links `next` at position pos and advances it.
+func validateLink[K cmp.Ordered, V any](last []*Node[K, V], lastPos []int, i int, next *Node[K, V], pos int) error {
+	if last[i].next[i] != next {
+		return fmt.Errorf("%w: broken chain on level %d after position %d", ErrCorrupted, i, lastPos[i])
+	}
+	if last[i].dist[i] != pos-lastPos[i] {
+		return fmt.Errorf("%w: distance on level %d at position %d is %d instead of %d",
+			ErrCorrupted, i, lastPos[i], last[i].dist[i], pos-lastPos[i])
+	}
+	last[i] = next
+	lastPos[i] = pos
+	return nil
+}
+
+// Repair rebuilds all level chains and distances by walking level 0, which is the only information
+// trusted. The size is set to the number of elements found on level 0. An error is returned if the
+// keys on level 0 are not strictly ascending, as this cannot be repaired locally.
+func (s *SkipList[K, V]) Repair() error {
+	s.ensureOwned()
+	level := 0
+	for x := s.First(); x != nil; x = x.Next() {
+		if y := x.Next(); y != nil && !s.ordered(x.key, y.key) {
+			return fmt.Errorf("%w: keys %v and %v are not ascending", ErrCorrupted, x.key, y.key)
+		}
+		if x.Level() > cap(s.head.next) {
+			return fmt.Errorf("%w: node %v exceeds the maximum level", ErrCorrupted, x.key)
+		}
+		level = max(level, x.Level())
+	}
+
+	first := s.First()
+	s.head.extendLevel(level)
+	s.head.shrinkLevel(level)
+	last := make([]*Node[K, V], level)
+	lastPos := make([]int, level)
+	for i := range last {
+		last[i] = s.head
+		lastPos[i] = -1
+	}
+	pos := 0
+	for x := first; x != nil; x = x.Next() {
+		if s.backPointers {
+			x.linkPrev(last[0], s.head)
+		}
+		for i := 0; i < x.Level(); i++ {
+			last[i].next[i] = x
+			last[i].dist[i] = pos - lastPos[i]
+			last[i] = x
+			lastPos[i] = pos
+		}
+		pos++
+	}
+	for i := range last {
+		last[i].next[i] = nil
+		last[i].dist[i] = pos - lastPos[i]
+	}
+	s.count = pos
+	s.version++
+	return nil
+}
+
+// ordered reports whether the key a may precede the key b.
+func (s *SkipList[K, V]) ordered(a, b K) bool {
+	return s.less(a, b) || s.duplicates && a == b
+}
+
+// corrupted handles an inconsistency detected by the operation `op`. In resilience mode the skip list is
+// repaired and nil is returned, so the operation can be retried. Otherwise, or if the repair fails, an error
+// wrapping ErrCorrupted is returned; operations which cannot return it continue as without the check.
+func (s *SkipList[K, V]) corrupted(op string) error {
+	err := fmt.Errorf("%w: inconsistent positions detected in %s", ErrCorrupted, op)
+	if !s.autoRepair {
+		return err
+	}
+	log.Printf("%v, repairing", err)
+	if rerr := s.Repair(); rerr != nil {
+		log.Print(rerr)
+		return rerr
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	s := NewSkipList[int, int]()
+	require.NoError(t, s.Validate())
+	for i, k := range makeRandomData(200) {
+		s.Set(k, k)
+		if i%2 == 0 {
+			s.Remove(k / 2)
+		}
+	}
+	require.NoError(t, s.Validate())
+
+	s = createSkipList(example1)
+	require.NoError(t, s.Validate())
+	s.head.dist[1]++
+	assert.ErrorIs(t, s.Validate(), ErrCorrupted)
+
+	s = createSkipList(example1)
+	s.count--
+	assert.ErrorIs(t, s.Validate(), ErrCorrupted)
+
+	s = createSkipList(example1)
+	x, _ := s.Get(6)
+	x.next[1] = x.next[1].next[1] // skip key 9 on level 1
+	assert.ErrorIs(t, s.Validate(), ErrCorrupted)
+}
+
+func TestRepair(t *testing.T) {
+	s := createSkipList(example1)
+	x, _ := s.Get(6)
+	x.next[1] = x.next[1].next[1]
+	x.dist[3] = 1
+	require.NoError(t, s.Repair())
+	require.NoError(t, s.Validate())
+	requireSameStructure(t, createSkipList(example1), s)
+
+	s = createSkipList(example1)
+	x, _ = s.Get(6)
+	x.key = 100
+	assert.ErrorIs(t, s.Repair(), ErrCorrupted)
+}
+
+func TestAutoRepair(t *testing.T) {
+	s := NewSkipList[int, int](WithLevelFunc(createPlayBackLevelFunc(example1)), WithAutoRepair())
+	for i, x := range example1 {
+		s.Set(x.key, i)
+	}
+	s.head.dist[3] = 1
+
+	x := s.GetByPos(9)
+	require.NotNil(t, x)
+	assert.Equal(t, 26, x.Key())
+	require.NoError(t, s.Validate())
+
+	s.head.dist[3] = 1
+	x = s.RemoveByPos(9)
+	require.NotNil(t, x)
+	assert.Equal(t, 26, x.Key())
+	require.NoError(t, s.Validate())
+
+	// without the resilience mode only TrySet reports the inconsistency
+	s = createSkipList(example1)
+	s.head.dist[3] = 1
+	assert.NotPanics(t, func() { s.GetByPos(9) })
+	s.count = 2
+	_, _, _, err := s.TrySet(100, 0)
+	assert.ErrorIs(t, err, ErrCorrupted)
+	assert.NotPanics(t, func() { s.Remove(100) })
+}
+
+func TestValidateParallel(t *testing.T) {
+	s := NewSkipList[int, int](WithBackPointers())
+	require.NoError(t, s.ValidateParallel(4, nil))
+	for _, k := range makeRandomData(5000) {
+		s.Set(k, k)
+	}
+	for _, parts := range []int{1, 2, 4, 16, 10000} {
+		require.NoError(t, s.ValidateParallel(parts, nil))
+	}
+
+	corruptions := []func(s *SkipList[int, int]){
+		func(s *SkipList[int, int]) { s.head.dist[1]++ },
+		func(s *SkipList[int, int]) { s.count-- },
+		func(s *SkipList[int, int]) { s.GetByPos(2500).key = -1 },
+		func(s *SkipList[int, int]) {
+			x := s.GetByPos(1000)
+			for x.Level() < 2 {
+				x = x.Next()
+			}
+			x.next[1] = x.next[1].next[1]
+		},
+		func(s *SkipList[int, int]) {
+			x := s.GetByPos(4000)
+			x.dist[0]++
+		},
+		func(s *SkipList[int, int]) {
+			x := s.GetByPos(3000)
+			x.next[0] = x.next[0].next[0]
+		},
+		func(s *SkipList[int, int]) { s.GetByPos(2000).prev = nil },
+		func(s *SkipList[int, int]) { s.First().prev = s.head },
+	}
+	for i, corrupt := range corruptions {
+		c := s.Compact()
+		corrupt(c)
+		assert.ErrorIs(t, c.Validate(), ErrCorrupted, "corruption %d", i)
+		var findings []error
+		err := c.ValidateParallel(8, func(err error) { findings = append(findings, err) })
+		assert.ErrorIs(t, err, ErrCorrupted, "corruption %d", i)
+		assert.NotEmpty(t, findings)
+	}
+
+	// the chunks start at nodes of a high level, so a broken back link of each of them must be found
+	for x := s.First(); x != nil; x = x.Next() {
+		if x.Level() < 3 {
+			continue
+		}
+		prev := x.prev
+		x.prev = x
+		assert.ErrorIs(t, s.ValidateParallel(8, nil), ErrCorrupted, "node %d", x.key)
+		x.prev = prev
+	}
+	require.NoError(t, s.ValidateParallel(8, nil))
+}
@@ -0,0 +1,14 @@
+package skiplist
+
+import "cmp"
+
+// WithValueCopier registers a function returning a deep copy of a value, e.g. slices.Clone or maps.Clone. Values
+// passed to Set, TrySet, Path.Set, InsertBefore, and InsertAfter are copied before they are stored, and GetCopy
+// returns a copy, so callers cannot modify stored values by keeping references to slices or maps. Node.Value
+// of nodes returned by other methods is not copied.
+// The type parameters are inferred from `copier`.
+func WithValueCopier[K cmp.Ordered, V any](copier func(V) V) Option {
+	return typedOption(func(s *SkipList[K, V]) {
+		s.copier = copier
+	})
+}
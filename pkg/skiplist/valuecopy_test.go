@@ -0,0 +1,40 @@
+package skiplist
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueCopier(t *testing.T) {
+	s := NewSkipList[int, []int](WithValueCopier[int, []int](slices.Clone[[]int]))
+	v := []int{1, 2}
+	s.Set(1, v)
+	v[0] = 100
+	got, ok := s.GetCopy(1)
+	require.True(t, ok)
+	assert.Equal(t, []int{1, 2}, got)
+	got[1] = 200
+	got, _ = s.GetCopy(1)
+	assert.Equal(t, []int{1, 2}, got)
+
+	v = []int{3}
+	_, _, _, err := s.FindPath(2).Set(v)
+	require.NoError(t, err)
+	x, _ := s.Get(1)
+	_, _, err = s.InsertAfter(x, 1, v)
+	assert.ErrorIs(t, err, ErrInvalidPlacement)
+	_, _, err = s.InsertBefore(x, 0, v)
+	require.NoError(t, err)
+	v[0] = 300
+	assert.Equal(t, [][]int{{3}, {1, 2}, {3}}, valuesOf(s))
+
+	// the copier is kept by Compact
+	c := s.Compact()
+	c.Set(5, v)
+	v[0] = 400
+	got, _ = c.GetCopy(5)
+	assert.Equal(t, []int{300}, got)
+}
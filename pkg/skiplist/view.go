@@ -0,0 +1,75 @@
+package skiplist
+
+import "cmp"
+
+// SortedView exposes a range of a SkipList as a read-only, slice-like sequence with random access by
+// index 0...Len()-1. It can be used with sort.Search and other code expecting indexed access without
+// copying the elements. Accessing the neighbor of the last accessed index costs O(1), all other accesses
+// O(log(n)) by GetByPos.
+//
+// The view becomes invalid when the underlying skip list is modified.
+type SortedView[K cmp.Ordered, V any] struct {
+	list      *SkipList[K, V]
+	offset    int         // position of index 0 within the skip list
+	n         int         // number of elements of the view
+	cache     *Node[K, V] // last accessed node
+	cacheIdx  int         // index of the last accessed node
+	cacheNext *Node[K, V] // successor of cache, used for sequential access
+}
+
+// AsSortedSlice returns a view on all elements of the skip list.
+func (s *SkipList[K, V]) AsSortedSlice() *SortedView[K, V] {
+	return &SortedView[K, V]{list: s, n: s.Size()}
+}
+
+// AsSortedSliceRange returns a view on all elements with from <= key <= to.
+func (s *SkipList[K, V]) AsSortedSliceRange(from, to K) *SortedView[K, V] {
+	_, begin := s.lowerBound(from)
+	_, end := s.upperBound(to)
+	return &SortedView[K, V]{list: s, offset: begin, n: max(0, end-begin)}
+}
+
+// Len returns the number of elements within the view.
+func (v *SortedView[K, V]) Len() int {
+	return v.n
+}
+
+// At returns the node at index i or nil if i is out of range.
+func (v *SortedView[K, V]) At(i int) *Node[K, V] {
+	if i < 0 || i >= v.n {
+		return nil
+	}
+	switch {
+	case v.cache != nil && i == v.cacheIdx:
+	case v.cache != nil && i == v.cacheIdx+1:
+		v.cache = v.cacheNext
+	default:
+		v.cache = v.list.GetByPos(v.offset + i)
+	}
+	v.cacheIdx = i
+	v.cacheNext = v.cache.Next()
+	return v.cache
+}
+
+// Key returns the key at index i. It panics if i is out of range.
+func (v *SortedView[K, V]) Key(i int) K {
+	return v.mustAt(i).key
+}
+
+// Value returns the value at index i. It panics if i is out of range.
+func (v *SortedView[K, V]) Value(i int) V {
+	return v.list.ValueOf(v.mustAt(i))
+}
+
+// Less reports whether the key at index i is smaller than the key at index j.
+func (v *SortedView[K, V]) Less(i, j int) bool {
+	return v.list.less(v.Key(i), v.Key(j))
+}
+
+func (v *SortedView[K, V]) mustAt(i int) *Node[K, V] {
+	x := v.At(i)
+	if x == nil {
+		panic("skiplist: SortedView index out of range")
+	}
+	return x
+}
@@ -0,0 +1,45 @@
+package skiplist
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedView(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for k := 0; k < 200; k += 2 {
+		s.Set(k, k*10)
+	}
+
+	v := s.AsSortedSlice()
+	assert.Equal(t, 100, v.Len())
+	assert.True(t, sort.IsSorted(sortInterface(v)))
+	for i := 0; i < v.Len(); i++ {
+		assert.Equal(t, 2*i, v.Key(i))
+	}
+	for i := v.Len() - 1; i >= 0; i-- {
+		assert.Equal(t, 20*i, v.Value(i))
+	}
+
+	idx := sort.Search(v.Len(), func(i int) bool { return v.Key(i) >= 51 })
+	assert.Equal(t, 26, idx)
+
+	r := s.AsSortedSliceRange(11, 20)
+	assert.Equal(t, 5, r.Len())
+	assert.Equal(t, 12, r.Key(0))
+	assert.Equal(t, 20, r.Key(4))
+	assert.Nil(t, r.At(5))
+	assert.Panics(t, func() { r.Key(-1) })
+
+	assert.Equal(t, 0, s.AsSortedSliceRange(20, 11).Len())
+}
+
+type sortView struct{ *SortedView[int, int] }
+
+func (sortView) Swap(i, j int) { panic("read-only") }
+
+func sortInterface(v *SortedView[int, int]) sort.Interface {
+	return sortView{v}
+}
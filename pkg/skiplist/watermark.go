@@ -0,0 +1,45 @@
+package skiplist
+
+import "log"
+
+// WithWatermarks calls fn when the size crosses the watermarks, e.g. to flush a memtable or to shed load:
+// fn(size, true) when a modification brings the size to `high` or above, and fn(size, false) when the size
+// afterwards drops to `low` or below. The gap between both watermarks is the hysteresis: after a crossing of
+// one watermark fn is not called again before the other one was crossed, so a size oscillating around a
+// watermark does not cause a storm of calls. fn is called within the modifying operation and must not modify
+// the list; it may e.g. signal a goroutine flushing the list.
+func WithWatermarks(low, high int, fn func(size int, high bool)) Option {
+	if low < 0 || high <= low {
+		log.Panic("Parameters low and high out of range (must be 0 <= low < high)")
+	}
+	return func(c *config) {
+		c.watermarks = &watermarks{low: low, high: high, fn: fn}
+	}
+}
+
+// watermarks holds the settings of WithWatermarks.
+type watermarks struct {
+	low, high int
+	fn        func(size int, high bool)
+}
+
+// AboveWatermark reports whether the high watermark was crossed and the low watermark was not crossed since
+// (see WithWatermarks).
+func (s *SkipList[K, V]) AboveWatermark() bool {
+	return s.aboveWatermark
+}
+
+// checkWatermarks calls the watermark function if the size crossed a watermark.
+func (s *SkipList[K, V]) checkWatermarks() {
+	w := s.watermarks
+	if w == nil {
+		return
+	}
+	if !s.aboveWatermark && s.count >= w.high {
+		s.aboveWatermark = true
+		w.fn(s.count, true)
+	} else if s.aboveWatermark && s.count <= w.low {
+		s.aboveWatermark = false
+		w.fn(s.count, false)
+	}
+}
@@ -0,0 +1,43 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatermarks(t *testing.T) {
+	type call struct {
+		size int
+		high bool
+	}
+	var calls []call
+	s := NewSkipList[int, int](WithWatermarks(2, 4, func(size int, high bool) {
+		calls = append(calls, call{size, high})
+	}))
+	for k := 0; k < 4; k++ {
+		s.Set(k, k)
+	}
+	assert.Equal(t, []call{{4, true}}, calls)
+	assert.True(t, s.AboveWatermark())
+
+	// oscillating between the watermarks does not call fn
+	s.Remove(3)
+	s.Set(3, 3)
+	s.Set(4, 4)
+	s.Remove(4)
+	s.Remove(3)
+	assert.Len(t, calls, 1)
+
+	s.RemoveByPos(0)
+	assert.Equal(t, []call{{4, true}, {2, false}}, calls)
+	assert.False(t, s.AboveWatermark())
+	s.Remove(1)
+	assert.Len(t, calls, 2)
+
+	s.Load([]Pair[int, int]{{1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}})
+	s.Clear()
+	assert.Equal(t, []call{{4, true}, {2, false}, {5, true}, {0, false}}, calls)
+
+	assert.Panics(t, func() { WithWatermarks(3, 3, nil) })
+}
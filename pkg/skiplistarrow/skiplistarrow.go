@@ -0,0 +1,356 @@
+// Package skiplistarrow exports key ranges of skip lists as Apache Arrow record batches in the IPC streaming
+// format, which is read by e.g. pyarrow.ipc.open_stream, Polars and DataFusion. Every record batch has two
+// non-nullable columns, the keys and the values. The keys are mapped to the Arrow type of their kind (see
+// WithKeyType), the values are converted by a Serializer. The messages are encoded with FlatBuffers following
+// the Arrow schema files Schema.fbs and Message.fbs, so no Arrow library is needed.
+package skiplistarrow
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log"
+	"math"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// ErrInvalidValue is returned (wrapped) when a serializer produces an element which does not fit its type.
+var ErrInvalidValue = errors.New("skiplistarrow: invalid serialized value")
+
+// ErrClosed is returned when a closed Writer is used.
+var ErrClosed = errors.New("skiplistarrow: writer closed")
+
+// constants of Message.fbs
+const (
+	metadataV5         = 4
+	headerSchema       = 1
+	headerRecordBatch  = 3
+	continuationMarker = 0xFFFFFFFF
+)
+
+type config struct {
+	batchSize  int
+	keyName    string
+	valueName  string
+	keyType    DataType
+	hasKeyType bool
+}
+
+// Option configures a Writer.
+type Option func(*config)
+
+// WithBatchSize sets the maximum number of rows per record batch. The default is 65536.
+func WithBatchSize(n int) Option {
+	if n <= 0 {
+		log.Panic("Parameter n out of range (must be > 0)")
+	}
+	return func(c *config) {
+		c.batchSize = n
+	}
+}
+
+// WithFieldNames sets the names of the key and the value column. The default is "key" and "value".
+func WithFieldNames(key, value string) Option {
+	return func(c *config) {
+		c.keyName, c.valueName = key, value
+	}
+}
+
+// WithKeyType overrides the Arrow type of the keys, which must have the same size as the mapped type, e.g.
+// Timestamp(Nanosecond, "UTC") for int64 keys holding Unix times in nanoseconds.
+func WithKeyType(t DataType) Option {
+	return func(c *config) {
+		c.keyType, c.hasKeyType = t, true
+	}
+}
+
+// column collects the buffers of a column of a record batch.
+type column struct {
+	typ     DataType
+	offsets []byte // int32 offsets of variable size types
+	data    []byte
+}
+
+func (c *column) reset() {
+	c.data = c.data[:0]
+	c.offsets = c.offsets[:0]
+	if c.typ.width == 0 {
+		c.offsets = binary.LittleEndian.AppendUint32(c.offsets, 0)
+	}
+}
+
+// appendElement appends the element of v and checks it against the type of the column.
+func appendElement[T any](c *column, s Serializer[T], v T) error {
+	start := len(c.data)
+	c.data = s.Append(c.data, v)
+	if c.typ.width > 0 {
+		if len(c.data)-start != c.typ.width {
+			return fmt.Errorf("%w: %d bytes instead of %d", ErrInvalidValue, len(c.data)-start, c.typ.width)
+		}
+		return nil
+	}
+	if len(c.data) > math.MaxInt32 {
+		return fmt.Errorf("%w: column data exceeds 2 GiB, use a smaller batch size", ErrInvalidValue)
+	}
+	c.offsets = binary.LittleEndian.AppendUint32(c.offsets, uint32(len(c.data)))
+	return nil
+}
+
+// buffers returns the buffers of the column in the order of the Arrow layout, starting with the empty
+// validity bitmap.
+func (c *column) buffers() [][]byte {
+	if c.typ.width == 0 {
+		return [][]byte{nil, c.offsets, c.data}
+	}
+	return [][]byte{nil, c.data}
+}
+
+// Writer writes skip list elements as an Arrow IPC stream. The schema is written before the first record
+// batch; Close terminates the stream.
+type Writer[K cmp.Ordered, V any] struct {
+	w       *bufio.Writer
+	config  config
+	keys    Serializer[K]
+	values  Serializer[V]
+	key     column
+	value   column
+	rows    int
+	b       *flatbuffers.Builder
+	started bool
+	closed  bool
+}
+
+// NewWriter creates a Writer of an Arrow IPC stream to w converting the values by `values`.
+func NewWriter[K cmp.Ordered, V any](w io.Writer, values Serializer[V], options ...Option) *Writer[K, V] {
+	c := config{batchSize: 1 << 16, keyName: "key", valueName: "value"}
+	for _, o := range options {
+		o(&c)
+	}
+	keys := keySerializer[K]()
+	if c.hasKeyType {
+		if c.keyType.width != keys.Type.width {
+			log.Panic("Parameter keyType out of range (must have the size of the key type)")
+		}
+		keys.Type = c.keyType
+	}
+	wr := &Writer[K, V]{
+		w:      bufio.NewWriter(w),
+		config: c,
+		keys:   keys,
+		values: values,
+		key:    column{typ: keys.Type},
+		value:  column{typ: values.Type},
+		b:      flatbuffers.NewBuilder(1024),
+	}
+	wr.key.reset()
+	wr.value.reset()
+	return wr
+}
+
+// WriteRange writes the elements of s with from <= key <= to in ascending order as record batches of up to
+// the batch size (see WithBatchSize). Soft deleted elements are skipped.
+func (w *Writer[K, V]) WriteRange(s *skiplist.SkipList[K, V], from, to K) error {
+	return w.write(s.RangeSeq(from, to))
+}
+
+// WriteAll writes all elements of s like WriteRange.
+func (w *Writer[K, V]) WriteAll(s *skiplist.SkipList[K, V]) error {
+	return w.write(s.All())
+}
+
+func (w *Writer[K, V]) write(seq iter.Seq2[K, V]) error {
+	if w.closed {
+		return ErrClosed
+	}
+	if err := w.writeSchema(); err != nil {
+		return err
+	}
+	for key, value := range seq {
+		if err := appendElement(&w.key, w.keys, key); err != nil {
+			return err
+		}
+		if err := appendElement(&w.value, w.values, value); err != nil {
+			return err
+		}
+		w.rows++
+		if w.rows == w.config.batchSize {
+			if err := w.writeBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	if w.rows > 0 {
+		if err := w.writeBatch(); err != nil {
+			return err
+		}
+	}
+	return w.w.Flush()
+}
+
+// Close writes the schema if no elements were written and the end of the stream. It does not close the
+// underlying writer.
+func (w *Writer[K, V]) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+	if err := w.writeSchema(); err != nil {
+		return err
+	}
+	var eos [8]byte
+	binary.LittleEndian.PutUint32(eos[:], continuationMarker)
+	if _, err := w.w.Write(eos[:]); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+// writeSchema writes the schema message once.
+func (w *Writer[K, V]) writeSchema() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	b := w.b
+	b.Reset()
+	keyField := buildField(b, w.config.keyName, w.keys.Type)
+	valueField := buildField(b, w.config.valueName, w.values.Type)
+	b.StartVector(flatbuffers.SizeUOffsetT, 2, flatbuffers.SizeUOffsetT)
+	b.PrependUOffsetT(valueField)
+	b.PrependUOffsetT(keyField)
+	fields := b.EndVector(2)
+	b.StartObject(2)
+	b.PrependUOffsetTSlot(1, fields, 0) // endianness defaults to little endian
+	return w.writeMessage(headerSchema, b.EndObject(), nil)
+}
+
+// buildField builds a non-nullable Field table without children.
+func buildField(b *flatbuffers.Builder, name string, t DataType) flatbuffers.UOffsetT {
+	n := b.CreateString(name)
+	typ := buildType(b, t)
+	b.StartVector(flatbuffers.SizeUOffsetT, 0, flatbuffers.SizeUOffsetT)
+	children := b.EndVector(0)
+	b.StartObject(7)
+	b.PrependUOffsetTSlot(0, n, 0)
+	b.PrependByteSlot(2, t.id, 0)
+	b.PrependUOffsetTSlot(3, typ, 0)
+	b.PrependUOffsetTSlot(5, children, 0)
+	return b.EndObject()
+}
+
+// buildType builds the table of the Type union.
+func buildType(b *flatbuffers.Builder, t DataType) flatbuffers.UOffsetT {
+	switch t.id {
+	case typeInt:
+		b.StartObject(2)
+		b.PrependInt32Slot(0, int32(8*t.width), 0)
+		b.PrependBoolSlot(1, t.signed, false)
+	case typeFloat:
+		b.StartObject(1)
+		b.PrependInt16Slot(0, int16(t.width/4), 0) // SINGLE = 1, DOUBLE = 2
+	case typeTimestamp:
+		var tz flatbuffers.UOffsetT
+		if t.timezone != "" {
+			tz = b.CreateString(t.timezone)
+		}
+		b.StartObject(2)
+		b.PrependInt16Slot(0, int16(t.unit), 0)
+		if tz != 0 {
+			b.PrependUOffsetTSlot(1, tz, 0)
+		}
+	default:
+		b.StartObject(0)
+	}
+	return b.EndObject()
+}
+
+// writeBatch writes the collected rows as a record batch and resets the columns.
+func (w *Writer[K, V]) writeBatch() error {
+	body := append(w.key.buffers(), w.value.buffers()...)
+	b := w.b
+	b.Reset()
+	b.StartVector(16, len(body), 8)
+	offset := int64(0)
+	for i := range body {
+		offset += padded(len(body[i]))
+	}
+	for i := len(body) - 1; i >= 0; i-- {
+		offset -= padded(len(body[i]))
+		b.Prep(8, 16)
+		b.PrependInt64(int64(len(body[i])))
+		b.PrependInt64(offset)
+	}
+	buffers := b.EndVector(len(body))
+	b.StartVector(16, 2, 8)
+	for i := 0; i < 2; i++ {
+		b.Prep(8, 16)
+		b.PrependInt64(0) // null count
+		b.PrependInt64(int64(w.rows))
+	}
+	nodes := b.EndVector(2)
+	b.StartObject(3)
+	b.PrependInt64Slot(0, int64(w.rows), 0)
+	b.PrependUOffsetTSlot(1, nodes, 0)
+	b.PrependUOffsetTSlot(2, buffers, 0)
+	if err := w.writeMessage(headerRecordBatch, b.EndObject(), body); err != nil {
+		return err
+	}
+	w.rows = 0
+	w.key.reset()
+	w.value.reset()
+	return nil
+}
+
+// writeMessage finishes a Message table with the header and writes it followed by the body, each buffer
+// padded to a multiple of 8 bytes.
+func (w *Writer[K, V]) writeMessage(headerType byte, header flatbuffers.UOffsetT, body [][]byte) error {
+	bodyLength := int64(0)
+	for _, buf := range body {
+		bodyLength += padded(len(buf))
+	}
+	b := w.b
+	b.StartObject(5)
+	b.PrependInt16Slot(0, metadataV5, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, bodyLength, 0)
+	b.Finish(b.EndObject())
+	meta := b.FinishedBytes()
+
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[:], continuationMarker)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(padded(len(meta))))
+	if _, err := w.w.Write(prefix[:]); err != nil {
+		return err
+	}
+	if err := w.writePadded(meta); err != nil {
+		return err
+	}
+	for _, buf := range body {
+		if err := w.writePadded(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Writer[K, V]) writePadded(buf []byte) error {
+	var zeros [8]byte
+	if _, err := w.w.Write(buf); err != nil {
+		return err
+	}
+	_, err := w.w.Write(zeros[:padded(len(buf))-int64(len(buf))])
+	return err
+}
+
+// padded returns n rounded up to a multiple of 8.
+func padded(n int) int64 {
+	return int64(n+7) &^ 7
+}
@@ -0,0 +1,195 @@
+package skiplistarrow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// message is a decoded message of an Arrow IPC stream.
+type message struct {
+	headerType byte
+	header     flatbuffers.Table
+	body       []byte
+}
+
+// field returns the table of the field at slot i of tab or false if it is missing.
+func field(tab flatbuffers.Table, slot int) (flatbuffers.Table, bool) {
+	o := flatbuffers.UOffsetT(tab.Offset(flatbuffers.VOffsetT(4 + 2*slot)))
+	if o == 0 {
+		return flatbuffers.Table{}, false
+	}
+	return flatbuffers.Table{Bytes: tab.Bytes, Pos: tab.Indirect(tab.Pos + o)}, true
+}
+
+// readStream decodes the messages of an Arrow IPC stream up to the end of stream marker.
+func readStream(t *testing.T, buf []byte) []message {
+	t.Helper()
+	var msgs []message
+	for {
+		require.GreaterOrEqual(t, len(buf), 8)
+		require.Equal(t, uint32(continuationMarker), binary.LittleEndian.Uint32(buf))
+		size := int(binary.LittleEndian.Uint32(buf[4:]))
+		if size == 0 {
+			assert.Len(t, buf, 8)
+			return msgs
+		}
+		require.Zero(t, size%8)
+		meta := buf[8 : 8+size]
+		tab := flatbuffers.Table{Bytes: meta, Pos: flatbuffers.GetUOffsetT(meta)}
+		assert.Equal(t, int16(metadataV5), tab.GetInt16Slot(4, 0))
+		bodyLength := int(tab.GetInt64Slot(10, 0))
+		header, ok := field(tab, 2)
+		require.True(t, ok)
+		msgs = append(msgs, message{
+			headerType: tab.GetByteSlot(6, 0),
+			header:     header,
+			body:       buf[8+size : 8+size+bodyLength],
+		})
+		buf = buf[8+size+bodyLength:]
+	}
+}
+
+// schemaFields returns the names and the type ids of the fields of a schema.
+func schemaFields(schema flatbuffers.Table) ([]string, []byte) {
+	var names []string
+	var types []byte
+	o := flatbuffers.UOffsetT(schema.Offset(6))
+	vec := schema.Vector(o)
+	for i := 0; i < schema.VectorLen(o); i++ {
+		f := flatbuffers.Table{Bytes: schema.Bytes}
+		f.Pos = f.Indirect(vec + flatbuffers.UOffsetT(i)*flatbuffers.SizeUOffsetT)
+		names = append(names, string(f.ByteVector(flatbuffers.UOffsetT(f.Offset(4))+f.Pos)))
+		types = append(types, f.GetByteSlot(8, 0))
+	}
+	return names, types
+}
+
+// batchBuffers returns the number of rows and the buffers of a record batch.
+func batchBuffers(t *testing.T, m message) (int, [][]byte) {
+	t.Helper()
+	require.Equal(t, byte(headerRecordBatch), m.headerType)
+	rows := int(m.header.GetInt64Slot(4, 0))
+	o := flatbuffers.UOffsetT(m.header.Offset(8))
+	vec := m.header.Vector(o)
+	var buffers [][]byte
+	for i := 0; i < m.header.VectorLen(o); i++ {
+		p := vec + flatbuffers.UOffsetT(16*i)
+		offset := m.header.GetInt64(p)
+		length := m.header.GetInt64(p + 8)
+		require.Zero(t, offset%8)
+		buffers = append(buffers, m.body[offset:offset+length])
+	}
+	return rows, buffers
+}
+
+// variableElements splits the data buffer of a Utf8 or Binary column by its offsets.
+func variableElements(offsets, data []byte) []string {
+	var elements []string
+	for i := 4; i < len(offsets); i += 4 {
+		start := binary.LittleEndian.Uint32(offsets[i-4:])
+		end := binary.LittleEndian.Uint32(offsets[i:])
+		elements = append(elements, string(data[start:end]))
+	}
+	return elements
+}
+
+func TestWriteRange(t *testing.T) {
+	s := skiplist.NewSkipList[int32, string]()
+	for k := int32(0); k < 10; k++ {
+		s.Set(k, string(rune('a'+k)))
+	}
+	s.MarkDeleted(5)
+
+	var buf bytes.Buffer
+	w := NewWriter[int32, string](&buf, Strings[string](), WithBatchSize(3), WithFieldNames("id", "name"))
+	require.NoError(t, w.WriteRange(s, 2, 8))
+	require.NoError(t, w.Close())
+	assert.ErrorIs(t, w.WriteAll(s), ErrClosed)
+
+	msgs := readStream(t, buf.Bytes())
+	require.Len(t, msgs, 3)
+	require.Equal(t, byte(headerSchema), msgs[0].headerType)
+	names, types := schemaFields(msgs[0].header)
+	assert.Equal(t, []string{"id", "name"}, names)
+	assert.Equal(t, []byte{typeInt, typeUtf8}, types)
+
+	var keys []int32
+	var values []string
+	for _, m := range msgs[1:] {
+		rows, buffers := batchBuffers(t, m)
+		require.Len(t, buffers, 5)
+		assert.Empty(t, buffers[0])
+		assert.Len(t, buffers[1], 4*rows)
+		for i := 0; i < rows; i++ {
+			keys = append(keys, int32(binary.LittleEndian.Uint32(buffers[1][4*i:])))
+		}
+		assert.Empty(t, buffers[2])
+		values = append(values, variableElements(buffers[3], buffers[4])...)
+	}
+	assert.Equal(t, []int32{2, 3, 4, 6, 7, 8}, keys)
+	assert.Equal(t, []string{"c", "d", "e", "g", "h", "i"}, values)
+}
+
+func TestWriteTypes(t *testing.T) {
+	s := skiplist.NewSkipList[int64, float32]()
+	s.Set(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixNano(), 1.5)
+
+	var buf bytes.Buffer
+	w := NewWriter[int64, float32](&buf, Floats[float32](), WithKeyType(Timestamp(Nanosecond, "UTC")))
+	require.NoError(t, w.WriteAll(s))
+	require.NoError(t, w.Close())
+	msgs := readStream(t, buf.Bytes())
+	require.Len(t, msgs, 2)
+	_, types := schemaFields(msgs[0].header)
+	assert.Equal(t, []byte{typeTimestamp, typeFloat}, types)
+	_, buffers := batchBuffers(t, msgs[1])
+	assert.Equal(t, s.First().Key(), int64(binary.LittleEndian.Uint64(buffers[1])))
+	assert.Equal(t, float32(1.5), math.Float32frombits(binary.LittleEndian.Uint32(buffers[3])))
+
+	assert.Panics(t, func() { NewWriter[int32, float32](&buf, Floats[float32](), WithKeyType(Float(64))) })
+	assert.Equal(t, Int(16, false), Ints[uint16]().Type)
+	assert.Equal(t, Int(64, true), keySerializer[int]().Type)
+	assert.Equal(t, Utf8(), keySerializer[string]().Type)
+	assert.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0}, Times(Second).Append(nil, time.Unix(1, 0)))
+}
+
+func TestWriteEncoded(t *testing.T) {
+	type point struct{ X, Y int }
+	s := skiplist.NewSkipList[string, point]()
+	s.Set("a", point{1, 2})
+
+	var buf bytes.Buffer
+	w := NewWriter[string, point](&buf, Encoded(func(dst []byte, p point) []byte {
+		b, _ := json.Marshal(p)
+		return append(dst, b...)
+	}))
+	require.NoError(t, w.Close())
+	assert.Len(t, readStream(t, buf.Bytes()), 1)
+
+	buf.Reset()
+	w = NewWriter[string, point](&buf, Encoded(func(dst []byte, p point) []byte {
+		b, _ := json.Marshal(p)
+		return append(dst, b...)
+	}))
+	require.NoError(t, w.WriteAll(s))
+	require.NoError(t, w.Close())
+	msgs := readStream(t, buf.Bytes())
+	_, buffers := batchBuffers(t, msgs[1])
+	assert.Equal(t, []string{"a"}, variableElements(buffers[1], buffers[2]))
+	assert.Equal(t, []string{`{"X":1,"Y":2}`}, variableElements(buffers[4], buffers[5]))
+
+	// fixed size columns reject elements of a wrong size
+	bad := Serializer[point]{Type: Int(32, true), Append: func(dst []byte, p point) []byte { return dst }}
+	w = NewWriter[string, point](&buf, bad)
+	assert.ErrorIs(t, w.WriteAll(s), ErrInvalidValue)
+}
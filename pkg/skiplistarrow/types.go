@@ -0,0 +1,186 @@
+package skiplistarrow
+
+import (
+	"cmp"
+	"log"
+	"math"
+	"reflect"
+	"time"
+)
+
+// TimeUnit is the unit of an Arrow timestamp.
+type TimeUnit int16
+
+const (
+	Second TimeUnit = iota
+	Millisecond
+	Microsecond
+	Nanosecond
+)
+
+// ids of the Arrow types within the Type union of Schema.fbs
+const (
+	typeInt       = 2
+	typeFloat     = 3
+	typeBinary    = 4
+	typeUtf8      = 5
+	typeTimestamp = 10
+)
+
+// DataType is the Arrow data type of a column.
+type DataType struct {
+	id       byte
+	width    int // size of an element in bytes, 0 for variable size types
+	signed   bool
+	unit     TimeUnit
+	timezone string
+}
+
+// Int returns the Arrow integer type of bitWidth 8, 16, 32 or 64 bits.
+func Int(bitWidth int, signed bool) DataType {
+	if bitWidth != 8 && bitWidth != 16 && bitWidth != 32 && bitWidth != 64 {
+		log.Panic("Parameter bitWidth out of range (must be 8, 16, 32 or 64)")
+	}
+	return DataType{id: typeInt, width: bitWidth / 8, signed: signed}
+}
+
+// Float returns the Arrow floating point type of bitWidth 32 or 64 bits.
+func Float(bitWidth int) DataType {
+	if bitWidth != 32 && bitWidth != 64 {
+		log.Panic("Parameter bitWidth out of range (must be 32 or 64)")
+	}
+	return DataType{id: typeFloat, width: bitWidth / 8}
+}
+
+// Utf8 returns the Arrow string type.
+func Utf8() DataType {
+	return DataType{id: typeUtf8}
+}
+
+// Binary returns the Arrow type of variable size byte strings.
+func Binary() DataType {
+	return DataType{id: typeBinary}
+}
+
+// Timestamp returns the Arrow timestamp type, which is stored as a 64 bit count of units since the Unix epoch.
+// An empty timezone denotes wall clock times without a timezone.
+func Timestamp(unit TimeUnit, timezone string) DataType {
+	if unit < Second || unit > Nanosecond {
+		log.Panic("Parameter unit out of range (must be Second, Millisecond, Microsecond or Nanosecond)")
+	}
+	return DataType{id: typeTimestamp, width: 8, signed: true, unit: unit, timezone: timezone}
+}
+
+// Serializer converts values of type T into the elements of an Arrow column of the type Type.
+type Serializer[T any] struct {
+	Type DataType
+	// Append appends the element of v to dst and returns the extended slice: exactly the width of Type in
+	// little endian byte order for fixed size types, or the bytes of the element for Utf8 and Binary.
+	Append func(dst []byte, v T) []byte
+}
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Ints returns the serializer of integers into the Arrow integer type of the same size and signedness.
+func Ints[T integer]() Serializer[T] {
+	var zero T
+	width := int(reflect.TypeOf(zero).Size())
+	return Serializer[T]{
+		Type: Int(8*width, ^zero < 0),
+		Append: func(dst []byte, v T) []byte {
+			return appendLittleEndian(dst, uint64(v), width)
+		},
+	}
+}
+
+// Floats returns the serializer of floating point numbers into the Arrow type of the same precision.
+func Floats[T ~float32 | ~float64]() Serializer[T] {
+	var zero T
+	if reflect.TypeOf(zero).Size() == 4 {
+		return Serializer[T]{Type: Float(32), Append: func(dst []byte, v T) []byte {
+			return appendLittleEndian(dst, uint64(math.Float32bits(float32(v))), 4)
+		}}
+	}
+	return Serializer[T]{Type: Float(64), Append: func(dst []byte, v T) []byte {
+		return appendLittleEndian(dst, math.Float64bits(float64(v)), 8)
+	}}
+}
+
+// Strings returns the serializer of strings into the Arrow Utf8 type.
+func Strings[T ~string]() Serializer[T] {
+	return Serializer[T]{Type: Utf8(), Append: func(dst []byte, v T) []byte {
+		return append(dst, v...)
+	}}
+}
+
+// Bytes returns the serializer of byte slices into the Arrow Binary type.
+func Bytes[T ~[]byte]() Serializer[T] {
+	return Serializer[T]{Type: Binary(), Append: func(dst []byte, v T) []byte {
+		return append(dst, v...)
+	}}
+}
+
+// Times returns the serializer of times into Arrow timestamps in UTC.
+func Times(unit TimeUnit) Serializer[time.Time] {
+	return Serializer[time.Time]{
+		Type: Timestamp(unit, "UTC"),
+		Append: func(dst []byte, t time.Time) []byte {
+			var v int64
+			switch unit {
+			case Second:
+				v = t.Unix()
+			case Millisecond:
+				v = t.UnixMilli()
+			case Microsecond:
+				v = t.UnixMicro()
+			default:
+				v = t.UnixNano()
+			}
+			return appendLittleEndian(dst, uint64(v), 8)
+		},
+	}
+}
+
+// Encoded returns a serializer storing the values encoded by `encode` (e.g. as JSON or Protocol Buffers) in
+// an Arrow Binary column. encode appends the encoding of v to dst and returns the extended slice.
+func Encoded[T any](encode func(dst []byte, v T) []byte) Serializer[T] {
+	return Serializer[T]{Type: Binary(), Append: encode}
+}
+
+// keySerializer returns the serializer mapping the keys to the Arrow type of their kind: integers and floating
+// point numbers of the same size and strings as Utf8.
+func keySerializer[K cmp.Ordered]() Serializer[K] {
+	t := reflect.TypeFor[K]()
+	width := int(t.Size())
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Serializer[K]{Type: Int(8*width, true), Append: func(dst []byte, k K) []byte {
+			return appendLittleEndian(dst, uint64(reflect.ValueOf(k).Int()), width)
+		}}
+	case reflect.Float32:
+		return Serializer[K]{Type: Float(32), Append: func(dst []byte, k K) []byte {
+			return appendLittleEndian(dst, uint64(math.Float32bits(float32(reflect.ValueOf(k).Float()))), 4)
+		}}
+	case reflect.Float64:
+		return Serializer[K]{Type: Float(64), Append: func(dst []byte, k K) []byte {
+			return appendLittleEndian(dst, math.Float64bits(reflect.ValueOf(k).Float()), 8)
+		}}
+	case reflect.String:
+		return Serializer[K]{Type: Utf8(), Append: func(dst []byte, k K) []byte {
+			return append(dst, reflect.ValueOf(k).String()...)
+		}}
+	default:
+		return Serializer[K]{Type: Int(8*width, false), Append: func(dst []byte, k K) []byte {
+			return appendLittleEndian(dst, reflect.ValueOf(k).Uint(), width)
+		}}
+	}
+}
+
+func appendLittleEndian(dst []byte, v uint64, width int) []byte {
+	for i := 0; i < width; i++ {
+		dst = append(dst, byte(v>>(8*i)))
+	}
+	return dst
+}
@@ -0,0 +1,113 @@
+// Package skiplistfb exports skip lists to FlatBuffers, a cross-language zero-copy format, following the
+// schema skiplist.fbs shipped with this package. Non-Go consumers generate their readers from the schema.
+package skiplistfb
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"sort"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// FileIdentifier is the FlatBuffers file identifier of exported snapshots.
+const FileIdentifier = "SKPL"
+
+// ErrInvalidSnapshot is returned when a buffer does not contain an exported snapshot.
+var ErrInvalidSnapshot = errors.New("skiplistfb: invalid snapshot")
+
+// vtable offsets of the fields (see skiplist.fbs)
+const (
+	entryKey        = 4
+	entryValue      = 6
+	snapshotName    = 4
+	snapshotEntries = 6
+)
+
+// Export serializes all elements of the skip list which are not soft deleted into a FlatBuffers snapshot.
+// The keys are encoded by `keys`, the values by `values`.
+func Export[K cmp.Ordered, V any](s *skiplist.SkipList[K, V], keys skiplist.OrderPreservingCodec[K],
+	values func(V) []byte) []byte {
+	b := flatbuffers.NewBuilder(1024)
+	entries := make([]flatbuffers.UOffsetT, 0, s.LiveSize())
+	var key []byte
+	for it := s.Iterator(); it.Next(); {
+		key = keys.AppendKey(key[:0], it.Node().Key())
+		k := b.CreateByteVector(key)
+		v := b.CreateByteVector(values(it.Node().Value))
+		b.StartObject(2)
+		b.PrependUOffsetTSlot(0, k, 0)
+		b.PrependUOffsetTSlot(1, v, 0)
+		entries = append(entries, b.EndObject())
+	}
+	b.StartVector(flatbuffers.SizeUOffsetT, len(entries), flatbuffers.SizeUOffsetT)
+	for i := len(entries) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(entries[i])
+	}
+	vec := b.EndVector(len(entries))
+	name := b.CreateString(s.Name())
+	b.StartObject(2)
+	b.PrependUOffsetTSlot(0, name, 0)
+	b.PrependUOffsetTSlot(1, vec, 0)
+	b.FinishWithFileIdentifier(b.EndObject(), []byte(FileIdentifier))
+	return b.FinishedBytes()
+}
+
+// Snapshot reads an exported snapshot without copying it.
+type Snapshot struct {
+	tab flatbuffers.Table
+}
+
+// ReadSnapshot returns a reader of the snapshot in buf.
+func ReadSnapshot(buf []byte) (*Snapshot, error) {
+	if len(buf) < flatbuffers.SizeUOffsetT+len(FileIdentifier) ||
+		!flatbuffers.BufferHasIdentifier(buf, FileIdentifier) {
+		return nil, ErrInvalidSnapshot
+	}
+	pos := flatbuffers.GetUOffsetT(buf)
+	if int(pos) >= len(buf) {
+		return nil, ErrInvalidSnapshot
+	}
+	return &Snapshot{tab: flatbuffers.Table{Bytes: buf, Pos: pos}}, nil
+}
+
+// Name returns the name of the exported skip list (see skiplist.WithName).
+func (s *Snapshot) Name() string {
+	if o := flatbuffers.UOffsetT(s.tab.Offset(snapshotName)); o != 0 {
+		return string(s.tab.ByteVector(o + s.tab.Pos))
+	}
+	return ""
+}
+
+// Len returns the number of entries.
+func (s *Snapshot) Len() int {
+	if o := flatbuffers.UOffsetT(s.tab.Offset(snapshotEntries)); o != 0 {
+		return s.tab.VectorLen(o)
+	}
+	return 0
+}
+
+// Entry returns the encoded key and value of entry i in [0, Len()). The slices point into the buffer.
+func (s *Snapshot) Entry(i int) (key, value []byte) {
+	o := flatbuffers.UOffsetT(s.tab.Offset(snapshotEntries))
+	e := flatbuffers.Table{Bytes: s.tab.Bytes}
+	e.Pos = e.Indirect(s.tab.Vector(o) + flatbuffers.UOffsetT(i)*flatbuffers.SizeUOffsetT)
+	if o := flatbuffers.UOffsetT(e.Offset(entryKey)); o != 0 {
+		key = e.ByteVector(o + e.Pos)
+	}
+	if o := flatbuffers.UOffsetT(e.Offset(entryValue)); o != 0 {
+		value = e.ByteVector(o + e.Pos)
+	}
+	return key, value
+}
+
+// Search returns the index of the first entry with an encoded key >= key, or Len() if there is none.
+func (s *Snapshot) Search(key []byte) int {
+	return sort.Search(s.Len(), func(i int) bool {
+		k, _ := s.Entry(i)
+		return bytes.Compare(k, key) >= 0
+	})
+}
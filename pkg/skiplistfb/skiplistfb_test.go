@@ -0,0 +1,53 @@
+package skiplistfb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+func TestExport(t *testing.T) {
+	s := skiplist.NewSkipList[int, string](skiplist.WithName("scores"))
+	for k := -50; k < 50; k++ {
+		s.Set(k, "v")
+	}
+	s.MarkDeleted(0)
+	codec := skiplist.IntCodec[int]{}
+
+	buf := Export(s, codec, func(v string) []byte { return []byte(v) })
+	snap, err := ReadSnapshot(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "scores", snap.Name())
+	assert.Equal(t, 99, snap.Len())
+
+	for i := 0; i < snap.Len(); i++ {
+		key, value := snap.Entry(i)
+		k, _, err := codec.DecodeKey(key)
+		require.NoError(t, err)
+		x := s.GetByPos(i)
+		if i >= 50 {
+			x = s.GetByPos(i + 1)
+		}
+		assert.Equal(t, x.Key(), k)
+		assert.Equal(t, "v", string(value))
+	}
+
+	assert.Equal(t, 40, snap.Search(codec.AppendKey(nil, -10)))
+	assert.Equal(t, 50, snap.Search(codec.AppendKey(nil, 0)))
+	assert.Equal(t, 99, snap.Search(codec.AppendKey(nil, 100)))
+}
+
+func TestExportEmpty(t *testing.T) {
+	buf := Export(skiplist.NewSkipList[string, int](), skiplist.StringCodec[string]{},
+		func(int) []byte { return nil })
+	snap, err := ReadSnapshot(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "", snap.Name())
+	assert.Equal(t, 0, snap.Len())
+
+	_, err = ReadSnapshot([]byte("garbage!"))
+	assert.ErrorIs(t, err, ErrInvalidSnapshot)
+}
@@ -0,0 +1,39 @@
+// Package skiplistotel creates OpenTelemetry spans for the bulk and slow operations of skip lists, e.g. loads,
+// compactions, rebuilds, snapshot copies, evictions, and range deletes (see skiplist.Tracer). It is a module
+// of its own, so skip lists do not depend on OpenTelemetry:
+//
+//	s := skiplist.NewSkipList[string, int](skiplist.WithName("orders"),
+//		skiplist.WithTracer(skiplistotel.Tracer(otel.Tracer("orders"), attribute.String("skiplist.name", "orders"))))
+package skiplistotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// Attribute keys of the spans.
+const (
+	// SizeKey is the number of input elements of an operation.
+	SizeKey = attribute.Key("skiplist.size")
+	// CountKey is the number of resulting elements, or of removed elements for evictions and range deletes.
+	CountKey = attribute.Key("skiplist.count")
+)
+
+// Tracer returns a skiplist.Tracer starting an internal span "skiplist.<op>" of tracer for every traced
+// operation, e.g. "skiplist.Load". The span is a child of the span in the context of the operation, which is
+// the context passed to RebuildInBackground for rebuilds. attrs are added to every span, e.g. the name of the
+// list.
+func Tracer(tracer trace.Tracer, attrs ...attribute.KeyValue) skiplist.Tracer {
+	return func(ctx context.Context, op string, size int) func(count int) {
+		_, span := tracer.Start(ctx, "skiplist."+op, trace.WithSpanKind(trace.SpanKindInternal),
+			trace.WithAttributes(attrs...), trace.WithAttributes(SizeKey.Int(size)))
+		return func(count int) {
+			span.SetAttributes(CountKey.Int(count))
+			span.End()
+		}
+	}
+}
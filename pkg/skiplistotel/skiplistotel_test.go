@@ -0,0 +1,47 @@
+package skiplistotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	name := attribute.String("skiplist.name", "orders")
+	s := skiplist.NewSkipList[int, int](skiplist.WithTracer(Tracer(provider.Tracer("test"), name)))
+
+	s.Load([]skiplist.Pair[int, int]{{Key: 3, Value: 3}, {Key: 1, Value: 1}, {Key: 2, Value: 2}})
+	s.RemoveBelow(2)
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	require.NoError(t, s.RebuildInBackground(ctx).Wait())
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	for i, want := range []struct {
+		name        string
+		size, count int
+	}{
+		{"skiplist.Load", 3, 3},
+		{"skiplist.RemoveBelow", 3, 1},
+		{"skiplist.Rebuild", 2, 2},
+	} {
+		span := spans[i]
+		assert.Equal(t, want.name, span.Name())
+		assert.Equal(t, trace.SpanKindInternal, span.SpanKind())
+		assert.ElementsMatch(t, []attribute.KeyValue{name, SizeKey.Int(want.size), CountKey.Int(want.count)},
+			span.Attributes())
+	}
+	assert.False(t, spans[0].Parent().IsValid())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[2].Parent().SpanID())
+}
@@ -0,0 +1,100 @@
+// Package skiplisttest provides helpers for testing code using skip lists: builders with deterministic
+// levels, structure assertions against golden strings, and invariant checks.
+package skiplisttest
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/andremueller/goskiplist/pkg/skiplist"
+)
+
+// FixedLevels returns a skiplist.LevelFunc which returns the given levels one after the other instead of
+// random levels. It panics if more levels are requested than given.
+func FixedLevels(levels ...int) skiplist.LevelFunc {
+	pos := -1
+	return func(p float64, maxLevel int) int {
+		pos++
+		if pos >= len(levels) {
+			panic("skiplisttest: no more fixed levels")
+		}
+		return levels[pos]
+	}
+}
+
+// FromKeys creates a skip list containing the keys in the given order. The value of each key is its index
+// within `keys`.
+func FromKeys[K cmp.Ordered](keys ...K) *skiplist.SkipList[K, int] {
+	s := skiplist.NewSkipList[K, int]()
+	for i, k := range keys {
+		s.Set(k, i)
+	}
+	return s
+}
+
+// FromKeysWithLevels creates a skip list like FromKeys, but the ith inserted key gets the level levels[i].
+// This allows building exactly the same structure in every test run.
+func FromKeysWithLevels[K cmp.Ordered](keys []K, levels []int) *skiplist.SkipList[K, int] {
+	s := skiplist.NewSkipList[K, int](skiplist.WithLevelFunc(FixedLevels(levels...)))
+	for i, k := range keys {
+		s.Set(k, i)
+	}
+	return s
+}
+
+// Structure returns a compact description of the skip list structure: the keys in ascending order with
+// their levels like "3:1 6:4 7:1". Together with valid distances it determines the structure completely.
+func Structure[K cmp.Ordered, V any](s *skiplist.SkipList[K, V]) string {
+	var b strings.Builder
+	for x := s.First(); x != nil; x = x.Next() {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%v:%d", x.Key(), x.Level())
+	}
+	return b.String()
+}
+
+// AssertStructure reports an error if the structure of the skip list does not match the golden
+// description (see Structure) or if the skip list is invalid.
+func AssertStructure[K cmp.Ordered, V any](t testing.TB, s *skiplist.SkipList[K, V], golden string) bool {
+	t.Helper()
+	if !AssertValid(t, s) {
+		return false
+	}
+	if actual := Structure(s); actual != golden {
+		t.Errorf("unexpected skip list structure\nexpected: %s\nactual:   %s", golden, actual)
+		return false
+	}
+	return true
+}
+
+// AssertValid reports an error if the skip list violates one of its invariants (see SkipList.Validate).
+func AssertValid[K cmp.Ordered, V any](t testing.TB, s *skiplist.SkipList[K, V]) bool {
+	t.Helper()
+	if err := s.Validate(); err != nil {
+		t.Errorf("invalid skip list: %v", err)
+		return false
+	}
+	return true
+}
+
+// AssertKeys reports an error if the skip list does not contain exactly the given keys in ascending order.
+func AssertKeys[K cmp.Ordered, V any](t testing.TB, s *skiplist.SkipList[K, V], keys ...K) bool {
+	t.Helper()
+	i := 0
+	for x := s.First(); x != nil; x = x.Next() {
+		if i >= len(keys) || x.Key() != keys[i] {
+			t.Errorf("unexpected key %v at position %d", x.Key(), i)
+			return false
+		}
+		i++
+	}
+	if i != len(keys) {
+		t.Errorf("expected %d keys, found %d", len(keys), i)
+		return false
+	}
+	return true
+}
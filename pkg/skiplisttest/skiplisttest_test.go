@@ -0,0 +1,36 @@
+package skiplisttest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromKeysWithLevels(t *testing.T) {
+	// example of Figure 1 of "A skip list cookbook"
+	keys := []int{3, 6, 7, 9, 12, 17, 19, 21, 25, 26}
+	levels := []int{1, 4, 1, 2, 1, 2, 1, 1, 3, 1}
+	s := FromKeysWithLevels(keys, levels)
+
+	assert.True(t, AssertStructure(t, s, "3:1 6:4 7:1 9:2 12:1 17:2 19:1 21:1 25:3 26:1"))
+	assert.True(t, AssertKeys(t, s, keys...))
+	assert.Equal(t, 4, s.Level())
+
+	assert.False(t, AssertStructure(&testing.T{}, s, "3:1"))
+	assert.False(t, AssertKeys(&testing.T{}, s, 3, 6))
+}
+
+func TestFromKeys(t *testing.T) {
+	s := FromKeys("b", "c", "a")
+	assert.True(t, AssertValid(t, s))
+	assert.True(t, AssertKeys(t, s, "a", "b", "c"))
+	x, _ := s.Get("c")
+	assert.Equal(t, 1, x.Value)
+}
+
+func TestFixedLevels(t *testing.T) {
+	f := FixedLevels(2, 1)
+	assert.Equal(t, 2, f(0.5, 10))
+	assert.Equal(t, 1, f(0.5, 10))
+	assert.Panics(t, func() { f(0.5, 10) })
+}